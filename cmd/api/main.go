@@ -4,17 +4,24 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
 
 	"github.com/MrKriegler/go-insurance/internal/core"
+	transportgraphql "github.com/MrKriegler/go-insurance/internal/graphql"
+	transportgrpc "github.com/MrKriegler/go-insurance/internal/grpc"
 	transporthttp "github.com/MrKriegler/go-insurance/internal/http"
 	healthhttp "github.com/MrKriegler/go-insurance/internal/http/health"
 
@@ -23,9 +30,15 @@ import (
 	"github.com/MrKriegler/go-insurance/internal/http/handlers"
 	"github.com/MrKriegler/go-insurance/internal/jobs"
 	"github.com/MrKriegler/go-insurance/internal/middleware"
+	"github.com/MrKriegler/go-insurance/internal/platform/auth"
 	"github.com/MrKriegler/go-insurance/internal/platform/config"
 	"github.com/MrKriegler/go-insurance/internal/platform/logging"
+	"github.com/MrKriegler/go-insurance/internal/platform/metrics"
+	"github.com/MrKriegler/go-insurance/internal/platform/ratelimit"
+	"github.com/MrKriegler/go-insurance/internal/platform/search"
+	"github.com/MrKriegler/go-insurance/internal/platform/tracing"
 	"github.com/MrKriegler/go-insurance/internal/store/dynamo"
+	"github.com/MrKriegler/go-insurance/internal/store/memory"
 	"github.com/MrKriegler/go-insurance/internal/store/mongo"
 )
 
@@ -37,14 +50,33 @@ type Pinger interface {
 func main() {
 	// --- Config & Logger ---
 	cfg := config.MustLoad()
-	log := logging.New(cfg.Env)
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.LogLevel)
+	log := logging.New(cfg.Env, logLevel)
 	addr := fmt.Sprintf(":%s", cfg.Port)
-	log.Info("starting server", "addr", addr, "env", cfg.Env, "db_type", cfg.DBType)
+	log.Info("starting server", "addr", addr, "env", cfg.Env, "db_type", cfg.DBType, "auth_mode", cfg.AuthMode)
 
 	// Root ctx with SIGINT/SIGTERM
 	rootCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	// --- Tracing: spans go nowhere unless an exporter is configured ---
+	shutdownTracing, err := tracing.Setup(rootCtx, tracing.Config{
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		OTLPInsecure: cfg.TracingOTLPInsecure,
+		SampleRatio:  float64(cfg.TracingSamplePercent) / 100,
+		ServiceName:  cfg.TracingServiceName,
+		Environment:  cfg.Env,
+	})
+	if err != nil {
+		log.Error("tracing setup failed", "err", err)
+		os.Exit(1)
+	}
+
+	// --- Health: probes and the readiness report ---
+	hc := healthhttp.New(log, time.Duration(cfg.HealthCheckTimeoutMs)*time.Millisecond)
+
 	// --- Initialize based on DB type ---
 	var (
 		productRepo core.ProductRepo
@@ -53,7 +85,16 @@ func main() {
 		uwRepo      core.UnderwritingRepo
 		offerRepo   core.OfferRepo
 		policyRepo  core.PolicyRepo
+		apiKeyRepo  core.APIKeyRepo
+		idemRepo    core.IdempotencyRepo
+		leaseRepo   core.LeaseRepo
+		jobRepo     core.JobRepo
+		runRepo     core.ScheduledRunRepo
+		auditRepo   core.AuditRepo
+		searchIndex core.SearchIndex
 		pinger      Pinger
+		checkSchema func(ctx context.Context) (any, error)
+		watchJobs   func(ctx context.Context, notify func(core.JobKind))
 	)
 
 	if cfg.DBType == "dynamodb" {
@@ -83,7 +124,19 @@ func main() {
 		uwRepo = dynamo.NewUnderwritingRepo(dynamoClient.DB)
 		offerRepo = dynamo.NewOfferRepo(dynamoClient.DB)
 		policyRepo = dynamo.NewPolicyRepo(dynamoClient.DB)
+		apiKeyRepo = dynamo.NewAPIKeyRepo(dynamoClient.DB)
+		idemRepo = dynamo.NewIdempotencyRepo(dynamoClient.DB)
+		leaseRepo = dynamo.NewLeaseRepo(dynamoClient.DB)
+		jobRepo = dynamo.NewJobRepo(dynamoClient.DB)
+		runRepo = dynamo.NewScheduledRunRepo(dynamoClient.DB)
+		auditRepo = dynamo.NewAuditRepo(dynamoClient.DB)
 		pinger = dynamoClient
+		checkSchema = func(ctx context.Context) (any, error) {
+			return dynamo.CheckTables(ctx, dynamoClient.DB)
+		}
+		watchJobs = func(ctx context.Context, notify func(core.JobKind)) {
+			dynamo.WatchQueuedJobs(ctx, dynamoClient, log, notify)
+		}
 
 	} else {
 		// --- MongoDB ---
@@ -113,15 +166,106 @@ func main() {
 		uwRepo = mongo.NewUnderwritingRepo(mongoClient.DB, opTimeout)
 		offerRepo = mongo.NewOfferRepo(mongoClient.DB, opTimeout)
 		policyRepo = mongo.NewPolicyRepo(mongoClient.DB, opTimeout)
+		apiKeyRepo = mongo.NewAPIKeyRepo(mongoClient.DB, opTimeout)
+		idemRepo = mongo.NewIdempotencyRepo(mongoClient.DB, opTimeout)
+		leaseRepo = mongo.NewLeaseRepo(mongoClient.DB, opTimeout)
+		jobRepo = mongo.NewJobRepo(mongoClient.DB, opTimeout)
+		runRepo = mongo.NewScheduledRunRepo(mongoClient.DB, opTimeout)
+		auditRepo = mongo.NewAuditRepo(mongoClient.DB, opTimeout)
+		if cfg.SearchIndex == "db" {
+			mongoSearch := mongo.NewSearchIndex(mongoClient.DB, opTimeout)
+			// First start with the index: fill it from existing records
+			if empty, err := mongoSearch.Empty(rootCtx); err == nil && empty {
+				go rebuildSearch(rootCtx, mongoSearch, appRepo, policyRepo, log, hc.Starting("search_index"))
+			}
+			searchIndex = mongoSearch
+		}
 		pinger = mongoClient
+		checkSchema = func(ctx context.Context) (any, error) {
+			return mongo.CheckIndexes(ctx, mongoClient.DB)
+		}
+		watchJobs = func(ctx context.Context, notify func(core.JobKind)) {
+			mongo.WatchQueuedJobs(ctx, mongoClient.DB, log, notify)
+		}
+	}
+
+	// --- Underwriting work queue ---
+	uwCfg := core.UWConfig{
+		SLA:                time.Duration(cfg.UWSLAHours) * time.Hour,
+		EscalationAssignee: cfg.UWEscalationAssignee,
+	}
+	for _, spec := range cfg.UWAssignmentRules {
+		rule, err := core.ParseUWAssignmentRule(spec)
+		if err != nil {
+			log.Error("invalid UW_ASSIGNMENT_RULES", "err", err)
+			os.Exit(1)
+		}
+		uwCfg.Rules = append(uwCfg.Rules, rule)
+	}
+	for _, spec := range cfg.UWAuthorityLimits {
+		auth, err := core.ParseUWAuthority(spec)
+		if err != nil {
+			log.Error("invalid UW_AUTHORITY_LIMITS", "err", err)
+			os.Exit(1)
+		}
+		uwCfg.Authorities = append(uwCfg.Authorities, auth)
+	}
+
+	// --- Search index, kept current by application and policy writes ---
+	if searchIndex == nil {
+		mem := search.NewMemoryIndex()
+		go rebuildSearch(rootCtx, mem, appRepo, policyRepo, log, hc.Starting("search_index"))
+		if cfg.SearchRebuildMinutes > 0 {
+			search.StartRebuilding(rootCtx, mem, appRepo, policyRepo,
+				time.Duration(cfg.SearchRebuildMinutes)*time.Minute, log)
+		}
+		searchIndex = mem
 	}
+	appRepo = search.Applications(appRepo, searchIndex, log)
+	policyRepo = search.Policies(policyRepo, searchIndex, log)
+
+	// Count business outcomes as they are stored
+	quoteRepo = metrics.Quotes(quoteRepo)
+	uwRepo = metrics.Underwriting(uwRepo)
+	offerRepo = metrics.Offers(offerRepo)
+	policyRepo = metrics.Policies(policyRepo)
+
+	// Queueing a job wakes its worker instead of waiting for the next poll
+	notifier := jobs.NewNotifier()
+	jobRepo = jobs.Notifying(jobRepo, notifier)
+
+	// Queued jobs remember the request's trace, for the worker's span to link to
+	jobRepo = tracing.Jobs(jobRepo)
 
 	// --- Services ---
 	quoteService := core.NewQuoteService(productRepo, quoteRepo)
-	appService := core.NewApplicationService(appRepo, quoteRepo)
-	offerService := core.NewOfferService(offerRepo, appRepo)
-	policyService := core.NewPolicyService(policyRepo, offerRepo, appRepo)
-	uwService := core.NewUnderwritingService(uwRepo, appRepo, offerRepo)
+	appService := core.NewApplicationService(appRepo, quoteRepo, jobRepo, auditRepo)
+	offerService := core.NewOfferService(offerRepo, appRepo, jobRepo, auditRepo)
+	policyService := core.NewPolicyService(policyRepo, offerRepo, appRepo, auditRepo)
+	uwService := core.NewUnderwritingService(uwRepo, appRepo, offerRepo, auditRepo, uwCfg)
+	apiKeyService := core.NewAPIKeyService(apiKeyRepo)
+	searchService := core.NewSearchService(searchIndex)
+	jobService := core.NewJobService(jobRepo)
+	maintenanceService := core.NewMaintenanceService(offerRepo, quoteRepo, policyRepo, runRepo, auditRepo)
+
+	// A span per service call, for every transport and worker
+	quoteService = tracing.Quotes(quoteService)
+	appService = tracing.Applications(appService)
+	offerService = tracing.Offers(offerService)
+	policyService = tracing.Policies(policyService)
+	uwService = tracing.Underwriting(uwService)
+	apiKeyService = tracing.APIKeys(apiKeyService)
+	searchService = tracing.Search(searchService)
+	jobService = tracing.JobAdmin(jobService)
+	maintenanceService = tracing.Maintenance(maintenanceService)
+
+	// Make the configured API_KEY usable as an admin key on first start
+	if cfg.APIKey != "" {
+		if err := apiKeyService.Bootstrap(rootCtx, "bootstrap", cfg.APIKey); err != nil {
+			log.Error("bootstrap api key failed", "err", err)
+			os.Exit(1)
+		}
+	}
 
 	// --- Handlers ---
 	productsH := handlers.NewProductHandler(productRepo, log)
@@ -130,22 +274,122 @@ func main() {
 	uwH := handlers.NewUWHandler(uwService, log)
 	offersH := handlers.NewOfferHandler(offerService, log)
 	policiesH := handlers.NewPolicyHandler(policyService, log)
+	apiKeysH := handlers.NewAPIKeyHandler(apiKeyService, log)
+	searchH := handlers.NewSearchHandler(searchService, log)
+	jobsH := handlers.NewJobHandler(jobService, log)
+	runsH := handlers.NewScheduledRunHandler(maintenanceService, log)
+	logLevelH := handlers.NewLogLevelHandler(logLevel, log)
+	graphqlH := transportgraphql.NewHandler(transportgraphql.Deps{
+		Applications:     appService,
+		Underwriting:     uwService,
+		Offers:           offerService,
+		Policies:         policyService,
+		Products:         productRepo,
+		Quotes:           quoteRepo,
+		ApplicationRepo:  appRepo,
+		UnderwritingRepo: uwRepo,
+		OfferRepo:        offerRepo,
+		PolicyRepo:       policyRepo,
+	}, transportgraphql.Limits{
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+	}, log)
+
+	// Optimistic concurrency: state changes must name the version they saw
+	appsH.RequireIfMatch = cfg.RequireIfMatch
+	uwH.RequireIfMatch = cfg.RequireIfMatch
+	offersH.RequireIfMatch = cfg.RequireIfMatch
 
 	// --- Background Workers ---
 	workerInterval := time.Duration(cfg.WorkerIntervalSec) * time.Second
-	uwWorker := jobs.NewUnderwritingWorker(appRepo, uwService, workerInterval, log)
-	issuanceWorker := jobs.NewIssuanceWorker(offerRepo, policyService, workerInterval, log)
+	claim := jobs.Claim{Owner: cfg.WorkerID, TTL: time.Duration(cfg.WorkerLeaseSec) * time.Second}
+	if claim.Owner == "" {
+		claim.Owner = jobs.DefaultOwner()
+	}
+	queue := jobs.NewQueue(jobRepo, notifier, claim, jobs.RetryPolicy{
+		MaxAttempts: cfg.JobMaxAttempts,
+		Backoff:     time.Duration(cfg.JobBackoffSec) * time.Second,
+		MaxBackoff:  time.Duration(cfg.JobMaxBackoffSec) * time.Second,
+		Retention:   time.Duration(cfg.JobRetentionHours) * time.Hour,
+	}, jobs.Pool{
+		BatchSize:   cfg.WorkerBatchSize,
+		Concurrency: cfg.WorkerConcurrency,
+		ItemTimeout: time.Duration(cfg.WorkerItemTimeoutSec) * time.Second,
+		Drain:       time.Duration(cfg.WorkerDrainSec) * time.Second,
+	}, log)
+	uwWorker := jobs.NewUnderwritingWorker(appRepo, uwService, queue, workerInterval, log)
+	issuanceWorker := jobs.NewIssuanceWorker(offerRepo, policyService, queue, workerInterval, log)
+	escalationWorker := jobs.NewEscalationWorker(uwService,
+		time.Duration(cfg.UWEscalationIntervalSec)*time.Second, log)
 
-	// Start workers
-	go uwWorker.Start(rootCtx)
-	go issuanceWorker.Start(rootCtx)
-	log.Info("background workers started", "interval", workerInterval)
+	// Maintenance tasks on their cron schedules; an empty schedule disables one
+	scheduler := jobs.NewScheduler(runRepo, claim.Owner, time.Duration(cfg.ScheduleRunRetentionDays)*24*time.Hour, log)
+	for _, task := range []struct {
+		name, spec string
+		run        func(context.Context) (int64, error)
+	}{
+		{core.TaskOfferExpiry, cfg.ScheduleOfferExpiry, maintenanceService.ExpireOffers},
+		{core.TaskQuoteExpiry, cfg.ScheduleQuoteExpiry, maintenanceService.ExpireQuotes},
+		{core.TaskPolicyMaturity, cfg.SchedulePolicyMaturity, maintenanceService.MaturePolicies},
+	} {
+		if task.spec == "" {
+			continue
+		}
+		if err := scheduler.Add(task.name, task.spec, task.run); err != nil {
+			log.Error("invalid task schedule", "task", task.name, "err", err)
+			os.Exit(1)
+		}
+	}
+
+	// Start workers; jobs are claimed, so every replica runs these. Shutdown
+	// waits for them to drain.
+	var workers sync.WaitGroup
+	startWorker := func(run func(context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(rootCtx)
+		}()
+	}
+	startWorker(uwWorker.Start)
+	startWorker(issuanceWorker.Start)
+	if cfg.WorkerEvents {
+		// Also wake them for jobs other replicas queue
+		go watchJobs(rootCtx, notifier.Notify)
+	}
+	// Escalation and the scheduler are singletons: with leader election, only
+	// the lease holder runs them
+	singletons := []jobs.Worker{escalationWorker}
+	if scheduler.Len() > 0 {
+		singletons = append(singletons, scheduler)
+	}
+	for _, w := range singletons {
+		if cfg.LeaderElection {
+			elector := jobs.NewElector(leaseRepo, claim.Owner, claim.TTL, log)
+			startWorker(func(ctx context.Context) { elector.Run(ctx, w) })
+		} else {
+			startWorker(w.Start)
+		}
+	}
+	// Workers only degrade the report: a stuck worker doesn't stop this
+	// replica serving requests
+	hc.Add(
+		healthhttp.Check{Name: "database", Critical: true, Run: healthhttp.Ping(pinger)},
+		healthhttp.Check{Name: "schema", Critical: true, Every: time.Minute, Run: checkSchema},
+		healthhttp.Check{Name: "worker:" + uwWorker.Name(), Every: 10 * time.Second,
+			Run: jobs.HealthCheck(uwWorker, jobRepo, core.JobUnderwriteApplication)},
+		healthhttp.Check{Name: "worker:" + issuanceWorker.Name(), Every: 10 * time.Second,
+			Run: jobs.HealthCheck(issuanceWorker, jobRepo, core.JobIssuePolicy)},
+		healthhttp.Check{Name: "worker:" + escalationWorker.Name(), Every: 10 * time.Second,
+			Run: jobs.HealthCheck(escalationWorker, jobRepo, "")},
+	)
+	log.Info("background workers started", "interval", workerInterval, "owner", claim.Owner, "leader_election", cfg.LeaderElection, "events", cfg.WorkerEvents)
 
 	// --- Outer router: health + /api/v1 mount ---
 	r := chi.NewRouter()
 
 	// Standard middleware
-	r.Use(chimw.RequestID, chimw.RealIP, chimw.Logger, chimw.Recoverer)
+	r.Use(chimw.RequestID, chimw.RealIP, tracing.HTTP, metrics.HTTP, logging.HTTP(log), chimw.Recoverer)
 	r.Use(chimw.Timeout(time.Duration(cfg.HTTPRequestTimeoutSec) * time.Second))
 
 	// Security middleware
@@ -153,33 +397,103 @@ func main() {
 	r.Use(middleware.CORS(cfg.AllowedOrigins))
 	r.Use(middleware.LimitRequestBody(middleware.MaxBodySize))
 
-	// Rate limiting (100 req/min default)
-	rateLimiter := middleware.NewRateLimiter(cfg.RateLimitRPM, time.Minute)
-	rateLimiter.StartWithContext(rootCtx) // Graceful shutdown support
+	// Request ID and X-Change-Reason, for the audit entries of changes
+	r.Use(middleware.AuditInfo)
+
+	// Rate limiting: per IP before authentication, so guessed keys and
+	// tokens are counted, then per API key / user after it
+	var limitStore ratelimit.Store
+	switch cfg.RateLimitStore {
+	case "redis":
+		rs, err := ratelimit.NewRedisStore(rootCtx, cfg.RedisURL)
+		if err != nil {
+			log.Error("rate limit store setup failed", "err", err)
+			os.Exit(1)
+		}
+		defer rs.Close()
+		limitStore = rs
+	default:
+		ms := ratelimit.NewMemoryStore()
+		ms.StartWithContext(rootCtx) // Graceful shutdown support
+		limitStore = ms
+	}
+	var limitRules []middleware.RateLimitRule
+	for _, spec := range cfg.RateLimitRules {
+		rule, err := middleware.ParseRateLimitRule(spec)
+		if err != nil {
+			log.Error("invalid RATE_LIMIT_RULES", "err", err)
+			os.Exit(1)
+		}
+		limitRules = append(limitRules, rule)
+	}
+	defaultLimit := ratelimit.PerMinute(cfg.RateLimitRPM)
+	defaultLimit.Burst = cfg.RateLimitBurst
+	rateLimiter := middleware.NewRateLimiter(limitStore, defaultLimit, limitRules, log)
+	r.Use(rateLimiter.IPMiddleware(ratelimit.PerMinute(cfg.RateLimitIPRPM)))
+
+	// Authentication (skips health/swagger): API keys always, JWTs in jwt mode
+	var verifier middleware.TokenVerifier
+	if cfg.AuthMode == "jwt" {
+		v, err := auth.NewVerifier(auth.Config{
+			JWKSURL:    cfg.JWTJWKSURL,
+			StaticKey:  cfg.JWTStaticKey,
+			Issuer:     cfg.JWTIssuer,
+			Audience:   cfg.JWTAudience,
+			RolesClaim: cfg.JWTRolesClaim,
+			CacheTTL:   time.Duration(cfg.JWTJWKSCacheSec) * time.Second,
+			Leeway:     30 * time.Second,
+		})
+		if err != nil {
+			log.Error("jwt verifier setup failed", "err", err)
+			os.Exit(1)
+		}
+		verifier = v
+	}
+	r.Use(middleware.Authenticate(apiKeyService, verifier))
+
+	// Rate limiting per API key / user (100 req/min default)
 	r.Use(rateLimiter.Middleware)
 
-	// API key auth (skips health/swagger)
-	r.Use(middleware.SimpleAPIKey(cfg.APIKey))
+	// Replay responses to retried mutations that carry an Idempotency-Key
+	if cfg.IdempotencyStore == "memory" {
+		mem := memory.NewIdempotencyRepo()
+		mem.StartWithContext(rootCtx)
+		idemRepo = mem
+	}
+	idempotency := middleware.NewIdempotency(idemRepo, time.Duration(cfg.IdempotencyTTLHours)*time.Hour, log)
+	r.Use(idempotency.Middleware)
 
-	r.Mount("/", healthhttp.New(
-		log,
-		pinger,
-		time.Duration(cfg.MongoOpTimeoutMs)*time.Millisecond,
-	))
+	r.Mount("/", hc.Handler())
 
 	// Swagger UI
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"),
 	))
 
-	// Build API subrouter (adds JSON content-type inside)
-	api := transporthttp.NewRouter(transporthttp.Deps{
-		Mounts: []handlers.Mountable{
-			productsH, quotesH, appsH, uwH, offersH, policiesH,
+	// API versions share the services; v2 sends money as decimal strings and
+	// nests coverage and premium (see handlers.RenderV2)
+	transporthttp.MountVersions(r,
+		transporthttp.Version{
+			Name: "v1",
+			Deps: transporthttp.Deps{
+				Mounts: []handlers.Mountable{
+					productsH, quotesH, appsH, uwH, offersH, policiesH, apiKeysH, jobsH, runsH, logLevelH, searchH, graphqlH,
+				},
+			},
+			Deprecated: cfg.APIV1Deprecated,
+			Sunset:     cfg.APIV1Sunset,
+			Successor:  "v2",
 		},
-	})
-
-	r.Mount("/api/v1", api)
+		transporthttp.Version{
+			Name: "v2",
+			Deps: transporthttp.Deps{
+				Mounts: []handlers.Mountable{
+					productsH, quotesH, appsH, uwH, offersH, policiesH, apiKeysH, jobsH, runsH, logLevelH, searchH, graphqlH,
+				},
+				Render: handlers.RenderV2,
+			},
+		},
+	)
 
 	// --- HTTP Server ---
 	srv := &http.Server{
@@ -191,18 +505,103 @@ func main() {
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
-	errCh := make(chan error, 1)
+	errCh := make(chan error, 3)
 	go func() {
 		log.Info("listening", "addr", addr)
 		errCh <- srv.ListenAndServe()
 	}()
 
+	// --- gRPC Server: same services and authentication, on its own port ---
+	var (
+		grpcSrv    *grpc.Server
+		grpcHealth = grpchealth.NewServer()
+	)
+	if cfg.GRPCEnabled {
+		grpcAddr := fmt.Sprintf(":%s", cfg.GRPCPort)
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Error("grpc listen failed", "addr", grpcAddr, "err", err)
+			os.Exit(1)
+		}
+		grpcSrv = transportgrpc.NewServer(transportgrpc.Deps{
+			Products:     productRepo,
+			Quotes:       quoteService,
+			QuoteRepo:    quoteRepo,
+			Applications: appService,
+			Underwriting: uwService,
+			Offers:       offerService,
+			Policies:     policyService,
+			Keys:         apiKeyService,
+			Tokens:       verifier,
+			RateLimiter:  rateLimiter,
+			PreAuthLimit: ratelimit.PerMinute(cfg.RateLimitIPRPM),
+			Health:       grpcHealth,
+			Log:          log,
+		})
+		go func() {
+			log.Info("grpc listening", "addr", grpcAddr)
+			errCh <- grpcSrv.Serve(lis)
+		}()
+	}
+
+	// --- Metrics Server: Prometheus scrapes /metrics on its own port ---
+	var metricsSrv *http.Server
+	if cfg.MetricsEnabled {
+		metrics.RegisterJobBacklog(jobRepo, log, core.JobUnderwriteApplication, core.JobIssuePolicy)
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		metricsSrv = &http.Server{
+			Addr:              fmt.Sprintf(":%s", cfg.MetricsPort),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+		go func() {
+			log.Info("metrics listening", "addr", metricsSrv.Addr)
+			errCh <- metricsSrv.ListenAndServe()
+		}()
+	}
+
 	// --- Shutdown / Exit ---
 	select {
 	case <-rootCtx.Done():
+		// Fail readiness first, so load balancers stop sending requests
+		// before the servers stop taking them
+		hc.Drain()
+		grpcHealth.Shutdown()
+		if delay := time.Duration(cfg.ShutdownDelaySec) * time.Second; delay > 0 {
+			log.Info("draining", "delay", delay)
+			time.Sleep(delay)
+		}
+
 		shCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		_ = srv.Shutdown(shCtx)
+		if grpcSrv != nil {
+			grpcSrv.GracefulStop()
+		}
+		if metricsSrv != nil {
+			_ = metricsSrv.Shutdown(shCtx)
+		}
+
+		// Workers stopped taking jobs when rootCtx was cancelled; running
+		// ones have WORKER_DRAIN_SEC to finish
+		drained := make(chan struct{})
+		go func() {
+			workers.Wait()
+			close(drained)
+		}()
+		select {
+		case <-drained:
+		case <-time.After(time.Duration(cfg.WorkerDrainSec)*time.Second + 5*time.Second):
+			log.Warn("workers did not stop in time")
+		}
+
+		// Flush the spans of everything that just finished
+		flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFlush()
+		if err := shutdownTracing(flushCtx); err != nil {
+			log.Warn("tracing shutdown failed", "err", err)
+		}
 		log.Info("shutdown complete")
 	case err := <-errCh:
 		if err != nil && err != http.ErrServerClosed {
@@ -211,3 +610,14 @@ func main() {
 		}
 	}
 }
+
+// rebuildSearch fills the search index from the database, then calls done.
+func rebuildSearch(ctx context.Context, index core.SearchIndex, apps core.ApplicationRepo, policies core.PolicyRepo, log *slog.Logger, done func()) {
+	defer done()
+	n, err := search.Rebuild(ctx, index, apps, policies)
+	if err != nil {
+		log.Error("search index build failed", "err", err)
+		return
+	}
+	log.Info("search index built", "documents", n)
+}
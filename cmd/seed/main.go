@@ -15,7 +15,7 @@ import (
 
 func main() {
 	cfg := config.MustLoad()
-	log := logging.New(cfg.Env)
+	log := logging.New(cfg.Env, cfg.LogLevel)
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
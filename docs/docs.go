@@ -7,7 +7,7 @@ const docTemplate = `{
     "swagger": "2.0",
     "info": {
         "title": "Go Insurance API",
-        "description": "Life Insurance Quote and Policy Management API.\n\nThis API implements a complete insurance workflow:\n1. **Products** - Browse available insurance products\n2. **Quotes** - Get pricing for coverage options\n3. **Applications** - Submit application with applicant info\n4. **Underwriting** - Automatic/manual risk assessment\n5. **Offers** - Accept or decline approved offers\n6. **Policies** - Issued policies after offer acceptance",
+        "description": "Life Insurance Quote and Policy Management API.\n\nThis API implements a complete insurance workflow:\n1. **Products** - Browse available insurance products\n2. **Quotes** - Get pricing for coverage options\n3. **Applications** - Submit application with applicant info\n4. **Underwriting** - Automatic/manual risk assessment\n5. **Offers** - Accept or decline approved offers\n6. **Policies** - Issued policies after offer acceptance\n\nThis document describes /api/v1. /api/v2 serves the same endpoints, with money as decimal strings and nested coverage and premium objects on quotes, offers and policies.",
         "contact": {
             "name": "API Support",
             "url": "https://github.com/MrKriegler/go-insurance"
@@ -22,6 +22,48 @@ const docTemplate = `{
     "schemes": ["http", "https"],
     "consumes": ["application/json"],
     "produces": ["application/json"],
+    "securityDefinitions": {
+        "BearerAuth": {"type": "apiKey", "name": "Authorization", "in": "header", "description": "Bearer JWT (AUTH_MODE=jwt)"},
+        "ApiKeyAuth": {"type": "apiKey", "name": "X-API-Key", "in": "header", "description": "Named, scoped API key"}
+    },
+    "security": [{"BearerAuth": []}, {"ApiKeyAuth": []}],
+    "parameters": {
+        "IdempotencyKey": {
+            "name": "Idempotency-Key",
+            "in": "header",
+            "required": false,
+            "type": "string",
+            "maxLength": 255,
+            "description": "Makes the request safe to retry: a repeat with the same key and body replays the first response (with Idempotent-Replayed: true), a different body gets 422 and a repeat while the first is running gets 409"
+        },
+        "IfMatch": {
+            "name": "If-Match",
+            "in": "header",
+            "required": false,
+            "type": "string",
+            "description": "ETag from a previous response. The change is only made if the resource still has this ETag, otherwise 412. Required (428 when missing) if REQUIRE_IF_MATCH is set."
+        },
+        "IfNoneMatch": {
+            "name": "If-None-Match",
+            "in": "header",
+            "required": false,
+            "type": "string",
+            "description": "ETag from a previous response; 304 Not Modified if the resource still has it"
+        },
+        "ChangeReason": {
+            "name": "X-Change-Reason",
+            "in": "header",
+            "required": false,
+            "type": "string",
+            "maxLength": 500,
+            "description": "Why the change is made, kept in the audit trail of every record it changes"
+        },
+        "Limit": {"name": "limit", "in": "query", "type": "integer", "default": 20, "maximum": 100, "description": "Page size"},
+        "Cursor": {"name": "cursor", "in": "query", "type": "string", "description": "next_cursor from the previous page; omit for the first page"},
+        "From": {"name": "from", "in": "query", "type": "string", "description": "Only items created at or after this time (RFC3339 or YYYY-MM-DD)"},
+        "To": {"name": "to", "in": "query", "type": "string", "description": "Only items created before this time (RFC3339 or YYYY-MM-DD)"},
+        "Product": {"name": "product", "in": "query", "type": "string", "description": "Filter by product slug"}
+    },
     "paths": {
         "/products": {
             "get": {
@@ -51,6 +93,7 @@ const docTemplate = `{
                 "description": "Returns a single insurance product",
                 "operationId": "getProduct",
                 "parameters": [
+                    {"$ref": "#/parameters/IfNoneMatch"},
                     {
                         "name": "product_slug",
                         "in": "path",
@@ -72,12 +115,42 @@ const docTemplate = `{
             }
         },
         "/quotes": {
+            "get": {
+                "tags": ["Quotes"],
+                "summary": "List quotes",
+                "description": "Returns quotes newest first, one page at a time (staff only)",
+                "operationId": "listQuotes",
+                "parameters": [
+                    {"name": "status", "in": "query", "type": "string", "enum": ["new", "priced", "expired"]},
+                    {"$ref": "#/parameters/Product"},
+                    {"$ref": "#/parameters/From"},
+                    {"$ref": "#/parameters/To"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"},
+                    {"$ref": "#/parameters/IfNoneMatch"}
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One page of results",
+                        "schema": {"$ref": "#/definitions/QuoteList"}
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    },
+                    "403": {
+                        "description": "Not staff",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    }
+                }
+            },
             "post": {
                 "tags": ["Quotes"],
                 "summary": "Create a quote",
                 "description": "Prices coverage and creates a quote valid for 24 hours",
                 "operationId": "createQuote",
                 "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
                     {
                         "name": "body",
                         "in": "body",
@@ -108,6 +181,7 @@ const docTemplate = `{
                 "description": "Returns a single quote",
                 "operationId": "getQuote",
                 "parameters": [
+                    {"$ref": "#/parameters/IfNoneMatch"},
                     {
                         "name": "quote_id",
                         "in": "path",
@@ -129,12 +203,44 @@ const docTemplate = `{
             }
         },
         "/applications": {
+            "get": {
+                "tags": ["Applications"],
+                "summary": "List applications",
+                "description": "Returns applications newest first, one page at a time. Applicants only see their own.",
+                "operationId": "listApplications",
+                "parameters": [
+                    {"name": "status", "in": "query", "type": "string", "enum": ["draft", "submitted", "under_review", "approved", "declined"]},
+                    {"name": "email", "in": "query", "type": "string", "description": "Filter by applicant email (exact match)"},
+                    {"$ref": "#/parameters/Product"},
+                    {"$ref": "#/parameters/From"},
+                    {"$ref": "#/parameters/To"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"},
+                    {"$ref": "#/parameters/IfNoneMatch"}
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One page of results",
+                        "schema": {"$ref": "#/definitions/ApplicationList"}
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    }
+                }
+            },
             "post": {
                 "tags": ["Applications"],
                 "summary": "Create an application",
                 "description": "Creates a draft application from a quote",
                 "operationId": "createApplication",
                 "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
                     {
                         "name": "body",
                         "in": "body",
@@ -169,6 +275,7 @@ const docTemplate = `{
                 "description": "Returns a single application",
                 "operationId": "getApplication",
                 "parameters": [
+                    {"$ref": "#/parameters/IfNoneMatch"},
                     {
                         "name": "application_id",
                         "in": "path",
@@ -194,6 +301,9 @@ const docTemplate = `{
                 "description": "Updates applicant information (only in draft status)",
                 "operationId": "patchApplication",
                 "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
+                    {"$ref": "#/parameters/IfMatch"},
                     {
                         "name": "application_id",
                         "in": "path",
@@ -234,6 +344,9 @@ const docTemplate = `{
                 "description": "Submits the application for underwriting review",
                 "operationId": "submitApplication",
                 "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
+                    {"$ref": "#/parameters/IfMatch"},
                     {
                         "name": "application_id",
                         "in": "path",
@@ -264,17 +377,35 @@ const docTemplate = `{
         "/underwriting/cases": {
             "get": {
                 "tags": ["Underwriting"],
-                "summary": "List referred cases",
-                "description": "Returns underwriting cases awaiting manual review",
-                "operationId": "listReferredCases",
+                "summary": "List the underwriting work queue",
+                "description": "Returns underwriting cases oldest first, by default those awaiting manual review",
+                "operationId": "listUnderwritingCases",
+                "parameters": [
+                    {"name": "assignee", "in": "query", "type": "string", "description": "Underwriter ID, or - for unassigned cases"},
+                    {"name": "status", "in": "query", "type": "string", "default": "referred", "enum": ["pending", "approved", "declined", "referred", "pending_approval"]},
+                    {"name": "min_age", "in": "query", "type": "string", "description": "Minimum case age, e.g. 24h"},
+                    {"name": "max_age", "in": "query", "type": "string", "description": "Maximum case age, e.g. 72h"},
+                    {"$ref": "#/parameters/From"},
+                    {"$ref": "#/parameters/To"},
+                    {"name": "limit", "in": "query", "type": "integer", "default": 50, "maximum": 200, "description": "Page size"},
+                    {"$ref": "#/parameters/Cursor"},
+                    {"$ref": "#/parameters/IfNoneMatch"}
+                ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "One page of cases, oldest first; a Link header with rel=\"next\" points at the next page",
+                        "headers": {
+                            "Link": {"type": "string", "description": "URL of the next page, absent on the last page"}
+                        },
                         "schema": {
                             "type": "array",
                             "items": {"$ref": "#/definitions/UnderwritingCase"}
                         }
                     },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {"$ref": "#/definitions/ProblemDetails"}
@@ -289,6 +420,7 @@ const docTemplate = `{
                 "description": "Returns details of an underwriting case",
                 "operationId": "getUnderwritingCase",
                 "parameters": [
+                    {"$ref": "#/parameters/IfNoneMatch"},
                     {
                         "name": "case_id",
                         "in": "path",
@@ -308,6 +440,51 @@ const docTemplate = `{
                 }
             }
         },
+        "/underwriting/cases/{case_id}:claim": {
+            "post": {
+                "tags": ["Underwriting"],
+                "summary": "Claim a case",
+                "description": "Assigns an unclaimed referred case to the calling underwriter",
+                "operationId": "claimCase",
+                "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
+                    {"$ref": "#/parameters/IfMatch"},
+                    {"name": "case_id", "in": "path", "required": true, "type": "string"}
+                ],
+                "responses": {
+                    "200": {"description": "Case claimed", "schema": {"$ref": "#/definitions/UnderwritingCase"}},
+                    "403": {"description": "Caller is not an underwriter", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "404": {"description": "Case not found", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "409": {"description": "Held by another underwriter or already decided", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/underwriting/cases/{case_id}:assign": {
+            "post": {
+                "tags": ["Underwriting"],
+                "summary": "Reassign a case",
+                "description": "Hands a referred case to another underwriter",
+                "operationId": "assignCase",
+                "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
+                    {"$ref": "#/parameters/IfMatch"},
+                    {"name": "case_id", "in": "path", "required": true, "type": "string"},
+                    {
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {"type": "object", "properties": {"assignee": {"type": "string"}}}
+                    }
+                ],
+                "responses": {
+                    "200": {"description": "Case reassigned", "schema": {"$ref": "#/definitions/UnderwritingCase"}},
+                    "404": {"description": "Case not found", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "409": {"description": "Changed concurrently or already decided", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
         "/underwriting/cases/{case_id}:decide": {
             "post": {
                 "tags": ["Underwriting"],
@@ -315,6 +492,9 @@ const docTemplate = `{
                 "description": "Manually approve or decline a referred case",
                 "operationId": "decideCase",
                 "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
+                    {"$ref": "#/parameters/IfMatch"},
                     {
                         "name": "case_id",
                         "in": "path",
@@ -333,6 +513,10 @@ const docTemplate = `{
                         "description": "Decision recorded",
                         "schema": {"$ref": "#/definitions/UnderwritingCase"}
                     },
+                    "202": {
+                        "description": "Approval exceeds the underwriter's authority and awaits confirmation",
+                        "schema": {"$ref": "#/definitions/UnderwritingCase"}
+                    },
                     "400": {
                         "description": "Validation error",
                         "schema": {"$ref": "#/definitions/ProblemDetails"}
@@ -342,12 +526,34 @@ const docTemplate = `{
                         "schema": {"$ref": "#/definitions/ProblemDetails"}
                     },
                     "409": {
-                        "description": "Already decided",
+                        "description": "Already decided or held by another underwriter",
                         "schema": {"$ref": "#/definitions/ProblemDetails"}
                     }
                 }
             }
         },
+        "/underwriting/cases/{case_id}:confirm": {
+            "post": {
+                "tags": ["Underwriting"],
+                "summary": "Confirm a pending decision",
+                "description": "Second underwriter confirms or rejects a decision awaiting four-eyes approval",
+                "operationId": "confirmCaseDecision",
+                "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
+                    {"$ref": "#/parameters/IfMatch"},
+                    {"name": "case_id", "in": "path", "required": true, "type": "string"},
+                    {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/UWConfirmInput"}}
+                ],
+                "responses": {
+                    "200": {"description": "Decision confirmed or sent back to the queue", "schema": {"$ref": "#/definitions/UnderwritingCase"}},
+                    "400": {"description": "Validation error", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Same underwriter as the proposer or insufficient authority", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "404": {"description": "Case not found", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "409": {"description": "Case is not awaiting approval", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
         "/applications/{application_id}/offers": {
             "post": {
                 "tags": ["Offers"],
@@ -355,6 +561,8 @@ const docTemplate = `{
                 "description": "Creates an offer from an approved application",
                 "operationId": "createOffer",
                 "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
                     {
                         "name": "application_id",
                         "in": "path",
@@ -378,6 +586,38 @@ const docTemplate = `{
                 }
             }
         },
+        "/offers": {
+            "get": {
+                "tags": ["Offers"],
+                "summary": "List offers",
+                "description": "Returns offers newest first, one page at a time (staff only)",
+                "operationId": "listOffers",
+                "parameters": [
+                    {"name": "status", "in": "query", "type": "string", "enum": ["pending", "accepted", "declined", "expired", "issued"]},
+                    {"name": "application_id", "in": "query", "type": "string", "description": "Filter by application ID"},
+                    {"$ref": "#/parameters/Product"},
+                    {"$ref": "#/parameters/From"},
+                    {"$ref": "#/parameters/To"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"},
+                    {"$ref": "#/parameters/IfNoneMatch"}
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One page of results",
+                        "schema": {"$ref": "#/definitions/OfferList"}
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    },
+                    "403": {
+                        "description": "Not staff",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    }
+                }
+            }
+        },
         "/offers/{offer_id}": {
             "get": {
                 "tags": ["Offers"],
@@ -385,6 +625,7 @@ const docTemplate = `{
                 "description": "Returns a single offer",
                 "operationId": "getOffer",
                 "parameters": [
+                    {"$ref": "#/parameters/IfNoneMatch"},
                     {
                         "name": "offer_id",
                         "in": "path",
@@ -411,6 +652,9 @@ const docTemplate = `{
                 "description": "Accepts the offer, triggering policy issuance",
                 "operationId": "acceptOffer",
                 "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
+                    {"$ref": "#/parameters/IfMatch"},
                     {
                         "name": "offer_id",
                         "in": "path",
@@ -441,6 +685,9 @@ const docTemplate = `{
                 "description": "Declines the offer",
                 "operationId": "declineOffer",
                 "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"$ref": "#/parameters/ChangeReason"},
+                    {"$ref": "#/parameters/IfMatch"},
                     {
                         "name": "offer_id",
                         "in": "path",
@@ -468,44 +715,31 @@ const docTemplate = `{
             "get": {
                 "tags": ["Policies"],
                 "summary": "List policies",
-                "description": "Returns policies with optional filtering and pagination",
+                "description": "Returns policies newest first, one page at a time (staff only). from/to filter on issue time.",
                 "operationId": "listPolicies",
                 "parameters": [
-                    {
-                        "name": "application_id",
-                        "in": "query",
-                        "type": "string",
-                        "description": "Filter by application ID"
-                    },
-                    {
-                        "name": "status",
-                        "in": "query",
-                        "type": "string",
-                        "enum": ["active", "lapsed", "cancelled", "expired"],
-                        "description": "Filter by status"
-                    },
-                    {
-                        "name": "limit",
-                        "in": "query",
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Page size"
-                    },
-                    {
-                        "name": "offset",
-                        "in": "query",
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page offset"
-                    }
+                    {"name": "application_id", "in": "query", "type": "string", "description": "Filter by application ID"},
+                    {"name": "status", "in": "query", "type": "string", "enum": ["active", "lapsed", "cancelled", "expired"]},
+                    {"name": "email", "in": "query", "type": "string", "description": "Filter by insured email (exact match)"},
+                    {"$ref": "#/parameters/Product"},
+                    {"$ref": "#/parameters/From"},
+                    {"$ref": "#/parameters/To"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"},
+                    {"name": "offset", "in": "query", "type": "integer", "default": 0, "description": "Policies to skip, after cursor when given"},
+                    {"$ref": "#/parameters/IfNoneMatch"}
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "One page of results",
                         "schema": {"$ref": "#/definitions/PolicyList"}
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    },
+                    "403": {
+                        "description": "Not staff",
                         "schema": {"$ref": "#/definitions/ProblemDetails"}
                     }
                 }
@@ -518,6 +752,7 @@ const docTemplate = `{
                 "description": "Returns a single policy",
                 "operationId": "getPolicy",
                 "parameters": [
+                    {"$ref": "#/parameters/IfNoneMatch"},
                     {
                         "name": "policy_number",
                         "in": "path",
@@ -537,6 +772,318 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/applications/{application_id}/history": {
+            "get": {
+                "tags": ["Applications"],
+                "summary": "Get an application's history",
+                "description": "Returns the audit trail: who changed what, when and why, oldest first (staff only)",
+                "operationId": "getApplicationHistory",
+                "parameters": [
+                    {"name": "application_id", "in": "path", "required": true, "type": "string"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"}
+                ],
+                "responses": {
+                    "200": {"description": "One page of entries", "schema": {"$ref": "#/definitions/AuditEntryList"}},
+                    "400": {"description": "Invalid cursor", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Not staff", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "404": {"description": "Application not found", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/underwriting/cases/{case_id}/history": {
+            "get": {
+                "tags": ["Underwriting"],
+                "summary": "Get an underwriting case's history",
+                "description": "Returns the audit trail: who changed what, when and why, oldest first (underwriters only)",
+                "operationId": "getUnderwritingCaseHistory",
+                "parameters": [
+                    {"name": "case_id", "in": "path", "required": true, "type": "string"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"}
+                ],
+                "responses": {
+                    "200": {"description": "One page of entries", "schema": {"$ref": "#/definitions/AuditEntryList"}},
+                    "400": {"description": "Invalid cursor", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Not an underwriter", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "404": {"description": "Case not found", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/offers/{offer_id}/history": {
+            "get": {
+                "tags": ["Offers"],
+                "summary": "Get an offer's history",
+                "description": "Returns the audit trail: who changed what, when and why, oldest first (staff only)",
+                "operationId": "getOfferHistory",
+                "parameters": [
+                    {"name": "offer_id", "in": "path", "required": true, "type": "string"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"}
+                ],
+                "responses": {
+                    "200": {"description": "One page of entries", "schema": {"$ref": "#/definitions/AuditEntryList"}},
+                    "400": {"description": "Invalid cursor", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Not staff", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "404": {"description": "Offer not found", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/policies/{policy_number}/history": {
+            "get": {
+                "tags": ["Policies"],
+                "summary": "Get a policy's history",
+                "description": "Returns the audit trail: who changed what, when and why, oldest first (staff only)",
+                "operationId": "getPolicyHistory",
+                "parameters": [
+                    {"name": "policy_number", "in": "path", "required": true, "type": "string", "description": "Policy number (e.g., POL-2025-000001)"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"}
+                ],
+                "responses": {
+                    "200": {"description": "One page of entries", "schema": {"$ref": "#/definitions/AuditEntryList"}},
+                    "400": {"description": "Invalid cursor", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Not staff", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "404": {"description": "Policy not found", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "tags": ["Search"],
+                "summary": "Search applicants, applications and policies",
+                "description": "Finds records by name, email, policy number prefix (POL-...) or date of birth (YYYY-MM-DD), best match first (staff only). Names match word prefixes in memory and whole words with the MongoDB text index.",
+                "operationId": "search",
+                "parameters": [
+                    {"name": "q", "in": "query", "required": true, "type": "string", "maxLength": 200, "description": "Name, email, policy number prefix or date of birth"},
+                    {"name": "type", "in": "query", "type": "array", "items": {"type": "string", "enum": ["applicant", "application", "policy"]}, "collectionFormat": "csv", "description": "Only return these result types"},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"},
+                    {"$ref": "#/parameters/IfNoneMatch"}
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One page of results",
+                        "schema": {"$ref": "#/definitions/SearchResults"}
+                    },
+                    "400": {
+                        "description": "Missing or invalid query",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    },
+                    "403": {
+                        "description": "Not staff",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "tags": ["GraphQL"],
+                "summary": "Run a GraphQL query",
+                "description": "Reads quotes, applications, underwriting cases, offers and policies, following the links between them in one request. Linked entities are loaded in batches; queries over GRAPHQL_MAX_DEPTH or GRAPHQL_MAX_COMPLEXITY are refused with QUERY_TOO_DEEP or QUERY_TOO_COMPLEX. API key scopes are checked per entity read.",
+                "operationId": "graphql",
+                "parameters": [
+                    {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/GraphQLRequest"}}
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Query result; errors carry a code in extensions.code",
+                        "schema": {"$ref": "#/definitions/GraphQLResponse"}
+                    },
+                    "400": {
+                        "description": "Body is not JSON",
+                        "schema": {"$ref": "#/definitions/ProblemDetails"}
+                    }
+                }
+            }
+        },
+        "/admin/api-keys": {
+            "get": {
+                "tags": ["Admin"],
+                "summary": "List API keys",
+                "description": "Returns all API keys without their secrets (admin only)",
+                "operationId": "listAPIKeys",
+                "responses": {
+                    "200": {"description": "Successful response", "schema": {"type": "array", "items": {"$ref": "#/definitions/APIKey"}}},
+                    "403": {"description": "Not an admin", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            },
+            "post": {
+                "tags": ["Admin"],
+                "summary": "Create an API key",
+                "description": "Issues a named, scoped key. The plaintext key is only returned in this response.",
+                "operationId": "createAPIKey",
+                "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/APIKeyInput"}}
+                ],
+                "responses": {
+                    "201": {"description": "Key issued", "schema": {"$ref": "#/definitions/IssuedAPIKey"}},
+                    "400": {"description": "Validation error", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Not an admin", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/api-keys/{key_id}": {
+            "get": {
+                "tags": ["Admin"],
+                "summary": "Get an API key",
+                "operationId": "getAPIKey",
+                "parameters": [
+                    {"name": "key_id", "in": "path", "required": true, "type": "string"}
+                ],
+                "responses": {
+                    "200": {"description": "Successful response", "schema": {"$ref": "#/definitions/APIKey"}},
+                    "404": {"description": "Key not found", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/api-keys/{key_id}:rotate": {
+            "post": {
+                "tags": ["Admin"],
+                "summary": "Rotate an API key",
+                "description": "Issues a replacement with the same name, scopes and roles. The old key stays valid for grace_seconds, or is revoked immediately.",
+                "operationId": "rotateAPIKey",
+                "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"name": "key_id", "in": "path", "required": true, "type": "string"},
+                    {"name": "body", "in": "body", "required": false, "schema": {"type": "object", "properties": {"grace_seconds": {"type": "integer", "example": 86400}}}}
+                ],
+                "responses": {
+                    "201": {"description": "Replacement issued", "schema": {"$ref": "#/definitions/IssuedAPIKey"}},
+                    "404": {"description": "Key not found", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "409": {"description": "Key is revoked, expired or already rotated", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/api-keys/{key_id}:revoke": {
+            "post": {
+                "tags": ["Admin"],
+                "summary": "Revoke an API key",
+                "operationId": "revokeAPIKey",
+                "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"name": "key_id", "in": "path", "required": true, "type": "string"}
+                ],
+                "responses": {
+                    "200": {"description": "Key revoked", "schema": {"$ref": "#/definitions/APIKey"}},
+                    "404": {"description": "Key not found", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/jobs": {
+            "get": {
+                "tags": ["Admin"],
+                "summary": "List background jobs",
+                "description": "Returns jobs one page at a time, e.g. status=dead for the dead-letter list (admin only)",
+                "operationId": "listJobs",
+                "parameters": [
+                    {"name": "status", "in": "query", "type": "string", "enum": ["queued", "succeeded", "dead", "discarded"]},
+                    {"name": "kind", "in": "query", "type": "string", "enum": ["underwrite_application", "issue_policy"]},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"}
+                ],
+                "responses": {
+                    "200": {"description": "One page of results", "schema": {"$ref": "#/definitions/JobList"}},
+                    "400": {"description": "Invalid filter or cursor", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Not an admin", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/jobs/{job_id}": {
+            "get": {
+                "tags": ["Admin"],
+                "summary": "Get a background job",
+                "operationId": "getJob",
+                "parameters": [
+                    {"name": "job_id", "in": "path", "required": true, "type": "string"}
+                ],
+                "responses": {
+                    "200": {"description": "Successful response", "schema": {"$ref": "#/definitions/Job"}},
+                    "404": {"description": "Job not found", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/scheduled-runs": {
+            "get": {
+                "tags": ["Admin"],
+                "summary": "List scheduled task runs",
+                "description": "Returns runs of the scheduled maintenance tasks newest first, one page at a time (admin only)",
+                "operationId": "listScheduledRuns",
+                "parameters": [
+                    {"name": "task", "in": "query", "type": "string", "enum": ["offer_expiry", "quote_expiry", "policy_maturity"]},
+                    {"name": "status", "in": "query", "type": "string", "enum": ["succeeded", "failed"]},
+                    {"$ref": "#/parameters/Limit"},
+                    {"$ref": "#/parameters/Cursor"}
+                ],
+                "responses": {
+                    "200": {"description": "One page of results", "schema": {"$ref": "#/definitions/ScheduledRunList"}},
+                    "400": {"description": "Invalid filter or cursor", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Not an admin", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/log-level": {
+            "get": {
+                "tags": ["Admin"],
+                "summary": "Get the log level",
+                "description": "Returns the level this replica logs at (admin only)",
+                "operationId": "getLogLevel",
+                "responses": {
+                    "200": {"description": "Current level", "schema": {"$ref": "#/definitions/LogLevel"}},
+                    "403": {"description": "Not an admin", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            },
+            "put": {
+                "tags": ["Admin"],
+                "summary": "Set the log level",
+                "description": "Changes the level this replica logs at until it restarts; other replicas keep theirs (admin only)",
+                "operationId": "setLogLevel",
+                "parameters": [
+                    {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/LogLevel"}}
+                ],
+                "responses": {
+                    "200": {"description": "New level", "schema": {"$ref": "#/definitions/LogLevel"}},
+                    "400": {"description": "Unknown level", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "403": {"description": "Not an admin", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/jobs/{job_id}:retry": {
+            "post": {
+                "tags": ["Admin"],
+                "summary": "Retry a dead job",
+                "description": "Queues the job to run now with a fresh set of attempts",
+                "operationId": "retryJob",
+                "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"name": "job_id", "in": "path", "required": true, "type": "string"}
+                ],
+                "responses": {
+                    "200": {"description": "Job queued", "schema": {"$ref": "#/definitions/Job"}},
+                    "404": {"description": "Job not found", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "409": {"description": "Job is not dead", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
+        },
+        "/admin/jobs/{job_id}:discard": {
+            "post": {
+                "tags": ["Admin"],
+                "summary": "Discard a dead job",
+                "description": "Gives up on the job; its subject is not queued again",
+                "operationId": "discardJob",
+                "parameters": [
+                    {"$ref": "#/parameters/IdempotencyKey"},
+                    {"name": "job_id", "in": "path", "required": true, "type": "string"}
+                ],
+                "responses": {
+                    "200": {"description": "Job discarded", "schema": {"$ref": "#/definitions/Job"}},
+                    "404": {"description": "Job not found", "schema": {"$ref": "#/definitions/ProblemDetails"}},
+                    "409": {"description": "Job is not dead", "schema": {"$ref": "#/definitions/ProblemDetails"}}
+                }
+            }
         }
     },
     "definitions": {
@@ -616,6 +1163,7 @@ const docTemplate = `{
                 "monthly_premium": {"type": "number"},
                 "applicant": {"$ref": "#/definitions/Applicant"},
                 "status": {"type": "string", "enum": ["draft", "submitted", "under_review", "approved", "declined"]},
+                "owner_id": {"type": "string", "description": "Subject of the principal that created the application"},
                 "created_at": {"type": "string", "format": "date-time"},
                 "updated_at": {"type": "string", "format": "date-time"},
                 "submitted_at": {"type": "string", "format": "date-time"}
@@ -645,13 +1193,37 @@ const docTemplate = `{
                 "application_id": {"type": "string"},
                 "risk_factors": {"$ref": "#/definitions/RiskFactors"},
                 "risk_score": {"$ref": "#/definitions/RiskScore"},
-                "decision": {"type": "string", "enum": ["pending", "approved", "declined", "referred"]},
+                "decision": {"type": "string", "enum": ["pending", "approved", "declined", "referred", "pending_approval"]},
                 "method": {"type": "string", "enum": ["auto", "manual"]},
                 "decided_by": {"type": "string"},
                 "reason": {"type": "string"},
                 "created_at": {"type": "string", "format": "date-time"},
                 "updated_at": {"type": "string", "format": "date-time"},
-                "decided_at": {"type": "string", "format": "date-time"}
+                "decided_at": {"type": "string", "format": "date-time"},
+                "assigned_to": {"type": "string"},
+                "assigned_at": {"type": "string", "format": "date-time"},
+                "sla_due_at": {"type": "string", "format": "date-time"},
+                "escalated_at": {"type": "string", "format": "date-time"},
+                "pending_decision": {"$ref": "#/definitions/UWDecisionStep"},
+                "decision_chain": {"type": "array", "items": {"$ref": "#/definitions/UWDecisionStep"}}
+            }
+        },
+        "UWDecisionStep": {
+            "type": "object",
+            "properties": {
+                "underwriter": {"type": "string"},
+                "action": {"type": "string", "enum": ["decided", "proposed", "confirmed", "rejected"]},
+                "decision": {"type": "string", "enum": ["approved", "declined"]},
+                "reason": {"type": "string"},
+                "at": {"type": "string", "format": "date-time"}
+            }
+        },
+        "UWConfirmInput": {
+            "type": "object",
+            "required": ["confirm", "reason"],
+            "properties": {
+                "confirm": {"type": "boolean"},
+                "reason": {"type": "string", "example": "Reviewed medical history"}
             }
         },
         "UWDecisionInput": {
@@ -696,23 +1268,227 @@ const docTemplate = `{
                 "issued_at": {"type": "string", "format": "date-time"}
             }
         },
+        "QuoteList": {
+            "type": "object",
+            "properties": {
+                "items": {"type": "array", "items": {"$ref": "#/definitions/Quote"}},
+                "next_cursor": {"type": "string", "description": "Pass as cursor to get the next page; absent on the last page"}
+            }
+        },
+        "ApplicationList": {
+            "type": "object",
+            "properties": {
+                "items": {"type": "array", "items": {"$ref": "#/definitions/Application"}},
+                "next_cursor": {"type": "string", "description": "Pass as cursor to get the next page; absent on the last page"}
+            }
+        },
+        "OfferList": {
+            "type": "object",
+            "properties": {
+                "items": {"type": "array", "items": {"$ref": "#/definitions/Offer"}},
+                "next_cursor": {"type": "string", "description": "Pass as cursor to get the next page; absent on the last page"}
+            }
+        },
         "PolicyList": {
             "type": "object",
             "properties": {
                 "items": {"type": "array", "items": {"$ref": "#/definitions/Policy"}},
-                "total": {"type": "integer"},
+                "total": {"type": "integer", "description": "Policies matching the filters, from cursor on"},
                 "limit": {"type": "integer"},
-                "offset": {"type": "integer"}
+                "offset": {"type": "integer"},
+                "next_cursor": {"type": "string", "description": "Pass as cursor to get the next page; absent on the last page"}
+            }
+        },
+        "SearchHit": {
+            "type": "object",
+            "properties": {
+                "kind": {"type": "string", "enum": ["applicant", "application", "policy"]},
+                "id": {"type": "string", "description": "Application or policy ID; the lowercased email for applicants"},
+                "first_name": {"type": "string"},
+                "last_name": {"type": "string"},
+                "email": {"type": "string"},
+                "date_of_birth": {"type": "string", "example": "1985-06-15"},
+                "policy_number": {"type": "string"},
+                "product_slug": {"type": "string"},
+                "status": {"type": "string"},
+                "updated_at": {"type": "string", "format": "date-time"},
+                "score": {"type": "number"}
+            }
+        },
+        "SearchResults": {
+            "type": "object",
+            "properties": {
+                "items": {"type": "array", "items": {"$ref": "#/definitions/SearchHit"}},
+                "next_cursor": {"type": "string", "description": "Pass as cursor to get the next page; absent on the last page"}
+            }
+        },
+        "GraphQLRequest": {
+            "type": "object",
+            "required": ["query"],
+            "properties": {
+                "query": {"type": "string", "example": "{ application(id: \"01J...\") { status quote { monthlyPremium } offer { id status } policy { number } } }"},
+                "operationName": {"type": "string"},
+                "variables": {"type": "object"}
+            }
+        },
+        "GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {"type": "object"},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "message": {"type": "string"},
+                            "path": {"type": "array", "items": {}},
+                            "extensions": {
+                                "type": "object",
+                                "properties": {"code": {"type": "string", "example": "NOT_FOUND"}}
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "APIKey": {
+            "type": "object",
+            "properties": {
+                "id": {"type": "string"},
+                "name": {"type": "string", "example": "acme-broker"},
+                "prefix": {"type": "string", "example": "gik_Xk3r9Q"},
+                "scopes": {"type": "array", "items": {"type": "string"}, "example": ["quotes:write", "policies:read"]},
+                "roles": {"type": "array", "items": {"type": "string", "enum": ["applicant", "agent", "underwriter", "admin"]}},
+                "created_by": {"type": "string"},
+                "created_at": {"type": "string", "format": "date-time"},
+                "expires_at": {"type": "string", "format": "date-time"},
+                "last_used_at": {"type": "string", "format": "date-time"},
+                "revoked_at": {"type": "string", "format": "date-time"},
+                "rotated_to": {"type": "string"}
+            }
+        },
+        "Job": {
+            "type": "object",
+            "properties": {
+                "id": {"type": "string", "example": "issue_policy.01HXYZ..."},
+                "kind": {"type": "string", "enum": ["underwrite_application", "issue_policy"]},
+                "subject_id": {"type": "string", "description": "The application or offer the job is for"},
+                "status": {"type": "string", "enum": ["queued", "succeeded", "dead", "discarded"]},
+                "attempts": {"type": "integer"},
+                "run_at": {"type": "string", "format": "date-time", "description": "Earliest next attempt"},
+                "last_error": {"type": "string"},
+                "lease_owner": {"type": "string", "description": "Replica running the job"},
+                "lease_expires_at": {"type": "string", "format": "date-time"},
+                "created_at": {"type": "string", "format": "date-time"},
+                "updated_at": {"type": "string", "format": "date-time"},
+                "expires_at": {"type": "string", "format": "date-time", "description": "When a succeeded job is deleted"},
+                "trace_parent": {"type": "string", "description": "W3C traceparent of the request that queued the job, when it was traced"}
+            }
+        },
+        "JobList": {
+            "type": "object",
+            "properties": {
+                "items": {"type": "array", "items": {"$ref": "#/definitions/Job"}},
+                "next_cursor": {"type": "string", "description": "Pass as cursor to get the next page; absent on the last page"}
+            }
+        },
+        "ScheduledRun": {
+            "type": "object",
+            "properties": {
+                "id": {"type": "string"},
+                "task": {"type": "string", "example": "offer_expiry"},
+                "status": {"type": "string", "enum": ["succeeded", "failed"]},
+                "affected": {"type": "integer", "description": "Records the run changed"},
+                "error": {"type": "string"},
+                "owner": {"type": "string", "description": "Replica that ran it"},
+                "started_at": {"type": "string", "format": "date-time"},
+                "finished_at": {"type": "string", "format": "date-time"},
+                "expires_at": {"type": "string", "format": "date-time", "description": "When the record is deleted"}
+            }
+        },
+        "ScheduledRunList": {
+            "type": "object",
+            "properties": {
+                "items": {"type": "array", "items": {"$ref": "#/definitions/ScheduledRun"}},
+                "next_cursor": {"type": "string", "description": "Pass as cursor to get the next page; absent on the last page"}
+            }
+        },
+        "AuditEntry": {
+            "type": "object",
+            "properties": {
+                "id": {"type": "string"},
+                "resource": {"type": "string", "enum": ["application", "underwriting_case", "offer", "policy"]},
+                "resource_id": {"type": "string"},
+                "action": {"type": "string", "example": "accepted", "description": "What the change did, e.g. created, submitted, approved, claimed, accepted, expired"},
+                "actor": {"type": "string", "description": "Subject of the caller; system for background work"},
+                "api_key_id": {"type": "string", "description": "API key the caller authenticated with"},
+                "changes": {"type": "array", "items": {"$ref": "#/definitions/AuditChange"}},
+                "request_id": {"type": "string"},
+                "reason": {"type": "string", "description": "X-Change-Reason, or the reason of an underwriting decision"},
+                "at": {"type": "string", "format": "date-time"}
+            }
+        },
+        "AuditChange": {
+            "type": "object",
+            "properties": {
+                "path": {"type": "string", "example": "/status", "description": "JSON pointer to the field, as stored (v1 field names)"},
+                "before": {"description": "Value before; absent if the field was added"},
+                "after": {"description": "Value after; absent if the field was removed"}
+            }
+        },
+        "AuditEntryList": {
+            "type": "object",
+            "properties": {
+                "items": {"type": "array", "items": {"$ref": "#/definitions/AuditEntry"}},
+                "next_cursor": {"type": "string", "description": "Pass as cursor to get the next page; absent on the last page"}
+            }
+        },
+        "LogLevel": {
+            "type": "object",
+            "required": ["level"],
+            "properties": {
+                "level": {"type": "string", "enum": ["debug", "info", "warn", "error"]}
+            }
+        },
+        "IssuedAPIKey": {
+            "allOf": [
+                {"$ref": "#/definitions/APIKey"},
+                {"type": "object", "properties": {"key": {"type": "string", "description": "Plaintext key, shown once"}}}
+            ]
+        },
+        "APIKeyInput": {
+            "type": "object",
+            "required": ["name", "scopes"],
+            "properties": {
+                "name": {"type": "string", "example": "acme-broker"},
+                "scopes": {"type": "array", "items": {"type": "string"}, "example": ["quotes:write", "applications:write", "policies:read"]},
+                "roles": {"type": "array", "items": {"type": "string", "enum": ["applicant", "agent", "underwriter", "admin"]}, "example": ["agent"]},
+                "expires_at": {"type": "string", "format": "date-time"}
             }
         },
         "ProblemDetails": {
             "type": "object",
             "description": "RFC 7807 Problem Details",
             "properties": {
-                "type": {"type": "string", "example": "about:blank"},
+                "type": {"type": "string", "example": "https://api.insurance.labs.iron-pig.com/problems/offer_not_found"},
                 "title": {"type": "string", "example": "Not Found"},
                 "status": {"type": "integer", "example": 404},
-                "detail": {"type": "string", "example": "Resource not found"}
+                "detail": {"type": "string", "example": "not found: offer not found"},
+                "instance": {"type": "string", "description": "ID of the request", "example": "api-7f9c/Xb3kQp1aZr-000042"},
+                "code": {"type": "string", "description": "Stable error code, listed in docs/problems.md", "example": "offer_not_found"},
+                "errors": {
+                    "type": "array",
+                    "description": "Invalid fields, on validation errors",
+                    "items": {"$ref": "#/definitions/FieldError"}
+                }
+            }
+        },
+        "FieldError": {
+            "type": "object",
+            "properties": {
+                "pointer": {"type": "string", "description": "JSON Pointer into the request body", "example": "/applicant/email"},
+                "code": {"type": "string", "enum": ["required", "invalid", "out_of_range", "unsupported"]},
+                "detail": {"type": "string", "example": "invalid email format"}
             }
         }
     },
@@ -722,7 +1498,10 @@ const docTemplate = `{
         {"name": "Applications", "description": "Manage insurance applications"},
         {"name": "Underwriting", "description": "Risk assessment and decisions"},
         {"name": "Offers", "description": "Accept or decline approved offers"},
-        {"name": "Policies", "description": "Issued insurance policies"}
+        {"name": "Policies", "description": "Issued insurance policies"},
+        {"name": "Search", "description": "Look up applicants, applications and policies"},
+        {"name": "GraphQL", "description": "Query a whole journey in one request"},
+        {"name": "Admin", "description": "API key management"}
     ]
 }`
 
@@ -102,6 +102,7 @@ export const policies = {
     if (filter?.status) params.set("status", filter.status);
     if (filter?.limit) params.set("limit", String(filter.limit));
     if (filter?.offset) params.set("offset", String(filter.offset));
+    if (filter?.cursor) params.set("cursor", filter.cursor);
     const query = params.toString();
     return request<PolicyList>("GET", `/policies${query ? `?${query}` : ""}`);
   },
@@ -145,6 +145,7 @@ export interface PolicyList {
   total: number;
   limit: number;
   offset: number;
+  next_cursor?: string;
 }
 
 export interface PolicyFilter {
@@ -152,6 +153,7 @@ export interface PolicyFilter {
   status?: Policy["status"];
   limit?: number;
   offset?: number;
+  cursor?: string;
 }
 
 // Errors
@@ -196,7 +196,10 @@ function handler(event) {
       actions: [
         "dynamodb:CreateTable",
         "dynamodb:DescribeTable",
+        "dynamodb:UpdateTimeToLive",
+        "dynamodb:UpdateTable",
         "dynamodb:GetItem",
+        "dynamodb:BatchGetItem",
         "dynamodb:PutItem",
         "dynamodb:UpdateItem",
         "dynamodb:DeleteItem",
@@ -208,6 +211,18 @@ function handler(event) {
         `arn:aws:dynamodb:${this.region}:${this.account}:table/insurance_*/index/*`,
       ],
     }));
+    // Read the jobs table's stream so workers wake when another replica queues a job
+    appRunnerInstanceRole.addToPolicy(new iam.PolicyStatement({
+      effect: iam.Effect.ALLOW,
+      actions: [
+        "dynamodb:DescribeStream",
+        "dynamodb:GetShardIterator",
+        "dynamodb:GetRecords",
+      ],
+      resources: [
+        `arn:aws:dynamodb:${this.region}:${this.account}:table/insurance_*/stream/*`,
+      ],
+    }));
     // ListTables needed for health checks and table creation
     appRunnerInstanceRole.addToPolicy(new iam.PolicyStatement({
       effect: iam.Effect.ALLOW,
@@ -229,6 +244,7 @@ function handler(event) {
             port: 8080,
             environmentVariables: {
               PORT: "8080",
+              GRPC_ENABLED: "false", // App Runner routes a single HTTP port
               ENV: "prod",
               DB_TYPE: "dynamodb",
               AWS_REGION: this.region,
@@ -0,0 +1,244 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/platform/ids"
+)
+
+// lastUsedResolution limits last-used writes to one per key per interval.
+const lastUsedResolution = time.Minute
+
+type APIKeyService interface {
+	// Create issues a new key; the plaintext is only returned here
+	Create(ctx context.Context, in APIKeyInput) (IssuedAPIKey, error)
+
+	// Get retrieves a key's metadata by ID
+	Get(ctx context.Context, id string) (APIKey, error)
+
+	// List returns all keys, newest first
+	List(ctx context.Context) ([]APIKey, error)
+
+	// Rotate issues a replacement with the same name and scopes. The old key
+	// keeps working for grace, or is revoked immediately when grace is zero.
+	Rotate(ctx context.Context, id string, grace time.Duration) (IssuedAPIKey, error)
+
+	// Revoke disables a key immediately
+	Revoke(ctx context.Context, id string) (APIKey, error)
+
+	// Authenticate resolves a presented key to its principal (called by middleware)
+	Authenticate(ctx context.Context, key string) (Principal, error)
+
+	// Bootstrap stores an admin key with the given plaintext unless it already
+	// exists (including revoked), so a configured key works on first start.
+	Bootstrap(ctx context.Context, name, key string) error
+}
+
+type apiKeyService struct {
+	keys  APIKeyRepo
+	clock func() time.Time
+}
+
+func NewAPIKeyService(keys APIKeyRepo) APIKeyService {
+	return &apiKeyService{
+		keys:  keys,
+		clock: time.Now,
+	}
+}
+
+func (s *apiKeyService) Create(ctx context.Context, in APIKeyInput) (IssuedAPIKey, error) {
+	// 1) Authorize and validate input
+	p, err := authorize(ctx)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+	if err := in.Validate(); err != nil {
+		return IssuedAPIKey{}, err
+	}
+	now := s.clock()
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(now) {
+		var v violations
+		v.add("/expires_at", FieldOutOfRange, "expires_at must be in the future")
+		return IssuedAPIKey{}, v.err()
+	}
+
+	// 2) Issue and persist
+	return s.issue(ctx, APIKey{
+		Name:      in.Name,
+		Scopes:    in.Scopes,
+		Roles:     in.Roles,
+		CreatedBy: p.Subject,
+		ExpiresAt: in.ExpiresAt,
+	}, now)
+}
+
+func (s *apiKeyService) Get(ctx context.Context, id string) (APIKey, error) {
+	if _, err := authorize(ctx); err != nil {
+		return APIKey{}, err
+	}
+	if id == "" {
+		return APIKey{}, fmt.Errorf("%w: missing key ID", ErrValidation)
+	}
+	return s.keys.Get(ctx, id)
+}
+
+func (s *apiKeyService) List(ctx context.Context) ([]APIKey, error) {
+	if _, err := authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.keys.List(ctx)
+}
+
+func (s *apiKeyService) Rotate(ctx context.Context, id string, grace time.Duration) (IssuedAPIKey, error) {
+	// 1) Authorize and load the key being replaced
+	p, err := authorize(ctx)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+	if grace < 0 {
+		var v violations
+		v.add("/grace_seconds", FieldOutOfRange, "grace period cannot be negative")
+		return IssuedAPIKey{}, v.err()
+	}
+	old, err := s.keys.Get(ctx, id)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+	now := s.clock()
+	if !old.Active(now) || old.RotatedTo != "" {
+		return IssuedAPIKey{}, ErrAPIKeyInactive
+	}
+
+	// 2) Issue the replacement with the same grants
+	issued, err := s.issue(ctx, APIKey{
+		Name:      old.Name,
+		Scopes:    old.Scopes,
+		Roles:     old.Roles,
+		CreatedBy: p.Subject,
+		ExpiresAt: old.ExpiresAt,
+	}, now)
+	if err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	// 3) Retire the old key
+	old.RotatedTo = issued.ID
+	if grace == 0 {
+		old.RevokedAt = &now
+	} else if until := now.Add(grace); old.ExpiresAt == nil || until.Before(*old.ExpiresAt) {
+		old.ExpiresAt = &until
+	}
+	if err := s.keys.Update(ctx, old); err != nil {
+		return IssuedAPIKey{}, err
+	}
+
+	return issued, nil
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, id string) (APIKey, error) {
+	if _, err := authorize(ctx); err != nil {
+		return APIKey{}, err
+	}
+	key, err := s.keys.Get(ctx, id)
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	// Revoking twice is a no-op
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := s.clock()
+	key.RevokedAt = &now
+	if err := s.keys.Update(ctx, key); err != nil {
+		return APIKey{}, err
+	}
+	return key, nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, plaintext string) (Principal, error) {
+	if plaintext == "" {
+		return Principal{}, ErrAPIKeyInvalid
+	}
+
+	key, err := s.keys.GetByHash(ctx, HashAPIKey(plaintext))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Principal{}, ErrAPIKeyInvalid
+		}
+		return Principal{}, err
+	}
+
+	now := s.clock()
+	if !key.Active(now) {
+		return Principal{}, ErrAPIKeyInvalid
+	}
+
+	// Best-effort and coarse-grained so busy keys don't write on every request
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		_ = s.keys.TouchLastUsed(ctx, key.ID, now)
+	}
+
+	return key.Principal(), nil
+}
+
+func (s *apiKeyService) Bootstrap(ctx context.Context, name, plaintext string) error {
+	hash := HashAPIKey(plaintext)
+	if _, err := s.keys.GetByHash(ctx, hash); err == nil {
+		return nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	now := s.clock()
+	key := APIKey{
+		ID:        ids.New(),
+		Name:      name,
+		Prefix:    keyPrefix(plaintext),
+		Hash:      hash,
+		Scopes:    []string{"*"},
+		Roles:     []Role{RoleAdmin},
+		CreatedBy: SystemPrincipal.Subject,
+		CreatedAt: now,
+	}
+	if err := s.keys.Create(ctx, key); err != nil && !errors.Is(err, ErrAPIKeyExists) {
+		return err
+	}
+	return nil
+}
+
+// issue generates the secret, fills in identity fields and stores the key.
+func (s *apiKeyService) issue(ctx context.Context, key APIKey, now time.Time) (IssuedAPIKey, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return IssuedAPIKey{}, fmt.Errorf("generate api key: %w", err)
+	}
+	plaintext := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	key.ID = ids.New()
+	key.Prefix = keyPrefix(plaintext)
+	key.Hash = HashAPIKey(plaintext)
+	key.CreatedAt = now
+	if key.Roles == nil {
+		key.Roles = []Role{}
+	}
+
+	if err := s.keys.Create(ctx, key); err != nil {
+		return IssuedAPIKey{}, err
+	}
+	return IssuedAPIKey{APIKey: key, Key: plaintext}, nil
+}
+
+func keyPrefix(plaintext string) string {
+	const n = len(APIKeyPrefix) + 6
+	if len(plaintext) <= n {
+		return plaintext[:len(plaintext)/2]
+	}
+	return plaintext[:n]
+}
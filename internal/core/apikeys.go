@@ -0,0 +1,135 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix marks keys issued by this service so they are easy to spot in logs and secret scanners.
+const APIKeyPrefix = "gik_"
+
+// APIKey is a named credential for a partner system. Only a hash of the
+// key is stored; the plaintext is returned once when the key is issued.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // First characters of the key, for identification
+	Hash       string     `json:"-"`
+	Scopes     []string   `json:"scopes"` // e.g. "quotes:write", "policies:read", "*"
+	Roles      []Role     `json:"roles"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	RotatedTo  string     `json:"rotated_to,omitempty"` // ID of the replacement key
+}
+
+// IssuedAPIKey carries the plaintext key; it cannot be retrieved again.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyInput struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	Roles     []Role     `json:"roles"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIKeyRepo interface {
+	Create(ctx context.Context, key APIKey) error
+	Get(ctx context.Context, id string) (APIKey, error)
+	GetByHash(ctx context.Context, hash string) (APIKey, error)
+	List(ctx context.Context) ([]APIKey, error)
+	Update(ctx context.Context, key APIKey) error
+	TouchLastUsed(ctx context.Context, id string, at time.Time) error
+}
+
+// scopeResources are the API areas a key can be scoped to.
+var scopeResources = map[string]bool{
+	"products": true, "quotes": true, "applications": true, "underwriting": true,
+	"offers": true, "policies": true, "search": true, "admin": true,
+}
+
+func (in APIKeyInput) Validate() error {
+	var v violations
+	if strings.TrimSpace(in.Name) == "" {
+		v.add("/name", FieldRequired, "name is required")
+	}
+	if len(in.Scopes) == 0 {
+		v.add("/scopes", FieldRequired, "at least one scope is required")
+	}
+	for i, s := range in.Scopes {
+		if !validScope(s) {
+			v.add(fmt.Sprintf("/scopes/%d", i), FieldInvalid,
+				fmt.Sprintf("invalid scope %q (want resource:read|write|*)", s))
+		}
+	}
+	for i, r := range in.Roles {
+		switch r {
+		case RoleApplicant, RoleAgent, RoleUnderwriter, RoleAdmin:
+		default:
+			v.add(fmt.Sprintf("/roles/%d", i), FieldUnsupported, fmt.Sprintf("unknown role %q", r))
+		}
+	}
+	return v.err()
+}
+
+func validScope(s string) bool {
+	if s == "*" {
+		return true
+	}
+	resource, action, ok := strings.Cut(s, ":")
+	return ok && scopeResources[resource] && (action == "read" || action == "write" || action == "*")
+}
+
+// ScopeAllows reports whether any granted scope covers want ("resource:action").
+// Write access implies read access.
+func ScopeAllows(granted []string, want string) bool {
+	resource, action, _ := strings.Cut(want, ":")
+	for _, g := range granted {
+		if g == "*" || g == want || g == resource+":*" {
+			return true
+		}
+		if action == "read" && g == resource+":write" {
+			return true
+		}
+	}
+	return false
+}
+
+// Active reports whether the key may be used at now.
+func (k APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// Principal is the identity a request authenticated with this key acts as.
+func (k APIKey) Principal() Principal {
+	return Principal{
+		Subject: "apikey:" + k.ID,
+		Name:    k.Name,
+		Roles:   k.Roles,
+		Scopes:  k.Scopes,
+		KeyID:   k.ID,
+	}
+}
+
+// HashAPIKey returns the stored form of a key. Keys are high-entropy
+// random strings, so a fast hash is sufficient.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	ErrAPIKeyNotFound = NewError(ErrNotFound, "api_key_not_found", "api key not found")
+	ErrAPIKeyExists   = NewError(ErrConflict, "api_key_exists", "api key already exists")
+	ErrAPIKeyInactive = NewError(ErrInvalidState, "api_key_inactive", "api key is revoked or expired")
+	ErrAPIKeyInvalid  = NewError(ErrUnauthorized, "api_key_invalid", "invalid or expired api key")
+)
@@ -14,24 +14,38 @@ type ApplicationService interface {
 	Get(ctx context.Context, id string) (Application, error)
 	Patch(ctx context.Context, id string, patch ApplicationPatch) (Application, error)
 	Submit(ctx context.Context, id string) (Application, error)
+
+	// List returns applications, newest first. Applicants only see their own.
+	List(ctx context.Context, filter ApplicationFilter, page PageRequest) (Page[Application], error)
+
+	// History returns an application's audit trail, oldest first (staff only)
+	History(ctx context.Context, id string, page PageRequest) (Page[AuditEntry], error)
 }
 
 type applicationService struct {
 	apps   ApplicationRepo
 	quotes QuoteRepo
+	jobs   JobRepo
+	audit  AuditRepo
 	clock  func() time.Time
 }
 
-func NewApplicationService(apps ApplicationRepo, quotes QuoteRepo) ApplicationService {
+func NewApplicationService(apps ApplicationRepo, quotes QuoteRepo, jobs JobRepo, audit AuditRepo) ApplicationService {
 	return &applicationService{
 		apps:   apps,
 		quotes: quotes,
+		jobs:   jobs,
+		audit:  audit,
 		clock:  time.Now,
 	}
 }
 
 func (s *applicationService) Create(ctx context.Context, in ApplicationInput) (Application, error) {
-	// 1) Validate input
+	// 1) Authorize and validate input
+	p, err := authorize(ctx, RoleApplicant, RoleAgent)
+	if err != nil {
+		return Application{}, err
+	}
 	if err := in.Validate(); err != nil {
 		return Application{}, err
 	}
@@ -48,7 +62,7 @@ func (s *applicationService) Create(ctx context.Context, in ApplicationInput) (A
 	// 3) Check quote is not expired
 	now := s.clock()
 	if now.After(quote.ExpiresAt) {
-		return Application{}, fmt.Errorf("%w: quote has expired", ErrInvalidState)
+		return Application{}, ErrQuoteExpired
 	}
 
 	// 4) Create application
@@ -62,6 +76,7 @@ func (s *applicationService) Create(ctx context.Context, in ApplicationInput) (A
 		MonthlyPremium: quote.MonthlyPremium,
 		Applicant:      in.Applicant,
 		Status:         ApplicationStatusDraft,
+		OwnerID:        p.Subject,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
@@ -73,6 +88,9 @@ func (s *applicationService) Create(ctx context.Context, in ApplicationInput) (A
 		}
 		return Application{}, err
 	}
+	if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditApplication, app.ID, AuditCreated, nil, app, now)); err != nil {
+		return Application{}, err
+	}
 
 	return app, nil
 }
@@ -81,24 +99,43 @@ func (s *applicationService) Get(ctx context.Context, id string) (Application, e
 	if id == "" {
 		return Application{}, fmt.Errorf("%w: missing application ID", ErrValidation)
 	}
-	return s.apps.Get(ctx, id)
+	return s.load(ctx, id)
+}
+
+func (s *applicationService) List(ctx context.Context, filter ApplicationFilter, page PageRequest) (Page[Application], error) {
+	p, err := authorize(ctx, RoleApplicant, RoleAgent, RoleUnderwriter)
+	if err != nil {
+		return Page[Application]{}, err
+	}
+	if !p.Staff() {
+		filter.OwnerID = p.Subject
+	}
+	if err := filter.Created.Validate(); err != nil {
+		return Page[Application]{}, err
+	}
+	return s.apps.List(ctx, filter, page.Normalize(DefaultPageLimit, MaxPageLimit))
 }
 
 func (s *applicationService) Patch(ctx context.Context, id string, patch ApplicationPatch) (Application, error) {
 	// 1) Load application
-	app, err := s.apps.Get(ctx, id)
+	app, err := s.load(ctx, id)
 	if err != nil {
 		return Application{}, err
 	}
 
+	if err := checkVersion(ctx, app.Version); err != nil {
+		return Application{}, err
+	}
+
 	// 2) Only allow patching in draft status
 	if app.Status != ApplicationStatusDraft {
-		return Application{}, fmt.Errorf("%w: can only update applications in draft status", ErrInvalidState)
+		return Application{}, ErrApplicationNotDraft
 	}
 
 	// 3) Apply patch
+	before := app
 	if patch.Applicant != nil {
-		if err := patch.Applicant.Validate(); err != nil {
+		if err := patch.Applicant.violations().under("/applicant").err(); err != nil {
 			return Application{}, err
 		}
 		app.Applicant = *patch.Applicant
@@ -108,6 +145,10 @@ func (s *applicationService) Patch(ctx context.Context, id string, patch Applica
 
 	// 4) Persist
 	if err := s.apps.Update(ctx, app); err != nil {
+		return Application{}, versionErr(ctx, err)
+	}
+	app.Version++
+	if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditApplication, app.ID, AuditPatched, before, app, app.UpdatedAt)); err != nil {
 		return Application{}, err
 	}
 
@@ -116,31 +157,65 @@ func (s *applicationService) Patch(ctx context.Context, id string, patch Applica
 
 func (s *applicationService) Submit(ctx context.Context, id string) (Application, error) {
 	// 1) Load application
-	app, err := s.apps.Get(ctx, id)
+	app, err := s.load(ctx, id)
 	if err != nil {
 		return Application{}, err
 	}
 
+	if err := checkVersion(ctx, app.Version); err != nil {
+		return Application{}, err
+	}
+
 	// 2) Validate current status allows submission
 	if !app.Status.CanTransitionTo(ApplicationStatusSubmitted) {
-		return Application{}, fmt.Errorf("%w: cannot submit application in %s status", ErrInvalidState, app.Status)
+		return Application{}, fmt.Errorf("%w (status %s)", ErrApplicationNotDraft, app.Status)
 	}
 
 	// 3) Validate application is complete
-	if err := app.Applicant.Validate(); err != nil {
-		return Application{}, fmt.Errorf("%w: application incomplete - %v", ErrValidation, err)
+	if err := app.Applicant.violations().under("/applicant").err(); err != nil {
+		return Application{}, fmt.Errorf("application incomplete: %w", err)
 	}
 
 	// 4) Update status
 	now := s.clock()
+	before := app
 	app.Status = ApplicationStatusSubmitted
 	app.UpdatedAt = now
 	app.SubmittedAt = &now
 
 	// 5) Persist
 	if err := s.apps.Update(ctx, app); err != nil {
+		return Application{}, versionErr(ctx, err)
+	}
+	app.Version++
+	if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditApplication, app.ID, AuditSubmitted, before, app, now)); err != nil {
 		return Application{}, err
 	}
 
+	// 6) Queue underwriting; if this fails, the worker's sweep queues it
+	_ = EnqueueJob(ctx, s.jobs, JobUnderwriteApplication, app.ID, now)
+
+	return app, nil
+}
+
+func (s *applicationService) History(ctx context.Context, id string, page PageRequest) (Page[AuditEntry], error) {
+	if _, err := authorize(ctx, RoleAgent, RoleUnderwriter); err != nil {
+		return Page[AuditEntry]{}, err
+	}
+	if _, err := s.apps.Get(ctx, id); err != nil {
+		return Page[AuditEntry]{}, err
+	}
+	return s.audit.History(ctx, AuditApplication, id, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
+
+// load fetches an application the caller is allowed to see.
+func (s *applicationService) load(ctx context.Context, id string) (Application, error) {
+	app, err := s.apps.Get(ctx, id)
+	if err != nil {
+		return Application{}, err
+	}
+	if _, err := authorizeOwner(ctx, app.OwnerID); err != nil {
+		return Application{}, err
+	}
 	return app, nil
 }
@@ -2,7 +2,6 @@ package core
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 	"time"
 )
@@ -39,9 +38,11 @@ type Application struct {
 	MonthlyPremium float64           `json:"monthly_premium"`
 	Applicant      Applicant         `json:"applicant"`
 	Status         ApplicationStatus `json:"status"`
+	OwnerID        string            `json:"owner_id,omitempty"` // Subject of the principal that created it
 	CreatedAt      time.Time         `json:"created_at"`
 	UpdatedAt      time.Time         `json:"updated_at"`
 	SubmittedAt    *time.Time        `json:"submitted_at,omitempty"`
+	Version        int               `json:"-"` // Bumped on every write
 }
 
 type ApplicationInput struct {
@@ -56,43 +57,58 @@ type ApplicationPatch struct {
 type ApplicationRepo interface {
 	Create(ctx context.Context, app Application) error
 	Get(ctx context.Context, id string) (Application, error)
+	// Update replaces the application if it is still at app.Version, and
+	// stores it as the next version. Returns ErrChanged otherwise.
 	Update(ctx context.Context, app Application) error
+	// UpdateStatus sets the status whatever the version, and bumps it.
 	UpdateStatus(ctx context.Context, id string, status ApplicationStatus, updatedAt time.Time) error
 	FindByStatus(ctx context.Context, status ApplicationStatus, limit int) ([]Application, error)
+
+	// GetMany returns the applications with the given IDs, skipping missing ones.
+	GetMany(ctx context.Context, ids []string) ([]Application, error)
+
+	// List returns applications matching the filter, newest first.
+	List(ctx context.Context, filter ApplicationFilter, page PageRequest) (Page[Application], error)
 }
 
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 
 func (a Applicant) Validate() error {
+	return a.violations().err()
+}
+
+func (a Applicant) violations() violations {
+	var v violations
 	if a.FirstName == "" {
-		return fmt.Errorf("%w: first name is required", ErrValidation)
+		v.add("/first_name", FieldRequired, "first name is required")
 	}
 	if a.LastName == "" {
-		return fmt.Errorf("%w: last name is required", ErrValidation)
+		v.add("/last_name", FieldRequired, "last name is required")
 	}
 	if a.Email == "" {
-		return fmt.Errorf("%w: email is required", ErrValidation)
-	}
-	if !emailRegex.MatchString(a.Email) {
-		return fmt.Errorf("%w: invalid email format", ErrValidation)
+		v.add("/email", FieldRequired, "email is required")
+	} else if !emailRegex.MatchString(a.Email) {
+		v.add("/email", FieldInvalid, "invalid email format")
 	}
 	if a.DateOfBirth == "" {
-		return fmt.Errorf("%w: date of birth is required", ErrValidation)
+		v.add("/date_of_birth", FieldRequired, "date of birth is required")
 	}
 	if a.Age < 18 || a.Age > 120 {
-		return fmt.Errorf("%w: age must be between 18 and 120", ErrValidation)
+		v.add("/age", FieldOutOfRange, "age must be between 18 and 120")
 	}
 	if a.State == "" {
-		return fmt.Errorf("%w: state is required", ErrValidation)
+		v.add("/state", FieldRequired, "state is required")
 	}
-	return nil
+	return v
 }
 
 func (in ApplicationInput) Validate() error {
+	var v violations
 	if in.QuoteID == "" {
-		return fmt.Errorf("%w: quote_id is required", ErrValidation)
+		v.add("/quote_id", FieldRequired, "quote_id is required")
 	}
-	return in.Applicant.Validate()
+	v = append(v, in.Applicant.violations().under("/applicant")...)
+	return v.err()
 }
 
 // CanTransitionTo checks if a status transition is valid.
@@ -111,6 +127,7 @@ func (s ApplicationStatus) CanTransitionTo(next ApplicationStatus) bool {
 }
 
 var (
-	ErrApplicationNotFound = fmt.Errorf("%w: application not found", ErrNotFound)
-	ErrQuoteAlreadyUsed    = fmt.Errorf("%w: quote already used for an application", ErrConflict)
+	ErrApplicationNotFound = NewError(ErrNotFound, "application_not_found", "application not found")
+	ErrQuoteAlreadyUsed    = NewError(ErrConflict, "quote_already_used", "quote already used for an application")
+	ErrApplicationNotDraft = NewError(ErrInvalidState, "application_not_draft", "application is not in draft status")
 )
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/platform/ids"
+)
+
+// AuditResource is the type of record an audit entry describes.
+type AuditResource string
+
+const (
+	AuditApplication      AuditResource = "application"
+	AuditUnderwritingCase AuditResource = "underwriting_case"
+	AuditOffer            AuditResource = "offer"
+	AuditPolicy           AuditResource = "policy"
+)
+
+// AuditAction names what a change did to its record.
+type AuditAction string
+
+const (
+	AuditCreated   AuditAction = "created"
+	AuditPatched   AuditAction = "patched"
+	AuditSubmitted AuditAction = "submitted"
+	AuditReviewing AuditAction = "under_review" // Application handed to underwriting
+	AuditApproved  AuditAction = "approved"
+	AuditDeclined  AuditAction = "declined"
+	AuditProposed  AuditAction = "proposed"  // Approval awaiting a second underwriter
+	AuditConfirmed AuditAction = "confirmed" // Second underwriter signed off a proposal
+	AuditRejected  AuditAction = "rejected"  // Second underwriter sent a proposal back
+	AuditClaimed   AuditAction = "claimed"
+	AuditAssigned  AuditAction = "assigned"
+	AuditEscalated AuditAction = "escalated"
+	AuditAccepted  AuditAction = "accepted"
+	AuditExpired   AuditAction = "expired"
+	AuditIssued    AuditAction = "issued"
+)
+
+// AuditEntry records one change to an application, underwriting case, offer
+// or policy. Entries are only ever appended.
+type AuditEntry struct {
+	ID         string        `json:"id"` // ULID, so IDs sort in the order entries were made
+	Resource   AuditResource `json:"resource"`
+	ResourceID string        `json:"resource_id"`
+	Action     AuditAction   `json:"action"`
+	Actor      string        `json:"actor"`                // Principal subject; "system" for workers
+	APIKeyID   string        `json:"api_key_id,omitempty"` // API key the actor authenticated with
+	Changes    []AuditChange `json:"changes"`
+	RequestID  string        `json:"request_id,omitempty"`
+	Reason     string        `json:"reason,omitempty"`
+	At         time.Time     `json:"at"`
+}
+
+// AuditChange is one field an audited change set, removed or altered,
+// named by its JSON pointer (e.g. /applicant/smoker). Before and After hold
+// the JSON values and are absent when the field was added or removed.
+type AuditChange struct {
+	Path   string          `json:"path"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+type AuditRepo interface {
+	// Append stores new entries. There is no way to change or delete one.
+	Append(ctx context.Context, entries ...AuditEntry) error
+
+	// History returns the entries for one record, oldest first.
+	History(ctx context.Context, resource AuditResource, resourceID string, page PageRequest) (Page[AuditEntry], error)
+}
+
+// MaxAuditReasonLength bounds the reason a caller may give for a change.
+const MaxAuditReasonLength = 500
+
+// AuditInfo is what the transport knows about the request making a change.
+type AuditInfo struct {
+	RequestID string
+	Reason    string // Given by the caller, e.g. in X-Change-Reason
+}
+
+type auditInfoKey struct{}
+
+// WithAuditInfo attaches the request's ID and reason to ctx, for the audit
+// entries of the changes it makes.
+func WithAuditInfo(ctx context.Context, info AuditInfo) context.Context {
+	return context.WithValue(ctx, auditInfoKey{}, info)
+}
+
+func auditInfoFrom(ctx context.Context) AuditInfo {
+	info, _ := ctx.Value(auditInfoKey{}).(AuditInfo)
+	return info
+}
+
+// newAuditEntry describes the change from before to after (nil when
+// created) made by the caller in ctx.
+func newAuditEntry(ctx context.Context, resource AuditResource, id string, action AuditAction, before, after any, at time.Time) AuditEntry {
+	p, _ := PrincipalFrom(ctx)
+	info := auditInfoFrom(ctx)
+	return AuditEntry{
+		ID:         ids.New(),
+		Resource:   resource,
+		ResourceID: id,
+		Action:     action,
+		Actor:      p.Subject,
+		APIKeyID:   p.KeyID,
+		Changes:    diff(before, after),
+		RequestID:  info.RequestID,
+		Reason:     info.Reason,
+		At:         at,
+	}
+}
+
+// appendAudit stores entries, made after the changes they describe were
+// stored. The two writes are not atomic: if this one fails the caller gets
+// the error, though the change stays. The error names the entries, so the
+// log of the failed request shows which changes the trail is missing.
+func appendAudit(ctx context.Context, audit AuditRepo, entries ...AuditEntry) error {
+	if err := audit.Append(ctx, entries...); err != nil {
+		missing := make([]string, len(entries))
+		for i, e := range entries {
+			missing[i] = fmt.Sprintf("%s %s %s", e.Resource, e.ResourceID, e.Action)
+		}
+		return fmt.Errorf("record audit entry (%s): %w", strings.Join(missing, ", "), err)
+	}
+	return nil
+}
+
+// diff lists the fields that differ between the JSON forms of before and
+// after. Objects are compared field by field; arrays and other values whole.
+func diff(before, after any) []AuditChange {
+	changes := diffValues("", toJSONValue(before), toJSONValue(after))
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// toJSONValue decodes v's JSON form into maps, slices and scalars. The
+// records audited always encode, so errors are not expected.
+func toJSONValue(v any) any {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out any
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+// diffValues compares two decoded JSON values at path. The record itself
+// (path "") is compared field by field even when one side is missing, so a
+// created record lists every field it was created with.
+func diffValues(path string, before, after any) []AuditChange {
+	b, bok := before.(map[string]any)
+	a, aok := after.(map[string]any)
+	if (bok && aok) || path == "" {
+		var changes []AuditChange
+		for key, bv := range b {
+			changes = append(changes, diffValues(path+"/"+escapePointer(key), bv, a[key])...)
+		}
+		for key, av := range a {
+			if _, ok := b[key]; !ok {
+				changes = append(changes, diffValues(path+"/"+escapePointer(key), nil, av)...)
+			}
+		}
+		return changes
+	}
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	return []AuditChange{{Path: path, Before: rawJSON(before), After: rawJSON(after)}}
+}
+
+func rawJSON(v any) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	b, _ := json.Marshal(v)
+	return b
+}
+
+// escapePointer escapes a key for a JSON pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+type Role string
+
+const (
+	RoleApplicant   Role = "applicant"
+	RoleAgent       Role = "agent"
+	RoleUnderwriter Role = "underwriter"
+	RoleAdmin       Role = "admin"  // Passes every role check
+	RoleSystem      Role = "system" // Background workers; passes every role check
+)
+
+// Principal is the authenticated caller of a service method.
+type Principal struct {
+	Subject string   `json:"subject"` // Stable user ID (JWT "sub")
+	Name    string   `json:"name,omitempty"`
+	Roles   []Role   `json:"roles"`
+	Scopes  []string `json:"scopes,omitempty"` // Set for API keys; nil means unrestricted
+	KeyID   string   `json:"key_id,omitempty"` // API key the request authenticated with
+}
+
+// SystemPrincipal is used by background workers acting on their own.
+var SystemPrincipal = Principal{Subject: "system", Roles: []Role{RoleSystem}}
+
+// HasRole reports whether the principal holds any of the roles.
+func (p Principal) HasRole(roles ...Role) bool {
+	for _, have := range p.Roles {
+		if have == RoleAdmin || have == RoleSystem {
+			return true
+		}
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AllowsScope reports whether the principal may call an API area.
+// Only API keys are scope-restricted.
+func (p Principal) AllowsScope(scope string) bool {
+	return p.Scopes == nil || ScopeAllows(p.Scopes, scope)
+}
+
+// Staff reports whether the principal acts on behalf of the insurer
+// rather than as an applicant.
+func (p Principal) Staff() bool {
+	return p.HasRole(RoleAgent, RoleUnderwriter)
+}
+
+type principalKey struct{}
+
+// WithPrincipal attaches the authenticated caller to ctx.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the authenticated caller, if any.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok && p.Subject != ""
+}
+
+// authorize returns the caller if they hold one of the roles.
+func authorize(ctx context.Context, roles ...Role) (Principal, error) {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return Principal{}, fmt.Errorf("%w: no authenticated principal", ErrUnauthorized)
+	}
+	if !p.HasRole(roles...) {
+		return Principal{}, fmt.Errorf("%w: requires role %v", ErrForbidden, roles)
+	}
+	return p, nil
+}
+
+// authorizeOwner lets staff through and applicants only for their own records.
+func authorizeOwner(ctx context.Context, ownerID string) (Principal, error) {
+	p, err := authorize(ctx, RoleApplicant, RoleAgent, RoleUnderwriter)
+	if err != nil {
+		return Principal{}, err
+	}
+	if !p.Staff() && (ownerID == "" || ownerID != p.Subject) {
+		return Principal{}, fmt.Errorf("%w: belongs to another applicant", ErrForbidden)
+	}
+	return p, nil
+}
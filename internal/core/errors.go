@@ -1,6 +1,9 @@
 package core
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
 	ErrNotFound     = errors.New("not found")
@@ -10,3 +13,121 @@ var (
 	ErrUnauthorized = errors.New("unauthorized")
 	ErrForbidden    = errors.New("forbidden operation")
 )
+
+// Error is a domain error with a stable, machine-readable code, such as
+// "offer_not_pending". It wraps one of the errors above, so errors.Is(err,
+// ErrInvalidState) still holds. Wrap it with fmt.Errorf("%w ...") to add
+// context; CodeOf finds the code underneath.
+type Error struct {
+	Kind error  // ErrNotFound, ErrConflict, ...
+	Code string // snake_case, never changed once published
+	Msg  string
+}
+
+// NewError creates a coded error of the given kind.
+func NewError(kind error, code, msg string) *Error {
+	return &Error{Kind: kind, Code: code, Msg: msg}
+}
+
+func (e *Error) Error() string { return e.Kind.Error() + ": " + e.Msg }
+
+func (e *Error) Unwrap() error { return e.Kind }
+
+// FieldError is one invalid field. Pointer is a JSON Pointer (RFC 6901)
+// into the request body, e.g. "/applicant/email".
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Code    string `json:"code"` // e.g. "required", "out_of_range"
+	Detail  string `json:"detail"`
+}
+
+// ValidationError lists every invalid field of an input, rather than only
+// the first. It is an ErrValidation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	details := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		details[i] = f.Detail
+	}
+	return ErrValidation.Error() + ": " + strings.Join(details, "; ")
+}
+
+func (e *ValidationError) Unwrap() error { return ErrValidation }
+
+// Field error codes
+const (
+	FieldRequired    = "required"
+	FieldInvalid     = "invalid"
+	FieldOutOfRange  = "out_of_range"
+	FieldUnsupported = "unsupported"
+)
+
+// violations collects field errors while validating an input.
+type violations []FieldError
+
+func (v *violations) add(pointer, code, detail string) {
+	*v = append(*v, FieldError{Pointer: pointer, Code: code, Detail: detail})
+}
+
+// under moves the collected violations below a parent field.
+func (v violations) under(pointer string) violations {
+	out := make(violations, len(v))
+	for i, f := range v {
+		f.Pointer = pointer + f.Pointer
+		out[i] = f
+	}
+	return out
+}
+
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v}
+}
+
+// Codes for the generic kinds, used when an error carries no code of its own
+const (
+	CodeNotFound     = "not_found"
+	CodeValidation   = "validation_failed"
+	CodeConflict     = "conflict"
+	CodeInvalidState = "invalid_state"
+	CodeUnauthorized = "unauthorized"
+	CodeForbidden    = "forbidden"
+)
+
+// CodeOf returns the code of err: its own if it (or an error it wraps) is
+// an *Error, otherwise the code of its kind, or "" for errors of no kind.
+func CodeOf(err error) string {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrValidation):
+		return CodeValidation
+	case errors.Is(err, ErrConflict):
+		return CodeConflict
+	case errors.Is(err, ErrInvalidState):
+		return CodeInvalidState
+	case errors.Is(err, ErrUnauthorized):
+		return CodeUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return CodeForbidden
+	}
+	return ""
+}
+
+// FieldErrorsOf returns the field errors of a validation error, if any.
+func FieldErrorsOf(err error) []FieldError {
+	var v *ValidationError
+	if errors.As(err, &v) {
+		return v.Fields
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotencyRecord remembers a mutating request made with an
+// Idempotency-Key and, once it finished, the response to replay.
+type IdempotencyRecord struct {
+	Key         string            // Client identity plus the Idempotency-Key header
+	Fingerprint string            // Hash of method, path and body
+	Status      int               // Zero while the first request is in flight
+	Header      map[string]string // Response headers worth replaying
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Completed reports whether the response has been stored.
+func (r IdempotencyRecord) Completed() bool {
+	return r.Status != 0
+}
+
+type IdempotencyRepo interface {
+	// Reserve stores an in-flight record unless an unexpired one exists for
+	// the key, in which case it returns ErrIdempotencyKeyExists.
+	Reserve(ctx context.Context, rec IdempotencyRecord) error
+	Get(ctx context.Context, key string) (IdempotencyRecord, error)
+	// Complete stores the response for a reserved record.
+	Complete(ctx context.Context, rec IdempotencyRecord) error
+	// Release deletes a reservation so the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+var (
+	ErrIdempotencyKeyNotFound = NewError(ErrNotFound, "idempotency_key_not_found", "idempotency key not found")
+	ErrIdempotencyKeyExists   = NewError(ErrConflict, "idempotency_key_exists", "idempotency key already used")
+)
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// JobService lets admins inspect the job queue and deal with dead jobs.
+type JobService interface {
+	// List returns jobs filtered by status and kind
+	List(ctx context.Context, filter JobFilter, page PageRequest) (Page[Job], error)
+
+	// Get retrieves a job by ID
+	Get(ctx context.Context, id string) (Job, error)
+
+	// Retry queues a dead job to run now with a fresh set of attempts
+	Retry(ctx context.Context, id string) (Job, error)
+
+	// Discard gives up on a dead job. It stays recorded, so the sweep
+	// doesn't queue its subject again.
+	Discard(ctx context.Context, id string) (Job, error)
+}
+
+type jobService struct {
+	jobs  JobRepo
+	clock func() time.Time
+}
+
+func NewJobService(jobs JobRepo) JobService {
+	return &jobService{
+		jobs:  jobs,
+		clock: time.Now,
+	}
+}
+
+func (s *jobService) List(ctx context.Context, filter JobFilter, page PageRequest) (Page[Job], error) {
+	if _, err := authorize(ctx); err != nil {
+		return Page[Job]{}, err
+	}
+	return s.jobs.List(ctx, filter, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
+
+func (s *jobService) Get(ctx context.Context, id string) (Job, error) {
+	if _, err := authorize(ctx); err != nil {
+		return Job{}, err
+	}
+	return s.jobs.Get(ctx, id)
+}
+
+func (s *jobService) Retry(ctx context.Context, id string) (Job, error) {
+	return s.resolve(ctx, id, func(job *Job, now time.Time) {
+		job.Status = JobStatusQueued
+		job.Attempts = 0
+		job.RunAt = now
+	})
+}
+
+func (s *jobService) Discard(ctx context.Context, id string) (Job, error) {
+	return s.resolve(ctx, id, func(job *Job, _ time.Time) {
+		job.Status = JobStatusDiscarded
+	})
+}
+
+// resolve applies an admin's change to a dead job.
+func (s *jobService) resolve(ctx context.Context, id string, change func(*Job, time.Time)) (Job, error) {
+	// 1) Authorize and load job
+	if _, err := authorize(ctx); err != nil {
+		return Job{}, err
+	}
+	job, err := s.jobs.Get(ctx, id)
+	if err != nil {
+		return Job{}, err
+	}
+
+	// 2) Only dead jobs are handed to admins
+	if job.Status != JobStatusDead {
+		return Job{}, ErrJobNotDead
+	}
+
+	// 3) Persist, unless a concurrent change got there first
+	now := s.clock()
+	change(&job, now)
+	job.UpdatedAt = now
+	if err := s.jobs.Update(ctx, job, JobStatusDead); err != nil {
+		return Job{}, err
+	}
+	return job, nil
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// JobKind names the work a job does and so the worker that runs it.
+type JobKind string
+
+const (
+	JobUnderwriteApplication JobKind = "underwrite_application" // Subject: a submitted application
+	JobIssuePolicy           JobKind = "issue_policy"           // Subject: an accepted offer
+)
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"    // Waiting for RunAt, or running while leased
+	JobStatusSucceeded JobStatus = "succeeded" // Kept until ExpiresAt
+	JobStatusDead      JobStatus = "dead"      // Out of attempts or failed for good; see LastError
+	JobStatusDiscarded JobStatus = "discarded" // A dead job an admin gave up on
+)
+
+// Job is a persisted unit of background work. Failed attempts are retried
+// with backoff until the worker's attempt limit, then the job is dead.
+type Job struct {
+	ID             string     `json:"id"` // Kind and subject, so a subject is queued once per kind
+	Kind           JobKind    `json:"kind"`
+	SubjectID      string     `json:"subject_id"`
+	Status         JobStatus  `json:"status"`
+	Attempts       int        `json:"attempts"`
+	RunAt          time.Time  `json:"run_at"` // Earliest next attempt
+	LastError      string     `json:"last_error,omitempty"`
+	LeaseOwner     string     `json:"lease_owner,omitempty"` // Replica running it
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`   // When a succeeded job is deleted
+	TraceParent    string     `json:"trace_parent,omitempty"` // W3C traceparent of the request that queued it
+}
+
+// JobID is the ID of the job of kind for subjectID. It is joined with a
+// dot, since a colon would clash with the :retry and :discard routes.
+func JobID(kind JobKind, subjectID string) string {
+	return string(kind) + "." + subjectID
+}
+
+// NewJob returns a job of kind for subjectID, due now.
+func NewJob(kind JobKind, subjectID string, now time.Time) Job {
+	return Job{
+		ID:        JobID(kind, subjectID),
+		Kind:      kind,
+		SubjectID: subjectID,
+		Status:    JobStatusQueued,
+		RunAt:     now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+type JobRepo interface {
+	// Enqueue stores a new job, or returns ErrJobExists if one with its ID
+	// exists in any status.
+	Enqueue(ctx context.Context, job Job) error
+	Get(ctx context.Context, id string) (Job, error)
+
+	// Claim leases up to limit queued jobs of kind that are due to owner for
+	// ttl, earliest first, skipping ones under an unexpired lease. Each claim
+	// is a single conditional update, so one owner gets each job.
+	Claim(ctx context.Context, kind JobKind, owner string, ttl time.Duration, limit int) ([]Job, error)
+	// Backlog counts the queued jobs of kind that are due and not leased,
+	// i.e. waiting for a worker.
+	Backlog(ctx context.Context, kind JobKind) (int64, error)
+	// Renew extends owner's lease, or returns ErrLeaseLost if another owner
+	// has taken it over.
+	Renew(ctx context.Context, id, owner string, ttl time.Duration) error
+	// Finish stores the outcome of an attempt and drops owner's lease, or
+	// returns ErrLeaseLost if another owner has taken it over.
+	Finish(ctx context.Context, job Job, owner string) error
+
+	// Update stores an admin's change to a job, or returns ErrConflict if
+	// the job is no longer in status from.
+	Update(ctx context.Context, job Job, from JobStatus) error
+
+	// List returns jobs matching the filter.
+	List(ctx context.Context, filter JobFilter, page PageRequest) (Page[Job], error)
+}
+
+// EnqueueJob queues the job of kind for subjectID unless it already exists.
+func EnqueueJob(ctx context.Context, jobs JobRepo, kind JobKind, subjectID string, now time.Time) error {
+	err := jobs.Enqueue(ctx, NewJob(kind, subjectID, now))
+	if errors.Is(err, ErrJobExists) {
+		return nil
+	}
+	return err
+}
+
+var (
+	ErrJobNotFound = NewError(ErrNotFound, "job_not_found", "job not found")
+	ErrJobExists   = NewError(ErrConflict, "job_exists", "job already exists")
+	ErrJobNotDead  = NewError(ErrInvalidState, "job_not_dead", "job is not dead-lettered")
+)
@@ -0,0 +1,24 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// A lease is a time-limited claim by one owner (a replica, see
+// jobs.DefaultOwner) on an item of work or a named singleton job. An owner
+// renews its lease while it works; a lease left to expire, e.g. by a replica
+// that crashed, can be taken by another owner, so no work is stranded.
+
+// LeaseRepo stores named leases, used to elect the one replica that runs a
+// singleton job.
+type LeaseRepo interface {
+	// Acquire takes the named lease for owner until ttl passes, or extends
+	// it if owner already holds it. It returns false while another owner
+	// holds an unexpired lease.
+	Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	// Release gives the lease up if owner holds it.
+	Release(ctx context.Context, name, owner string) error
+}
+
+var ErrLeaseLost = NewError(ErrConflict, "lease_lost", "lease was taken over by another owner")
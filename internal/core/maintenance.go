@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// Scheduled maintenance tasks, run by the scheduler on the schedules in
+// config.
+const (
+	TaskOfferExpiry    = "offer_expiry"    // Pending offers past their expiry
+	TaskQuoteExpiry    = "quote_expiry"    // Unused quotes past their expiry
+	TaskPolicyMaturity = "policy_maturity" // Active policies past the end of their term
+)
+
+type ScheduledRunStatus string
+
+const (
+	ScheduledRunSucceeded ScheduledRunStatus = "succeeded"
+	ScheduledRunFailed    ScheduledRunStatus = "failed"
+)
+
+// ScheduledRun records one run of a scheduled task.
+type ScheduledRun struct {
+	ID         string             `json:"id"`
+	Task       string             `json:"task"`
+	Status     ScheduledRunStatus `json:"status"`
+	Affected   int64              `json:"affected"` // Records the task changed
+	Error      string             `json:"error,omitempty"`
+	Owner      string             `json:"owner"` // Replica that ran it
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+	ExpiresAt  time.Time          `json:"expires_at"` // When the record is deleted
+}
+
+type ScheduledRunRepo interface {
+	Create(ctx context.Context, run ScheduledRun) error
+
+	// List returns runs matching the filter, newest first.
+	List(ctx context.Context, filter ScheduledRunFilter, page PageRequest) (Page[ScheduledRun], error)
+}
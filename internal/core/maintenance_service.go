@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// MaintenanceService holds the scheduled housekeeping tasks, which the
+// scheduler runs as the system principal, and their run history.
+type MaintenanceService interface {
+	// ExpireOffers marks pending offers past their expiry as expired
+	ExpireOffers(ctx context.Context) (int64, error)
+
+	// ExpireQuotes marks priced quotes past their expiry as expired
+	ExpireQuotes(ctx context.Context) (int64, error)
+
+	// MaturePolicies marks active policies past their expiry date as expired
+	MaturePolicies(ctx context.Context) (int64, error)
+
+	// Runs returns recorded runs of scheduled tasks, newest first
+	Runs(ctx context.Context, filter ScheduledRunFilter, page PageRequest) (Page[ScheduledRun], error)
+}
+
+type maintenanceService struct {
+	offers   OfferRepo
+	quotes   QuoteRepo
+	policies PolicyRepo
+	runs     ScheduledRunRepo
+	audit    AuditRepo
+	clock    func() time.Time
+}
+
+func NewMaintenanceService(offers OfferRepo, quotes QuoteRepo, policies PolicyRepo, runs ScheduledRunRepo, audit AuditRepo) MaintenanceService {
+	return &maintenanceService{
+		offers:   offers,
+		quotes:   quotes,
+		policies: policies,
+		runs:     runs,
+		audit:    audit,
+		clock:    time.Now,
+	}
+}
+
+func (s *maintenanceService) ExpireOffers(ctx context.Context) (int64, error) {
+	if _, err := authorize(ctx); err != nil {
+		return 0, err
+	}
+	now := s.clock()
+	expired, err := s.offers.ExpireOffers(ctx, now)
+
+	// Record the ones changed, even if the rest failed
+	entries := make([]AuditEntry, len(expired))
+	for i, offer := range expired {
+		before := offer
+		before.Status = OfferStatusPending
+		entries[i] = newAuditEntry(ctx, AuditOffer, offer.ID, AuditExpired, before, offer, now)
+	}
+	return int64(len(expired)), errors.Join(err, s.appendAll(ctx, entries))
+}
+
+func (s *maintenanceService) ExpireQuotes(ctx context.Context) (int64, error) {
+	if _, err := authorize(ctx); err != nil {
+		return 0, err
+	}
+	return s.quotes.ExpireQuotes(ctx, s.clock())
+}
+
+func (s *maintenanceService) MaturePolicies(ctx context.Context) (int64, error) {
+	if _, err := authorize(ctx); err != nil {
+		return 0, err
+	}
+	now := s.clock()
+	matured, err := s.policies.ExpirePolicies(ctx, now)
+
+	entries := make([]AuditEntry, len(matured))
+	for i, policy := range matured {
+		before := policy
+		before.Status = PolicyStatusActive
+		entries[i] = newAuditEntry(ctx, AuditPolicy, policy.ID, AuditExpired, before, policy, now)
+	}
+	return int64(len(matured)), errors.Join(err, s.appendAll(ctx, entries))
+}
+
+// appendAll records the entries of a task's changes, if it made any.
+func (s *maintenanceService) appendAll(ctx context.Context, entries []AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return appendAudit(ctx, s.audit, entries...)
+}
+
+func (s *maintenanceService) Runs(ctx context.Context, filter ScheduledRunFilter, page PageRequest) (Page[ScheduledRun], error) {
+	if _, err := authorize(ctx); err != nil {
+		return Page[ScheduledRun]{}, err
+	}
+	return s.runs.List(ctx, filter, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
@@ -24,24 +24,37 @@ type OfferService interface {
 
 	// Decline marks an offer as declined
 	Decline(ctx context.Context, id string) (Offer, error)
+
+	// List returns offers, newest first (staff only)
+	List(ctx context.Context, filter OfferFilter, page PageRequest) (Page[Offer], error)
+
+	// History returns an offer's audit trail, oldest first (staff only)
+	History(ctx context.Context, id string, page PageRequest) (Page[AuditEntry], error)
 }
 
 type offerService struct {
 	offers OfferRepo
 	apps   ApplicationRepo
+	jobs   JobRepo
+	audit  AuditRepo
 	clock  func() time.Time
 }
 
-func NewOfferService(offers OfferRepo, apps ApplicationRepo) OfferService {
+func NewOfferService(offers OfferRepo, apps ApplicationRepo, jobs JobRepo, audit AuditRepo) OfferService {
 	return &offerService{
 		offers: offers,
 		apps:   apps,
+		jobs:   jobs,
+		audit:  audit,
 		clock:  time.Now,
 	}
 }
 
 func (s *offerService) GenerateOffer(ctx context.Context, appID string) (Offer, error) {
-	// 1) Load application
+	// 1) Authorize and load application
+	if _, err := authorize(ctx, RoleAgent, RoleUnderwriter); err != nil {
+		return Offer{}, err
+	}
 	app, err := s.apps.Get(ctx, appID)
 	if err != nil {
 		return Offer{}, err
@@ -84,6 +97,9 @@ func (s *offerService) GenerateOffer(ctx context.Context, appID string) (Offer,
 		}
 		return Offer{}, err
 	}
+	if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditOffer, offer.ID, AuditCreated, nil, offer, now)); err != nil {
+		return Offer{}, err
+	}
 
 	return offer, nil
 }
@@ -92,23 +108,44 @@ func (s *offerService) Get(ctx context.Context, id string) (Offer, error) {
 	if id == "" {
 		return Offer{}, fmt.Errorf("%w: missing offer ID", ErrValidation)
 	}
-	return s.offers.Get(ctx, id)
+	return s.load(ctx, id)
+}
+
+func (s *offerService) List(ctx context.Context, filter OfferFilter, page PageRequest) (Page[Offer], error) {
+	if _, err := authorize(ctx, RoleAgent, RoleUnderwriter); err != nil {
+		return Page[Offer]{}, err
+	}
+	if err := filter.Created.Validate(); err != nil {
+		return Page[Offer]{}, err
+	}
+	return s.offers.List(ctx, filter, page.Normalize(DefaultPageLimit, MaxPageLimit))
 }
 
 func (s *offerService) GetByApplicationID(ctx context.Context, appID string) (Offer, error) {
 	if appID == "" {
 		return Offer{}, fmt.Errorf("%w: missing application ID", ErrValidation)
 	}
-	return s.offers.GetByApplicationID(ctx, appID)
+	offer, err := s.offers.GetByApplicationID(ctx, appID)
+	if err != nil {
+		return Offer{}, err
+	}
+	if err := s.authorizeOffer(ctx, offer); err != nil {
+		return Offer{}, err
+	}
+	return offer, nil
 }
 
 func (s *offerService) Accept(ctx context.Context, id string) (Offer, error) {
 	// 1) Load offer
-	offer, err := s.offers.Get(ctx, id)
+	offer, err := s.load(ctx, id)
 	if err != nil {
 		return Offer{}, err
 	}
 
+	if err := checkVersion(ctx, offer.Version); err != nil {
+		return Offer{}, err
+	}
+
 	// 2) Verify offer is pending
 	if offer.Status != OfferStatusPending {
 		return Offer{}, ErrOfferNotPending
@@ -116,11 +153,18 @@ func (s *offerService) Accept(ctx context.Context, id string) (Offer, error) {
 
 	// 3) Check if expired
 	now := s.clock()
+	before := offer
 	if offer.IsExpired(now) {
-		// Update status to expired (best-effort)
+		// Update status to expired (best-effort - the expiry task catches up),
+		// but a change that was stored is audited like any other
 		offer.Status = OfferStatusExpired
 		offer.AcceptedAt = nil
-		_ = s.offers.Update(ctx, offer) // Ignore error - returning ErrOfferExpired anyway
+		if err := s.offers.Update(ctx, offer); err != nil {
+			return Offer{}, ErrOfferExpired
+		}
+		if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditOffer, offer.ID, AuditExpired, before, offer, now)); err != nil {
+			return Offer{}, err
+		}
 		return Offer{}, ErrOfferExpired
 	}
 
@@ -129,19 +173,30 @@ func (s *offerService) Accept(ctx context.Context, id string) (Offer, error) {
 	offer.AcceptedAt = &now
 
 	if err := s.offers.Update(ctx, offer); err != nil {
+		return Offer{}, versionErr(ctx, err)
+	}
+	offer.Version++
+	if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditOffer, offer.ID, AuditAccepted, before, offer, now)); err != nil {
 		return Offer{}, err
 	}
 
+	// 5) Queue issuance; if this fails, the worker's sweep queues it
+	_ = EnqueueJob(ctx, s.jobs, JobIssuePolicy, offer.ID, now)
+
 	return offer, nil
 }
 
 func (s *offerService) Decline(ctx context.Context, id string) (Offer, error) {
 	// 1) Load offer
-	offer, err := s.offers.Get(ctx, id)
+	offer, err := s.load(ctx, id)
 	if err != nil {
 		return Offer{}, err
 	}
 
+	if err := checkVersion(ctx, offer.Version); err != nil {
+		return Offer{}, err
+	}
+
 	// 2) Verify offer is pending
 	if offer.Status != OfferStatusPending {
 		return Offer{}, ErrOfferNotPending
@@ -149,12 +204,49 @@ func (s *offerService) Decline(ctx context.Context, id string) (Offer, error) {
 
 	// 3) Update offer
 	now := s.clock()
+	before := offer
 	offer.Status = OfferStatusDeclined
 	offer.DeclinedAt = &now
 
 	if err := s.offers.Update(ctx, offer); err != nil {
+		return Offer{}, versionErr(ctx, err)
+	}
+	offer.Version++
+	if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditOffer, offer.ID, AuditDeclined, before, offer, now)); err != nil {
 		return Offer{}, err
 	}
 
 	return offer, nil
 }
+
+func (s *offerService) History(ctx context.Context, id string, page PageRequest) (Page[AuditEntry], error) {
+	if _, err := authorize(ctx, RoleAgent, RoleUnderwriter); err != nil {
+		return Page[AuditEntry]{}, err
+	}
+	if _, err := s.offers.Get(ctx, id); err != nil {
+		return Page[AuditEntry]{}, err
+	}
+	return s.audit.History(ctx, AuditOffer, id, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
+
+// load fetches an offer the caller is allowed to see.
+func (s *offerService) load(ctx context.Context, id string) (Offer, error) {
+	offer, err := s.offers.Get(ctx, id)
+	if err != nil {
+		return Offer{}, err
+	}
+	if err := s.authorizeOffer(ctx, offer); err != nil {
+		return Offer{}, err
+	}
+	return offer, nil
+}
+
+// authorizeOffer applies the owning application's access rules.
+func (s *offerService) authorizeOffer(ctx context.Context, offer Offer) error {
+	app, err := s.apps.Get(ctx, offer.ApplicationID)
+	if err != nil {
+		return err
+	}
+	_, err = authorizeOwner(ctx, app.OwnerID)
+	return err
+}
@@ -2,7 +2,6 @@ package core
 
 import (
 	"context"
-	"fmt"
 	"time"
 )
 
@@ -34,15 +33,25 @@ type Offer struct {
 	ExpiresAt      time.Time   `json:"expires_at"`
 	AcceptedAt     *time.Time  `json:"accepted_at,omitempty"`
 	DeclinedAt     *time.Time  `json:"declined_at,omitempty"`
+	Version        int         `json:"-"` // Bumped on every write
 }
 
 type OfferRepo interface {
 	Create(ctx context.Context, offer Offer) error
 	Get(ctx context.Context, id string) (Offer, error)
 	GetByApplicationID(ctx context.Context, appID string) (Offer, error)
+	// FindByApplicationIDs returns the offers for the given applications, skipping ones without an offer.
+	FindByApplicationIDs(ctx context.Context, appIDs []string) ([]Offer, error)
+	// Update replaces the offer if it is still at offer.Version, and stores
+	// it as the next version. Returns ErrChanged otherwise.
 	Update(ctx context.Context, offer Offer) error
 	FindAccepted(ctx context.Context, limit int) ([]Offer, error)
-	ExpireOffers(ctx context.Context, before time.Time) (int64, error)
+	// ExpireOffers marks pending offers whose expiry is before the given
+	// time as expired, bumping their versions, and returns them.
+	ExpireOffers(ctx context.Context, before time.Time) ([]Offer, error)
+
+	// List returns offers matching the filter, newest first.
+	List(ctx context.Context, filter OfferFilter, page PageRequest) (Page[Offer], error)
 }
 
 // CanTransitionTo checks if a status transition is valid.
@@ -65,10 +74,10 @@ func (o Offer) IsExpired(now time.Time) bool {
 }
 
 var (
-	ErrOfferNotFound     = fmt.Errorf("%w: offer not found", ErrNotFound)
-	ErrOfferExists       = fmt.Errorf("%w: offer already exists for application", ErrConflict)
-	ErrOfferExpired      = fmt.Errorf("%w: offer has expired", ErrInvalidState)
-	ErrOfferNotPending   = fmt.Errorf("%w: offer is not in pending status", ErrInvalidState)
-	ErrOfferNotAccepted  = fmt.Errorf("%w: offer is not in accepted status", ErrInvalidState)
-	ErrAppNotApproved    = fmt.Errorf("%w: application is not approved", ErrInvalidState)
+	ErrOfferNotFound     = NewError(ErrNotFound, "offer_not_found", "offer not found")
+	ErrOfferExists       = NewError(ErrConflict, "offer_exists", "offer already exists for application")
+	ErrOfferExpired      = NewError(ErrInvalidState, "offer_expired", "offer has expired")
+	ErrOfferNotPending   = NewError(ErrInvalidState, "offer_not_pending", "offer is not in pending status")
+	ErrOfferNotAccepted  = NewError(ErrInvalidState, "offer_not_accepted", "offer is not in accepted status")
+	ErrAppNotApproved    = NewError(ErrInvalidState, "application_not_approved", "application is not approved")
 )
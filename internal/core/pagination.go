@@ -0,0 +1,109 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// PageRequest asks for one page of a list. Cursor is the NextCursor of the
+// previous page, or empty for the first page.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+}
+
+// Page is one page of a list. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Normalize applies the default and maximum page sizes.
+func (p PageRequest) Normalize(def, max int) PageRequest {
+	if p.Limit <= 0 {
+		p.Limit = def
+	}
+	if p.Limit > max {
+		p.Limit = max
+	}
+	return p
+}
+
+// EncodeCursor makes a store's resume position opaque to clients.
+func EncodeCursor(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor reads a cursor made by EncodeCursor into v.
+func DecodeCursor(cursor string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// TimeRange bounds a list by creation (or issue) time. Zero ends are open.
+type TimeRange struct {
+	From time.Time // Inclusive
+	To   time.Time // Exclusive
+}
+
+func (r TimeRange) Validate() error {
+	if !r.From.IsZero() && !r.To.IsZero() && !r.From.Before(r.To) {
+		return fmt.Errorf("%w: from must be before to", ErrValidation)
+	}
+	return nil
+}
+
+// Contains reports whether t falls in the range.
+func (r TimeRange) Contains(t time.Time) bool {
+	return (r.From.IsZero() || !t.Before(r.From)) && (r.To.IsZero() || t.Before(r.To))
+}
+
+type QuoteFilter struct {
+	ProductSlug string
+	Status      QuoteStatus
+	Created     TimeRange
+}
+
+type ApplicationFilter struct {
+	Status         ApplicationStatus
+	ProductSlug    string
+	ApplicantEmail string
+	OwnerID        string // Set by the service for applicants
+	Created        TimeRange
+}
+
+type OfferFilter struct {
+	Status        OfferStatus
+	ProductSlug   string
+	ApplicationID string
+	Created       TimeRange
+}
+
+type JobFilter struct {
+	Status JobStatus
+	Kind   JobKind
+}
+
+type ScheduledRunFilter struct {
+	Task   string
+	Status ScheduledRunStatus
+}
+
+var ErrInvalidCursor = NewError(ErrValidation, "invalid_cursor", "invalid cursor")
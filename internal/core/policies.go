@@ -2,7 +2,6 @@ package core
 
 import (
 	"context"
-	"fmt"
 	"time"
 )
 
@@ -35,6 +34,9 @@ type Policy struct {
 type PolicyFilter struct {
 	ApplicationID string
 	Status        PolicyStatus
+	ProductSlug   string
+	InsuredEmail  string
+	Issued        TimeRange
 }
 
 type PolicyRepo interface {
@@ -43,11 +45,18 @@ type PolicyRepo interface {
 	GetByNumber(ctx context.Context, number string) (Policy, error)
 	GetByOfferID(ctx context.Context, offerID string) (Policy, error)
 	GetByApplicationID(ctx context.Context, appID string) (Policy, error)
-	List(ctx context.Context, filter PolicyFilter, limit, offset int) ([]Policy, int64, error)
+	// FindByApplicationIDs returns the policies for the given applications, skipping ones without a policy.
+	FindByApplicationIDs(ctx context.Context, appIDs []string) ([]Policy, error)
+	// List returns policies matching the filter, newest first.
+	List(ctx context.Context, filter PolicyFilter, page PageRequest) (Page[Policy], error)
 	NextPolicyNumber(ctx context.Context) (string, error)
+
+	// ExpirePolicies marks active policies whose expiry date is before the
+	// given time as expired, and returns them.
+	ExpirePolicies(ctx context.Context, before time.Time) ([]Policy, error)
 }
 
 var (
-	ErrPolicyNotFound = fmt.Errorf("%w: policy not found", ErrNotFound)
-	ErrPolicyExists   = fmt.Errorf("%w: policy already exists for offer", ErrConflict)
+	ErrPolicyNotFound = NewError(ErrNotFound, "policy_not_found", "policy not found")
+	ErrPolicyExists   = NewError(ErrConflict, "policy_exists", "policy already exists for offer")
 )
@@ -19,66 +19,97 @@ type PolicyService interface {
 	// GetByNumber retrieves a policy by policy number
 	GetByNumber(ctx context.Context, number string) (Policy, error)
 
-	// List returns policies with optional filtering and pagination
-	List(ctx context.Context, filter PolicyFilter, limit, offset int) ([]Policy, int64, error)
+	// List returns policies with optional filtering, newest first (staff only)
+	List(ctx context.Context, filter PolicyFilter, page PageRequest) (Page[Policy], error)
+
+	// History returns a policy's audit trail, oldest first (staff only)
+	History(ctx context.Context, id string, page PageRequest) (Page[AuditEntry], error)
 }
 
 type policyService struct {
 	policies PolicyRepo
 	offers   OfferRepo
 	apps     ApplicationRepo
+	audit    AuditRepo
 	clock    func() time.Time
 }
 
-func NewPolicyService(policies PolicyRepo, offers OfferRepo, apps ApplicationRepo) PolicyService {
+func NewPolicyService(policies PolicyRepo, offers OfferRepo, apps ApplicationRepo, audit AuditRepo) PolicyService {
 	return &policyService{
 		policies: policies,
 		offers:   offers,
 		apps:     apps,
+		audit:    audit,
 		clock:    time.Now,
 	}
 }
 
 func (s *policyService) IssueFromOffer(ctx context.Context, offerID string) (Policy, error) {
-	// 1) Load offer
+	// 1) Authorize (issuance worker only) and load offer
+	if _, err := authorize(ctx); err != nil {
+		return Policy{}, err
+	}
 	offer, err := s.offers.Get(ctx, offerID)
 	if err != nil {
 		return Policy{}, err
 	}
 
-	// 2) Verify offer is accepted
-	if offer.Status != OfferStatusAccepted {
+	// 2) Verify offer is accepted, or issued by an attempt that failed later
+	if offer.Status != OfferStatusAccepted && offer.Status != OfferStatusIssued {
 		return Policy{}, ErrOfferNotAccepted
 	}
 
-	// 3) Check if policy already exists for this offer
-	existing, err := s.policies.GetByOfferID(ctx, offerID)
-	if err == nil {
-		// Policy already exists
-		return existing, nil
-	}
-	if !errors.Is(err, ErrPolicyNotFound) {
+	// 3) Reuse the policy an earlier attempt created, or create it
+	policy, err := s.policies.GetByOfferID(ctx, offerID)
+	switch {
+	case errors.Is(err, ErrPolicyNotFound):
+		if offer.Status != OfferStatusAccepted {
+			return Policy{}, ErrOfferNotAccepted
+		}
+		if policy, err = s.create(ctx, offer); err != nil {
+			return Policy{}, err
+		}
+	case err != nil:
 		return Policy{}, err
 	}
 
-	// 4) Load application for insured details
+	// 4) Update offer status to issued. The policy is created first, so a
+	// retry after a failure here finishes the job.
+	if offer.Status == OfferStatusAccepted {
+		now := s.clock()
+		before := offer
+		offer.Status = OfferStatusIssued
+		if err := s.offers.Update(ctx, offer); err != nil {
+			return Policy{}, err
+		}
+		offer.Version++
+		if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditOffer, offer.ID, AuditIssued, before, offer, now)); err != nil {
+			return Policy{}, err
+		}
+	}
+
+	return policy, nil
+}
+
+// create issues the policy for an accepted offer.
+func (s *policyService) create(ctx context.Context, offer Offer) (Policy, error) {
+	// Load application for insured details
 	app, err := s.apps.Get(ctx, offer.ApplicationID)
 	if err != nil {
 		return Policy{}, err
 	}
 
-	// 5) Generate policy number
+	// Generate policy number
 	policyNumber, err := s.policies.NextPolicyNumber(ctx)
 	if err != nil {
 		return Policy{}, fmt.Errorf("failed to generate policy number: %w", err)
 	}
 
-	// 6) Calculate dates
+	// Calculate dates
 	now := s.clock()
 	effectiveDate := now
 	expiryDate := effectiveDate.AddDate(offer.TermYears, 0, 0)
 
-	// 7) Create policy
 	policy := Policy{
 		ID:             ids.New(),
 		Number:         policyNumber,
@@ -95,19 +126,17 @@ func (s *policyService) IssueFromOffer(ctx context.Context, offerID string) (Pol
 		IssuedAt:       now,
 	}
 
-	// 8) Save policy
+	// Save policy
 	if err := s.policies.Create(ctx, policy); err != nil {
 		if errors.Is(err, ErrPolicyExists) {
 			// Race condition - policy was created by another process
-			return s.policies.GetByOfferID(ctx, offerID)
+			return s.policies.GetByOfferID(ctx, offer.ID)
 		}
 		return Policy{}, err
 	}
-
-	// 9) Update offer status to issued (best-effort, policy is already created)
-	offer.Status = OfferStatusIssued
-	_ = s.offers.Update(ctx, offer) // Ignore error - policy exists, offer update is non-critical
-
+	if err := appendAudit(ctx, s.audit, newAuditEntry(ctx, AuditPolicy, policy.ID, AuditCreated, nil, policy, now)); err != nil {
+		return Policy{}, err
+	}
 	return policy, nil
 }
 
@@ -115,25 +144,56 @@ func (s *policyService) Get(ctx context.Context, id string) (Policy, error) {
 	if id == "" {
 		return Policy{}, fmt.Errorf("%w: missing policy ID", ErrValidation)
 	}
-	return s.policies.Get(ctx, id)
+	policy, err := s.policies.Get(ctx, id)
+	if err != nil {
+		return Policy{}, err
+	}
+	if err := s.authorizePolicy(ctx, policy); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
 }
 
 func (s *policyService) GetByNumber(ctx context.Context, number string) (Policy, error) {
 	if number == "" {
 		return Policy{}, fmt.Errorf("%w: missing policy number", ErrValidation)
 	}
-	return s.policies.GetByNumber(ctx, number)
+	policy, err := s.policies.GetByNumber(ctx, number)
+	if err != nil {
+		return Policy{}, err
+	}
+	if err := s.authorizePolicy(ctx, policy); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
 }
 
-func (s *policyService) List(ctx context.Context, filter PolicyFilter, limit, offset int) ([]Policy, int64, error) {
-	if limit <= 0 {
-		limit = 20
+func (s *policyService) List(ctx context.Context, filter PolicyFilter, page PageRequest) (Page[Policy], error) {
+	if _, err := authorize(ctx, RoleAgent, RoleUnderwriter); err != nil {
+		return Page[Policy]{}, err
+	}
+	if err := filter.Issued.Validate(); err != nil {
+		return Page[Policy]{}, err
 	}
-	if limit > 100 {
-		limit = 100
+	return s.policies.List(ctx, filter, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
+
+func (s *policyService) History(ctx context.Context, id string, page PageRequest) (Page[AuditEntry], error) {
+	if _, err := authorize(ctx, RoleAgent, RoleUnderwriter); err != nil {
+		return Page[AuditEntry]{}, err
 	}
-	if offset < 0 {
-		offset = 0
+	if _, err := s.policies.Get(ctx, id); err != nil {
+		return Page[AuditEntry]{}, err
+	}
+	return s.audit.History(ctx, AuditPolicy, id, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
+
+// authorizePolicy applies the originating application's access rules.
+func (s *policyService) authorizePolicy(ctx context.Context, policy Policy) error {
+	app, err := s.apps.Get(ctx, policy.ApplicationID)
+	if err != nil {
+		return err
 	}
-	return s.policies.List(ctx, filter, limit, offset)
+	_, err = authorizeOwner(ctx, app.OwnerID)
+	return err
 }
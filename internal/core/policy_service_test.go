@@ -0,0 +1,62 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/store/memory"
+)
+
+// flakyAudit fails the first append of action.
+type flakyAudit struct {
+	*memory.AuditRepo
+	action core.AuditAction
+	failed bool
+}
+
+func (r *flakyAudit) Append(ctx context.Context, entries ...core.AuditEntry) error {
+	if !r.failed && entries[0].Action == r.action {
+		r.failed = true
+		return errors.New("connection reset")
+	}
+	return r.AuditRepo.Append(ctx, entries...)
+}
+
+// A failed audit write fails issuance, naming the missing entry, and the
+// retry finishes issuing the same policy.
+func TestIssueFromOfferResumes(t *testing.T) {
+	ctx := core.WithPrincipal(context.Background(), core.SystemPrincipal)
+	apps, offers, policies := memory.NewApplicationRepo(), memory.NewOfferRepo(), memory.NewPolicyRepo()
+	svc := core.NewPolicyService(policies, offers, apps, &flakyAudit{AuditRepo: memory.NewAuditRepo(), action: core.AuditIssued})
+
+	now := time.Now()
+	app := core.Application{ID: "01JA0000000000000000000001", Status: core.ApplicationStatusApproved, CreatedAt: now}
+	offer := core.Offer{
+		ID: "01JO0000000000000000000001", ApplicationID: app.ID, TermYears: 20,
+		Status: core.OfferStatusAccepted, CreatedAt: now, ExpiresAt: now.Add(time.Hour), AcceptedAt: &now,
+	}
+	if err := apps.Create(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := offers.Create(ctx, offer); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := svc.IssueFromOffer(ctx, offer.ID)
+	if err == nil || !strings.Contains(err.Error(), "offer "+offer.ID+" issued") {
+		t.Fatalf("first attempt: %v, want the failed entry named", err)
+	}
+	first, _ := policies.GetByOfferID(ctx, offer.ID)
+
+	policy, err := svc.IssueFromOffer(ctx, offer.ID)
+	if err != nil || policy.ID != first.ID {
+		t.Fatalf("retry = %+v, %v; want policy %s", policy, err, first.ID)
+	}
+	if got, _ := offers.Get(ctx, offer.ID); got.Status != core.OfferStatusIssued {
+		t.Errorf("offer status = %q, want issued", got.Status)
+	}
+}
@@ -40,6 +40,6 @@ func (p Product) Validate() error {
 
 // Error helpers pertaining to products.
 var (
-	ErrProductNotFound = fmt.Errorf("%v: product not found", ErrNotFound)
-	ErrProductConflict = fmt.Errorf("%v: product already exists", ErrConflict)
+	ErrProductNotFound = NewError(ErrNotFound, "product_not_found", "product not found")
+	ErrProductConflict = NewError(ErrConflict, "product_exists", "product already exists")
 )
@@ -39,13 +39,17 @@ func (s *quoteService) Price(ctx context.Context, in QuoteInput) (Quote, error)
 	}
 
 	// 3) validation against product bounds
+	var v violations
 	if in.CoverageAmount < p.MinCoverage || in.CoverageAmount > p.MaxCoverage {
-		return Quote{}, fmt.Errorf("%w: coverage must be between %d and %d",
-			ErrValidation, p.MinCoverage, p.MaxCoverage)
+		v.add("/coverage_amount", FieldOutOfRange,
+			fmt.Sprintf("coverage must be between %d and %d", p.MinCoverage, p.MaxCoverage))
 	}
 	if in.TermYears != p.TermYears {
-		return Quote{}, fmt.Errorf("%w: term must be %d years for product %s",
-			ErrValidation, p.TermYears, p.Slug)
+		v.add("/term_years", FieldUnsupported,
+			fmt.Sprintf("term must be %d years for product %s", p.TermYears, p.Slug))
+	}
+	if err := v.err(); err != nil {
+		return Quote{}, err
 	}
 
 	// 4) price
@@ -77,6 +81,16 @@ func (s *quoteService) Price(ctx context.Context, in QuoteInput) (Quote, error)
 	return q, nil
 }
 
+func (s *quoteService) List(ctx context.Context, filter QuoteFilter, page PageRequest) (Page[Quote], error) {
+	if _, err := authorize(ctx, RoleAgent, RoleUnderwriter); err != nil {
+		return Page[Quote]{}, err
+	}
+	if err := filter.Created.Validate(); err != nil {
+		return Page[Quote]{}, err
+	}
+	return s.quotes.List(ctx, filter, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
+
 func factorAge(age int) float64 {
 	switch {
 	case age <= 30:
@@ -2,7 +2,6 @@ package core
 
 import (
 	"context"
-	"fmt"
 	"time"
 )
 
@@ -38,29 +37,44 @@ type Quote struct {
 type QuoteRepo interface {
 	Create(ctx context.Context, q Quote) error
 	Get(ctx context.Context, id string) (Quote, error)
+
+	// GetMany returns the quotes with the given IDs, skipping missing ones.
+	GetMany(ctx context.Context, ids []string) ([]Quote, error)
+
+	// ExpireQuotes marks new and priced quotes that expired before the given
+	// time as expired, and returns how many it changed.
+	ExpireQuotes(ctx context.Context, before time.Time) (int64, error)
+
+	// List returns quotes matching the filter, newest first.
+	List(ctx context.Context, filter QuoteFilter, page PageRequest) (Page[Quote], error)
 }
 
 // Pricing is pure domain/service logic; no I/O beyond reading product(s).
 type QuoteService interface {
 	Price(ctx context.Context, in QuoteInput) (Quote, error)
+
+	// List returns quotes, newest first (staff only)
+	List(ctx context.Context, filter QuoteFilter, page PageRequest) (Page[Quote], error)
 }
 
 func (in QuoteInput) Validate() error {
+	var v violations
 	if in.ProductSlug == "" {
-		return fmt.Errorf("%w: missing product slug", ErrValidation)
+		v.add("/product_slug", FieldRequired, "missing product slug")
 	}
 	if in.CoverageAmount <= 0 {
-		return fmt.Errorf("%w: coverage must be > 0", ErrValidation)
+		v.add("/coverage_amount", FieldOutOfRange, "coverage must be > 0")
 	}
 	if in.TermYears <= 0 {
-		return fmt.Errorf("%w: term must be > 0", ErrValidation)
+		v.add("/term_years", FieldOutOfRange, "term must be > 0")
 	}
 	if in.Age <= 0 || in.Age > 120 {
-		return fmt.Errorf("%w: invalid age", ErrValidation)
+		v.add("/age", FieldOutOfRange, "invalid age")
 	}
-	return nil
+	return v.err()
 }
 
 var (
-	ErrQuoteNotFound = fmt.Errorf("%w: quote not found", ErrNotFound)
+	ErrQuoteNotFound = NewError(ErrNotFound, "quote_not_found", "quote not found")
+	ErrQuoteExpired  = NewError(ErrInvalidState, "quote_expired", "quote has expired")
 )
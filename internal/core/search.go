@@ -0,0 +1,219 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SearchKind is the type of resource a search result points to.
+type SearchKind string
+
+const (
+	SearchKindApplicant   SearchKind = "applicant" // A person, by email, across their applications and policies
+	SearchKindApplication SearchKind = "application"
+	SearchKindPolicy      SearchKind = "policy"
+)
+
+func (k SearchKind) Valid() bool {
+	switch k {
+	case SearchKindApplicant, SearchKindApplication, SearchKindPolicy:
+		return true
+	}
+	return false
+}
+
+// SearchDoc is what the search index holds for one resource.
+type SearchDoc struct {
+	Kind         SearchKind `json:"kind"`
+	ID           string     `json:"id"` // Resource ID; the lowercased email for applicants
+	FirstName    string     `json:"first_name"`
+	LastName     string     `json:"last_name"`
+	Email        string     `json:"email"`
+	DateOfBirth  string     `json:"date_of_birth"`
+	PolicyNumber string     `json:"policy_number,omitempty"`
+	ProductSlug  string     `json:"product_slug,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// SearchHit is one search result.
+type SearchHit struct {
+	SearchDoc
+	Score float64 `json:"score"`
+}
+
+// SearchField is the field a query is matched against.
+type SearchField string
+
+const (
+	SearchByName         SearchField = "name"
+	SearchByEmail        SearchField = "email"
+	SearchByPolicyNumber SearchField = "policy_number"
+	SearchByDateOfBirth  SearchField = "date_of_birth"
+)
+
+// SearchQuery is a parsed search. Terms are lowercased; for name searches
+// every term must match the start of a first or last name, otherwise the
+// single term is an email or policy number prefix or an exact date of birth.
+type SearchQuery struct {
+	Field SearchField
+	Terms []string
+	Kinds []SearchKind // Empty for every kind
+}
+
+const maxSearchQueryLength = 200
+
+var (
+	dateOfBirthRegex  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	policyNumberRegex = regexp.MustCompile(`(?i)^pol-[0-9-]*$`)
+)
+
+// ParseSearchQuery works out from its shape what a staff member typed:
+// a date of birth (YYYY-MM-DD), an email (contains @), a policy number
+// prefix (POL-...) or otherwise a name.
+func ParseSearchQuery(text string, kinds []SearchKind) (SearchQuery, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return SearchQuery{}, fmt.Errorf("%w: search query is required", ErrValidation)
+	}
+	if len(text) > maxSearchQueryLength {
+		return SearchQuery{}, fmt.Errorf("%w: search query is longer than %d characters", ErrValidation, maxSearchQueryLength)
+	}
+	for _, k := range kinds {
+		if !k.Valid() {
+			return SearchQuery{}, fmt.Errorf("%w: unknown search type %q", ErrValidation, k)
+		}
+	}
+
+	q := SearchQuery{Kinds: kinds}
+	lower := strings.ToLower(text)
+	switch {
+	case dateOfBirthRegex.MatchString(text):
+		q.Field, q.Terms = SearchByDateOfBirth, []string{text}
+	case strings.Contains(text, "@"):
+		q.Field, q.Terms = SearchByEmail, []string{lower}
+	case policyNumberRegex.MatchString(text):
+		q.Field, q.Terms = SearchByPolicyNumber, []string{lower}
+	default:
+		q.Field, q.Terms = SearchByName, strings.Fields(lower)
+	}
+	return q, nil
+}
+
+// Matches reports whether the query names kind.
+func (q SearchQuery) Matches(kind SearchKind) bool {
+	if len(q.Kinds) == 0 {
+		return true
+	}
+	for _, k := range q.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchIndex finds people, applications and policies. It is derived data:
+// stores keep it up to date on writes and it can be rebuilt from the repos.
+type SearchIndex interface {
+	// Put adds a document, or replaces it unless the stored one was updated
+	// later. An applicant therefore shows their most recent details.
+	Put(ctx context.Context, doc SearchDoc) error
+
+	// Search returns matching documents, best match first
+	Search(ctx context.Context, q SearchQuery, page PageRequest) (Page[SearchHit], error)
+}
+
+// ApplicationSearchDocs returns the index documents for an application:
+// the application itself and its applicant.
+func ApplicationSearchDocs(app Application) []SearchDoc {
+	doc := SearchDoc{
+		Kind:        SearchKindApplication,
+		ID:          app.ID,
+		FirstName:   app.Applicant.FirstName,
+		LastName:    app.Applicant.LastName,
+		Email:       app.Applicant.Email,
+		DateOfBirth: app.Applicant.DateOfBirth,
+		ProductSlug: app.ProductSlug,
+		Status:      string(app.Status),
+		UpdatedAt:   app.UpdatedAt,
+	}
+	return []SearchDoc{doc, applicantSearchDoc(doc)}
+}
+
+// PolicySearchDocs returns the index documents for a policy: the policy
+// itself and its insured.
+func PolicySearchDocs(p Policy) []SearchDoc {
+	doc := SearchDoc{
+		Kind:         SearchKindPolicy,
+		ID:           p.ID,
+		FirstName:    p.Insured.FirstName,
+		LastName:     p.Insured.LastName,
+		Email:        p.Insured.Email,
+		DateOfBirth:  p.Insured.DateOfBirth,
+		PolicyNumber: p.Number,
+		ProductSlug:  p.ProductSlug,
+		Status:       string(p.Status),
+		UpdatedAt:    p.IssuedAt,
+	}
+	return []SearchDoc{doc, applicantSearchDoc(doc)}
+}
+
+func applicantSearchDoc(d SearchDoc) SearchDoc {
+	return SearchDoc{
+		Kind:        SearchKindApplicant,
+		ID:          strings.ToLower(d.Email),
+		FirstName:   d.FirstName,
+		LastName:    d.LastName,
+		Email:       d.Email,
+		DateOfBirth: d.DateOfBirth,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+type SearchService interface {
+	// Search looks up applicants, applications and policies (staff only)
+	Search(ctx context.Context, text string, kinds []SearchKind, page PageRequest) (Page[SearchHit], error)
+}
+
+type searchService struct {
+	index SearchIndex
+}
+
+func NewSearchService(index SearchIndex) SearchService {
+	return &searchService{index: index}
+}
+
+func (s *searchService) Search(ctx context.Context, text string, kinds []SearchKind, page PageRequest) (Page[SearchHit], error) {
+	if _, err := authorize(ctx, RoleAgent, RoleUnderwriter); err != nil {
+		return Page[SearchHit]{}, err
+	}
+	q, err := ParseSearchQuery(text, kinds)
+	if err != nil {
+		return Page[SearchHit]{}, err
+	}
+	return s.index.Search(ctx, q, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
+
+// SearchCursor resumes a search at an offset into its ranked results.
+type SearchCursor struct {
+	Offset int `json:"o"`
+}
+
+// DecodeSearchCursor returns the offset a search page starts at.
+func DecodeSearchCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	var c SearchCursor
+	if err := DecodeCursor(cursor, &c); err != nil {
+		return 0, err
+	}
+	if c.Offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return c.Offset, nil
+}
@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,6 +16,10 @@ const (
 	UWDecisionApproved UWDecision = "approved"
 	UWDecisionDeclined UWDecision = "declined"
 	UWDecisionReferred UWDecision = "referred" // Needs manual review
+
+	// UWDecisionPendingApproval means a manual decision exceeded the deciding
+	// underwriter's authority and awaits confirmation by a second underwriter.
+	UWDecisionPendingApproval UWDecision = "pending_approval"
 )
 
 const (
@@ -43,12 +49,55 @@ type UnderwritingCase struct {
 	RiskFactors   RiskFactors `json:"risk_factors"`
 	RiskScore     RiskScore   `json:"risk_score"`
 	Decision      UWDecision  `json:"decision"`
-	Method        UWMethod    `json:"method"` // auto or manual
-	DecidedBy     string      `json:"decided_by"` // "system" or admin user ID
-	Reason        string      `json:"reason"` // Explanation for decision
+	Method        UWMethod    `json:"method"`     // auto or manual
+	DecidedBy     string      `json:"decided_by"` // "system" or the deciding principal's subject
+	Reason        string      `json:"reason"`     // Explanation for decision
 	CreatedAt     time.Time   `json:"created_at"`
 	UpdatedAt     time.Time   `json:"updated_at"`
 	DecidedAt     *time.Time  `json:"decided_at,omitempty"`
+
+	// Work queue tracking for referred cases
+	AssignedTo  string     `json:"assigned_to,omitempty"` // Underwriter who owns the case
+	AssignedAt  *time.Time `json:"assigned_at,omitempty"`
+	SLADueAt    *time.Time `json:"sla_due_at,omitempty"` // Manual decision due by
+	EscalatedAt *time.Time `json:"escalated_at,omitempty"`
+
+	// Four-eyes approval
+	PendingDecision *UWDecisionStep  `json:"pending_decision,omitempty"` // Proposal awaiting confirmation
+	DecisionChain   []UWDecisionStep `json:"decision_chain,omitempty"`   // Every manual step, oldest first
+
+	Version int `json:"-"` // Bumped on every write
+}
+
+type UWStepAction string
+
+const (
+	UWStepDecided   UWStepAction = "decided"   // Final decision within the underwriter's authority
+	UWStepProposed  UWStepAction = "proposed"  // Exceeded authority, awaiting confirmation
+	UWStepConfirmed UWStepAction = "confirmed" // Second underwriter accepted the proposal
+	UWStepRejected  UWStepAction = "rejected"  // Second underwriter sent the case back to the queue
+)
+
+// UWDecisionStep records one underwriter's action in the manual decision chain.
+type UWDecisionStep struct {
+	Underwriter string       `json:"underwriter"`
+	Action      UWStepAction `json:"action"`
+	Decision    UWDecision   `json:"decision"`
+	Reason      string       `json:"reason"`
+	At          time.Time    `json:"at"`
+}
+
+// UWConfirmInput is a second underwriter's response to a pending decision.
+type UWConfirmInput struct {
+	Confirm bool   `json:"confirm"` // false sends the case back to referred
+	Reason  string `json:"reason"`
+}
+
+// UWAuthority is the largest case an underwriter may approve on their own.
+type UWAuthority struct {
+	Underwriter  string
+	MaxCoverage  int64
+	MaxRiskScore int
 }
 
 type UWDecisionInput struct {
@@ -56,30 +105,167 @@ type UWDecisionInput struct {
 	Reason   string     `json:"reason"`
 }
 
+// UWUnassigned filters the work queue down to cases nobody has claimed.
+const UWUnassigned = "-"
+
+// UWCaseFilter narrows the underwriting work queue.
+type UWCaseFilter struct {
+	Assignee      string     // Empty for any; UWUnassigned for unclaimed cases
+	Decision      UWDecision // Empty for any
+	CreatedBefore time.Time  // Zero for no bound (minimum age)
+	CreatedAfter  time.Time  // Zero for no bound (maximum age)
+}
+
+// UWConfig controls how referred cases are routed, escalated and approved.
+type UWConfig struct {
+	SLA                time.Duration      // Time allowed for a manual decision
+	Rules              []UWAssignmentRule // Evaluated in order; first match wins
+	EscalationAssignee string             // Optional: reassign breached cases here
+
+	// Authorities limits solo approvals per underwriter. When empty, limits
+	// are not enforced; otherwise unlisted underwriters have no authority.
+	Authorities []UWAuthority
+}
+
+// UWAssignmentRule routes a referred case to an underwriter.
+// Zero-valued conditions are ignored, so a rule with none matches everything.
+type UWAssignmentRule struct {
+	Assignee    string
+	MinScore    int
+	MinCoverage int64
+	Flag        string
+}
+
 type UnderwritingRepo interface {
 	Create(ctx context.Context, uw UnderwritingCase) error
 	Get(ctx context.Context, id string) (UnderwritingCase, error)
 	GetByApplicationID(ctx context.Context, appID string) (UnderwritingCase, error)
+	// FindByApplicationIDs returns the cases for the given applications, skipping ones without a case.
+	FindByApplicationIDs(ctx context.Context, appIDs []string) ([]UnderwritingCase, error)
+	// Update replaces the case if it is still at uw.Version, and stores it
+	// as the next version. Returns ErrChanged otherwise.
 	Update(ctx context.Context, uw UnderwritingCase) error
 	FindPending(ctx context.Context, limit int) ([]UnderwritingCase, error)
 	FindReferred(ctx context.Context, limit int) ([]UnderwritingCase, error)
+
+	// List returns cases matching the filter, oldest first.
+	List(ctx context.Context, filter UWCaseFilter, page PageRequest) (Page[UnderwritingCase], error)
+
+	// Assign sets the case owner if the case is still at version, and bumps
+	// it. Returns ErrChanged otherwise.
+	Assign(ctx context.Context, id, assignee string, version int, at time.Time) error
+
+	// FindSLABreached returns referred cases past their SLA that were not yet escalated.
+	FindSLABreached(ctx context.Context, now time.Time, limit int) ([]UnderwritingCase, error)
 }
 
 func (in UWDecisionInput) Validate() error {
+	var v violations
 	if in.Decision != UWDecisionApproved && in.Decision != UWDecisionDeclined {
-		return fmt.Errorf("%w: decision must be 'approved' or 'declined'", ErrValidation)
+		v.add("/decision", FieldUnsupported, "decision must be 'approved' or 'declined'")
 	}
 	if in.Reason == "" {
-		return fmt.Errorf("%w: reason is required", ErrValidation)
+		v.add("/reason", FieldRequired, "reason is required")
+	}
+	return v.err()
+}
+
+func (in UWConfirmInput) Validate() error {
+	var v violations
+	if in.Reason == "" {
+		v.add("/reason", FieldRequired, "reason is required")
+	}
+	return v.err()
+}
+
+// Covers checks whether the case is within this authority.
+func (a UWAuthority) Covers(c UnderwritingCase) bool {
+	return c.RiskFactors.CoverageAmount <= a.MaxCoverage && c.RiskScore.Score <= a.MaxRiskScore
+}
+
+// ParseUWAuthority parses "underwriter:max_coverage:max_risk_score".
+func ParseUWAuthority(spec string) (UWAuthority, error) {
+	parts := strings.Split(strings.TrimSpace(spec), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return UWAuthority{}, fmt.Errorf("%w: authority %q must be underwriter:max_coverage:max_risk_score", ErrValidation, spec)
+	}
+	coverage, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return UWAuthority{}, fmt.Errorf("%w: authority %q: invalid coverage", ErrValidation, spec)
+	}
+	score, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return UWAuthority{}, fmt.Errorf("%w: authority %q: invalid risk score", ErrValidation, spec)
+	}
+	return UWAuthority{Underwriter: parts[0], MaxCoverage: coverage, MaxRiskScore: score}, nil
+}
+
+// Age returns how long the case has been open.
+func (c UnderwritingCase) Age(now time.Time) time.Duration {
+	return now.Sub(c.CreatedAt)
+}
+
+// SLABreached reports whether a referred case is past its due time.
+func (c UnderwritingCase) SLABreached(now time.Time) bool {
+	return c.Decision == UWDecisionReferred && c.SLADueAt != nil && now.After(*c.SLADueAt)
+}
+
+// Matches checks whether the rule applies to the case.
+func (r UWAssignmentRule) Matches(c UnderwritingCase) bool {
+	if c.RiskScore.Score < r.MinScore {
+		return false
+	}
+	if c.RiskFactors.CoverageAmount < r.MinCoverage {
+		return false
+	}
+	if r.Flag != "" {
+		for _, f := range c.RiskScore.Flags {
+			if f == r.Flag {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// ParseUWAssignmentRule parses "assignee:condition", where condition is one of
+// "score>=N", "coverage>=N", "flag=NAME" or "*".
+func ParseUWAssignmentRule(spec string) (UWAssignmentRule, error) {
+	assignee, cond, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok || assignee == "" || cond == "" {
+		return UWAssignmentRule{}, fmt.Errorf("%w: assignment rule %q must be assignee:condition", ErrValidation, spec)
+	}
+
+	rule := UWAssignmentRule{Assignee: assignee}
+	switch {
+	case cond == "*":
+	case strings.HasPrefix(cond, "score>="):
+		n, err := strconv.Atoi(strings.TrimPrefix(cond, "score>="))
+		if err != nil {
+			return UWAssignmentRule{}, fmt.Errorf("%w: assignment rule %q: invalid score", ErrValidation, spec)
+		}
+		rule.MinScore = n
+	case strings.HasPrefix(cond, "coverage>="):
+		n, err := strconv.ParseInt(strings.TrimPrefix(cond, "coverage>="), 10, 64)
+		if err != nil {
+			return UWAssignmentRule{}, fmt.Errorf("%w: assignment rule %q: invalid coverage", ErrValidation, spec)
+		}
+		rule.MinCoverage = n
+	case strings.HasPrefix(cond, "flag="):
+		rule.Flag = strings.TrimPrefix(cond, "flag=")
+	default:
+		return UWAssignmentRule{}, fmt.Errorf("%w: assignment rule %q: unknown condition", ErrValidation, spec)
 	}
-	return nil
+	return rule, nil
 }
 
 // CanTransitionTo checks if a decision transition is valid.
 func (d UWDecision) CanTransitionTo(next UWDecision) bool {
 	transitions := map[UWDecision][]UWDecision{
-		UWDecisionPending:  {UWDecisionApproved, UWDecisionDeclined, UWDecisionReferred},
-		UWDecisionReferred: {UWDecisionApproved, UWDecisionDeclined},
+		UWDecisionPending:         {UWDecisionApproved, UWDecisionDeclined, UWDecisionReferred},
+		UWDecisionReferred:        {UWDecisionApproved, UWDecisionDeclined, UWDecisionPendingApproval},
+		UWDecisionPendingApproval: {UWDecisionApproved, UWDecisionDeclined, UWDecisionReferred},
 	}
 	for _, allowed := range transitions[d] {
 		if allowed == next {
@@ -157,8 +343,14 @@ func ShouldAutoDecline(factors RiskFactors) bool {
 }
 
 var (
-	ErrUWCaseNotFound    = fmt.Errorf("%w: underwriting case not found", ErrNotFound)
-	ErrUWCaseExists      = fmt.Errorf("%w: underwriting case already exists for application", ErrConflict)
-	ErrUWAlreadyDecided  = fmt.Errorf("%w: underwriting case already decided", ErrInvalidState)
-	ErrUWInvalidDecision = fmt.Errorf("%w: invalid underwriting decision", ErrValidation)
+	ErrUWCaseNotFound       = NewError(ErrNotFound, "uw_case_not_found", "underwriting case not found")
+	ErrUWCaseExists         = NewError(ErrConflict, "uw_case_exists", "underwriting case already exists for application")
+	ErrUWAlreadyDecided     = NewError(ErrInvalidState, "uw_case_decided", "underwriting case already decided")
+	ErrUWInvalidDecision    = NewError(ErrValidation, "uw_invalid_decision", "invalid underwriting decision")
+	ErrUWCaseLocked         = NewError(ErrConflict, "uw_case_locked", "underwriting case is held by another underwriter")
+	ErrUWPendingApproval    = NewError(ErrInvalidState, "uw_case_pending_approval", "decision is awaiting approval by a second underwriter")
+	ErrAppNotSubmitted      = NewError(ErrInvalidState, "application_not_submitted", "application must be in submitted status")
+	ErrUWNotPendingApproval = NewError(ErrInvalidState, "uw_case_not_pending_approval", "underwriting case has no decision awaiting approval")
+	ErrUWSameApprover       = NewError(ErrForbidden, "uw_same_approver", "a different underwriter must confirm the decision")
+	ErrUWNoAuthority        = NewError(ErrForbidden, "uw_no_authority", "underwriter lacks authority for this case")
 )
@@ -13,9 +13,13 @@ type UnderwritingService interface {
 	// ProcessApplication is called by the worker when an application is submitted
 	ProcessApplication(ctx context.Context, appID string) (UnderwritingCase, error)
 
-	// MakeDecision is called by admin for manual decisions on referred cases
+	// MakeDecision is called by an underwriter for manual decisions on referred cases.
+	// Approvals beyond their authority become pending until a second underwriter confirms.
 	MakeDecision(ctx context.Context, caseID string, input UWDecisionInput) (UnderwritingCase, error)
 
+	// ConfirmDecision confirms or rejects a decision awaiting four-eyes approval
+	ConfirmDecision(ctx context.Context, caseID string, input UWConfirmInput) (UnderwritingCase, error)
+
 	// GetCase retrieves a case by ID
 	GetCase(ctx context.Context, caseID string) (UnderwritingCase, error)
 
@@ -24,53 +28,102 @@ type UnderwritingService interface {
 
 	// ListReferred returns cases awaiting manual review
 	ListReferred(ctx context.Context, limit int) ([]UnderwritingCase, error)
+
+	// ListCases returns the work queue filtered by assignee, decision and age
+	ListCases(ctx context.Context, filter UWCaseFilter, page PageRequest) (Page[UnderwritingCase], error)
+
+	// ClaimCase assigns an unclaimed referred case to the calling underwriter
+	ClaimCase(ctx context.Context, caseID string) (UnderwritingCase, error)
+
+	// AssignCase hands a referred case to another underwriter
+	AssignCase(ctx context.Context, caseID, assignee string) (UnderwritingCase, error)
+
+	// EscalateBreached marks referred cases past their SLA as escalated
+	EscalateBreached(ctx context.Context, limit int) ([]UnderwritingCase, error)
+
+	// History returns a case's audit trail, oldest first
+	History(ctx context.Context, caseID string, page PageRequest) (Page[AuditEntry], error)
 }
 
 type underwritingService struct {
 	uw     UnderwritingRepo
 	apps   ApplicationRepo
 	offers OfferRepo
+	audit  AuditRepo
+	cfg    UWConfig
 	clock  func() time.Time
 }
 
-func NewUnderwritingService(uw UnderwritingRepo, apps ApplicationRepo, offers OfferRepo) UnderwritingService {
+func NewUnderwritingService(uw UnderwritingRepo, apps ApplicationRepo, offers OfferRepo, audit AuditRepo, cfg UWConfig) UnderwritingService {
 	return &underwritingService{
 		uw:     uw,
 		apps:   apps,
 		offers: offers,
+		audit:  audit,
+		cfg:    cfg,
 		clock:  time.Now,
 	}
 }
 
+// ProcessApplication opens the case for a submitted application and carries
+// out an automatic decision. Every step is skipped or repeated safely, so a
+// job that failed part-way resumes on retry: the case is created first, and
+// the application's status is set last.
 func (s *underwritingService) ProcessApplication(ctx context.Context, appID string) (UnderwritingCase, error) {
-	// 1) Load application
+	// 1) Authorize (underwriting worker only) and load application
+	if _, err := authorize(ctx); err != nil {
+		return UnderwritingCase{}, err
+	}
 	app, err := s.apps.Get(ctx, appID)
 	if err != nil {
 		return UnderwritingCase{}, err
 	}
+	inReview := app.Status == ApplicationStatusSubmitted || app.Status == ApplicationStatusUnderReview
 
-	// 2) Verify application is in submitted status
-	if app.Status != ApplicationStatusSubmitted {
-		return UnderwritingCase{}, fmt.Errorf("%w: application must be in submitted status", ErrInvalidState)
+	// 2) Reuse the case an earlier attempt created, or create it
+	now := s.clock()
+	uwCase, err := s.uw.GetByApplicationID(ctx, appID)
+	switch {
+	case err == nil:
+		if !inReview {
+			// Finished, or decided by an underwriter since
+			return uwCase, nil
+		}
+	case errors.Is(err, ErrUWCaseNotFound):
+		// Under review without a case when an earlier attempt failed
+		if !inReview {
+			return UnderwritingCase{}, ErrAppNotSubmitted
+		}
+		uwCase = s.openCase(app, now)
+		if err := s.uw.Create(ctx, uwCase); err != nil {
+			return UnderwritingCase{}, err
+		}
+		if err := s.record(ctx, AuditUnderwritingCase, uwCase.ID, AuditCreated, nil, uwCase, uwCase.Reason, now); err != nil {
+			return UnderwritingCase{}, err
+		}
+	default:
+		return UnderwritingCase{}, err
 	}
 
-	// 3) Check if UW case already exists
-	existing, err := s.uw.GetByApplicationID(ctx, appID)
-	if err == nil {
-		// Case already exists, return it
-		return existing, nil
-	}
-	if !errors.Is(err, ErrUWCaseNotFound) {
-		return UnderwritingCase{}, err
+	// 3) Update application status to under_review
+	if app.Status == ApplicationStatusSubmitted {
+		if app, err = s.updateAppStatus(ctx, app, ApplicationStatusUnderReview, "", now); err != nil {
+			return UnderwritingCase{}, err
+		}
 	}
 
-	// 4) Update application status to under_review
-	now := s.clock()
-	if err := s.apps.UpdateStatus(ctx, appID, ApplicationStatusUnderReview, now); err != nil {
+	// 4) Carry out a decision, automatic or made by hand since
+	if err := s.settle(ctx, app, uwCase, now); err != nil {
 		return UnderwritingCase{}, err
 	}
 
-	// 5) Build risk factors
+	return uwCase, nil
+}
+
+// openCase scores the application and makes the automatic decision for
+// its new case.
+func (s *underwritingService) openCase(app Application, now time.Time) UnderwritingCase {
+	// Build risk factors
 	factors := RiskFactors{
 		Age:            app.Applicant.Age,
 		Smoker:         app.Applicant.Smoker,
@@ -78,16 +131,13 @@ func (s *underwritingService) ProcessApplication(ctx context.Context, appID stri
 		TermYears:      app.TermYears,
 	}
 
-	// 6) Score risk
+	// Score risk and determine decision
 	score := ScoreRisk(factors)
-
-	// 7) Determine decision
 	decision, method := s.determineDecision(factors, score)
 
-	// 8) Create UW case
 	uwCase := UnderwritingCase{
 		ID:            ids.New(),
-		ApplicationID: appID,
+		ApplicationID: app.ID,
 		RiskFactors:   factors,
 		RiskScore:     score,
 		Decision:      decision,
@@ -106,90 +156,272 @@ func (s *underwritingService) ProcessApplication(ctx context.Context, appID stri
 			uwCase.Reason = "Auto-declined: does not meet eligibility requirements"
 		}
 	}
+	if decision == UWDecisionReferred {
+		s.enqueue(&uwCase, now)
+	}
+	return uwCase
+}
 
-	// 9) Save UW case
-	if err := s.uw.Create(ctx, uwCase); err != nil {
+func (s *underwritingService) MakeDecision(ctx context.Context, caseID string, input UWDecisionInput) (UnderwritingCase, error) {
+	// 1) Authorize and validate input
+	p, err := authorize(ctx, RoleUnderwriter)
+	if err != nil {
 		return UnderwritingCase{}, err
 	}
+	if err := input.Validate(); err != nil {
+		return UnderwritingCase{}, err
+	}
+	underwriter := p.Subject
 
-	// 10) If auto-approved, update application and create offer
-	if decision == UWDecisionApproved {
-		if err := s.apps.UpdateStatus(ctx, appID, ApplicationStatusApproved, now); err != nil {
-			return UnderwritingCase{}, err
+	// 2) Load case
+	uwCase, err := s.uw.Get(ctx, caseID)
+	if err != nil {
+		return UnderwritingCase{}, err
+	}
+
+	// A retry of a decision that was stored, but not carried out, finishes it
+	if lastStep(uwCase, underwriter, UWStepDecided, input.Decision) {
+		return s.resume(ctx, uwCase)
+	}
+
+	if err := checkVersion(ctx, uwCase.Version); err != nil {
+		return UnderwritingCase{}, err
+	}
+
+	// 3) Verify case can be decided (pending approvals go through ConfirmDecision)
+	if uwCase.Decision == UWDecisionPendingApproval {
+		return UnderwritingCase{}, ErrUWPendingApproval
+	}
+	if !uwCase.Decision.CanTransitionTo(input.Decision) {
+		return UnderwritingCase{}, fmt.Errorf("%w: cannot transition from %s to %s",
+			ErrUWAlreadyDecided, uwCase.Decision, input.Decision)
+	}
+
+	// 4) Take the lock: claim an unassigned case, reject one held by someone else
+	now := s.clock()
+	switch uwCase.AssignedTo {
+	case underwriter:
+	case "":
+		if err := s.uw.Assign(ctx, caseID, underwriter, uwCase.Version, now); err != nil {
+			return UnderwritingCase{}, versionErr(ctx, err)
 		}
-		if err := s.createOffer(ctx, app, now); err != nil {
+		before := uwCase
+		uwCase.AssignedTo = underwriter
+		uwCase.AssignedAt = &now
+		uwCase.UpdatedAt = now
+		uwCase.Version++
+		if err := s.record(ctx, AuditUnderwritingCase, caseID, AuditClaimed, before, uwCase, "", now); err != nil {
 			return UnderwritingCase{}, err
 		}
-	} else if decision == UWDecisionDeclined {
-		if err := s.apps.UpdateStatus(ctx, appID, ApplicationStatusDeclined, now); err != nil {
+	default:
+		return UnderwritingCase{}, ErrUWCaseLocked
+	}
+
+	// 5) Approvals beyond the underwriter's authority need a second pair of eyes
+	if input.Decision == UWDecisionApproved && !s.hasAuthority(underwriter, uwCase) {
+		step := UWDecisionStep{
+			Underwriter: underwriter,
+			Action:      UWStepProposed,
+			Decision:    input.Decision,
+			Reason:      input.Reason,
+			At:          now,
+		}
+		before := uwCase
+		uwCase.Decision = UWDecisionPendingApproval
+		uwCase.PendingDecision = &step
+		uwCase.DecisionChain = append(uwCase.DecisionChain, step)
+		uwCase.UpdatedAt = now
+
+		if err := s.uw.Update(ctx, uwCase); err != nil {
+			return UnderwritingCase{}, versionErr(ctx, err)
+		}
+		uwCase.Version++
+		if err := s.record(ctx, AuditUnderwritingCase, caseID, AuditProposed, before, uwCase, input.Reason, now); err != nil {
 			return UnderwritingCase{}, err
 		}
+		return uwCase, nil
 	}
-	// If referred, application stays in under_review
 
-	return uwCase, nil
+	// 6) Within authority (declines never add exposure) - decide now
+	step := UWDecisionStep{
+		Underwriter: underwriter,
+		Action:      UWStepDecided,
+		Decision:    input.Decision,
+		Reason:      input.Reason,
+		At:          now,
+	}
+	return s.finalize(ctx, uwCase, step, input.Reason)
 }
 
-func (s *underwritingService) MakeDecision(ctx context.Context, caseID string, input UWDecisionInput) (UnderwritingCase, error) {
-	// 1) Validate input
+func (s *underwritingService) ConfirmDecision(ctx context.Context, caseID string, input UWConfirmInput) (UnderwritingCase, error) {
+	// 1) Authorize and validate input
+	p, err := authorize(ctx, RoleUnderwriter)
+	if err != nil {
+		return UnderwritingCase{}, err
+	}
 	if err := input.Validate(); err != nil {
 		return UnderwritingCase{}, err
 	}
+	underwriter := p.Subject
 
 	// 2) Load case
 	uwCase, err := s.uw.Get(ctx, caseID)
 	if err != nil {
 		return UnderwritingCase{}, err
 	}
+	if input.Confirm && lastStep(uwCase, underwriter, UWStepConfirmed, uwCase.Decision) {
+		return s.resume(ctx, uwCase) // As in MakeDecision
+	}
+	if err := checkVersion(ctx, uwCase.Version); err != nil {
+		return UnderwritingCase{}, err
+	}
+	if uwCase.Decision != UWDecisionPendingApproval || uwCase.PendingDecision == nil {
+		return UnderwritingCase{}, ErrUWNotPendingApproval
+	}
 
-	// 3) Verify case can be decided
-	if !uwCase.Decision.CanTransitionTo(input.Decision) {
-		return UnderwritingCase{}, fmt.Errorf("%w: cannot transition from %s to %s",
-			ErrInvalidState, uwCase.Decision, input.Decision)
+	// 3) Four-eyes: a different underwriter with enough authority
+	proposal := *uwCase.PendingDecision
+	if underwriter == proposal.Underwriter {
+		return UnderwritingCase{}, ErrUWSameApprover
+	}
+	if !s.hasAuthority(underwriter, uwCase) {
+		return UnderwritingCase{}, ErrUWNoAuthority
+	}
+
+	now := s.clock()
+	step := UWDecisionStep{
+		Underwriter: underwriter,
+		Decision:    proposal.Decision,
+		Reason:      input.Reason,
+		At:          now,
+	}
+	uwCase.PendingDecision = nil
+
+	// 4) Rejected proposals go back to the holder's queue
+	if !input.Confirm {
+		before := uwCase
+		step.Action = UWStepRejected
+		uwCase.DecisionChain = append(uwCase.DecisionChain, step)
+		uwCase.Decision = UWDecisionReferred
+		uwCase.UpdatedAt = now
+
+		if err := s.uw.Update(ctx, uwCase); err != nil {
+			return UnderwritingCase{}, versionErr(ctx, err)
+		}
+		uwCase.Version++
+		if err := s.record(ctx, AuditUnderwritingCase, caseID, AuditRejected, before, uwCase, input.Reason, now); err != nil {
+			return UnderwritingCase{}, err
+		}
+		return uwCase, nil
 	}
 
-	// 4) Load application for offer creation if approved
+	// 5) Confirmed - the confirming underwriter signs off the decision
+	step.Action = UWStepConfirmed
+	return s.finalize(ctx, uwCase, step, proposal.Reason)
+}
+
+// finalize records the final manual decision made by step, which decides
+// or confirms it with reason, and moves the application on.
+func (s *underwritingService) finalize(ctx context.Context, uwCase UnderwritingCase, step UWDecisionStep, reason string) (UnderwritingCase, error) {
+	// 1) Load application for offer creation if approved
 	app, err := s.apps.Get(ctx, uwCase.ApplicationID)
 	if err != nil {
 		return UnderwritingCase{}, err
 	}
 
-	// 5) Update case
-	now := s.clock()
-	uwCase.Decision = input.Decision
+	// 2) Update case unless it changed since it was read
+	now, decision := step.At, step.Decision
+	before := uwCase
+	uwCase.DecisionChain = append(uwCase.DecisionChain, step)
+	uwCase.Decision = decision
 	uwCase.Method = UWMethodManual
-	uwCase.DecidedBy = "admin" // In a real system, this would be the admin user ID
-	uwCase.Reason = input.Reason
+	uwCase.DecidedBy = step.Underwriter
+	uwCase.Reason = reason
 	uwCase.UpdatedAt = now
 	uwCase.DecidedAt = &now
 
 	if err := s.uw.Update(ctx, uwCase); err != nil {
-		return UnderwritingCase{}, err
+		return UnderwritingCase{}, versionErr(ctx, err)
 	}
-
-	// 6) Update application status
-	var newAppStatus ApplicationStatus
-	if input.Decision == UWDecisionApproved {
-		newAppStatus = ApplicationStatusApproved
-	} else {
-		newAppStatus = ApplicationStatusDeclined
+	uwCase.Version++
+	action := AuditAction(decision)
+	if step.Action == UWStepConfirmed {
+		action = AuditConfirmed
+	}
+	if err := s.record(ctx, AuditUnderwritingCase, uwCase.ID, action, before, uwCase, step.Reason, now); err != nil {
+		return UnderwritingCase{}, err
 	}
 
-	if err := s.apps.UpdateStatus(ctx, uwCase.ApplicationID, newAppStatus, now); err != nil {
+	// 3) Create the offer if approved, and move the application on
+	if err := s.settle(ctx, app, uwCase, now); err != nil {
 		return UnderwritingCase{}, err
 	}
 
-	// 7) Create offer if approved
-	if input.Decision == UWDecisionApproved {
-		if err := s.createOffer(ctx, app, now); err != nil {
-			return UnderwritingCase{}, err
+	return uwCase, nil
+}
+
+// settle moves an application under review on to its case's final
+// decision. The offer is created first, and the application's status set
+// last, so a retry after a failure part-way finishes the job.
+func (s *underwritingService) settle(ctx context.Context, app Application, uwCase UnderwritingCase, now time.Time) error {
+	if app.Status != ApplicationStatusUnderReview {
+		return nil
+	}
+	switch uwCase.Decision {
+	case UWDecisionApproved:
+		if err := s.createOffer(ctx, app, now); err != nil && !errors.Is(err, ErrOfferExists) {
+			return err
 		}
+		_, err := s.updateAppStatus(ctx, app, ApplicationStatusApproved, uwCase.Reason, now)
+		return err
+	case UWDecisionDeclined:
+		_, err := s.updateAppStatus(ctx, app, ApplicationStatusDeclined, uwCase.Reason, now)
+		return err
 	}
+	// If referred or pending approval, application stays in under_review
+	return nil
+}
 
+// resume settles the application of a case whose final decision was
+// stored by an earlier request that failed before it finished.
+func (s *underwritingService) resume(ctx context.Context, uwCase UnderwritingCase) (UnderwritingCase, error) {
+	app, err := s.apps.Get(ctx, uwCase.ApplicationID)
+	if err != nil {
+		return UnderwritingCase{}, err
+	}
+	if err := s.settle(ctx, app, uwCase, s.clock()); err != nil {
+		return UnderwritingCase{}, err
+	}
 	return uwCase, nil
 }
 
+// lastStep reports whether the case's final decision is decision, made by
+// underwriter with the step action.
+func lastStep(uwCase UnderwritingCase, underwriter string, action UWStepAction, decision UWDecision) bool {
+	if n := len(uwCase.DecisionChain); n > 0 && (decision == UWDecisionApproved || decision == UWDecisionDeclined) {
+		step := uwCase.DecisionChain[n-1]
+		return uwCase.Decision == decision && step.Underwriter == underwriter && step.Action == action && step.Decision == decision
+	}
+	return false
+}
+
+// hasAuthority checks the underwriter's solo approval limits against the case.
+func (s *underwritingService) hasAuthority(underwriter string, uwCase UnderwritingCase) bool {
+	if len(s.cfg.Authorities) == 0 {
+		return true
+	}
+	for _, a := range s.cfg.Authorities {
+		if a.Underwriter == underwriter {
+			return a.Covers(uwCase)
+		}
+	}
+	return false
+}
+
 func (s *underwritingService) GetCase(ctx context.Context, caseID string) (UnderwritingCase, error) {
+	if _, err := authorize(ctx, RoleUnderwriter); err != nil {
+		return UnderwritingCase{}, err
+	}
 	if caseID == "" {
 		return UnderwritingCase{}, fmt.Errorf("%w: missing case ID", ErrValidation)
 	}
@@ -197,6 +429,9 @@ func (s *underwritingService) GetCase(ctx context.Context, caseID string) (Under
 }
 
 func (s *underwritingService) GetByApplicationID(ctx context.Context, appID string) (UnderwritingCase, error) {
+	if _, err := authorize(ctx, RoleUnderwriter); err != nil {
+		return UnderwritingCase{}, err
+	}
 	if appID == "" {
 		return UnderwritingCase{}, fmt.Errorf("%w: missing application ID", ErrValidation)
 	}
@@ -204,12 +439,177 @@ func (s *underwritingService) GetByApplicationID(ctx context.Context, appID stri
 }
 
 func (s *underwritingService) ListReferred(ctx context.Context, limit int) ([]UnderwritingCase, error) {
+	if _, err := authorize(ctx, RoleUnderwriter); err != nil {
+		return nil, err
+	}
 	if limit <= 0 {
 		limit = 50
 	}
 	return s.uw.FindReferred(ctx, limit)
 }
 
+func (s *underwritingService) ListCases(ctx context.Context, filter UWCaseFilter, page PageRequest) (Page[UnderwritingCase], error) {
+	if _, err := authorize(ctx, RoleUnderwriter); err != nil {
+		return Page[UnderwritingCase]{}, err
+	}
+	return s.uw.List(ctx, filter, page.Normalize(50, 200))
+}
+
+func (s *underwritingService) ClaimCase(ctx context.Context, caseID string) (UnderwritingCase, error) {
+	p, err := authorize(ctx, RoleUnderwriter)
+	if err != nil {
+		return UnderwritingCase{}, err
+	}
+	underwriter := p.Subject
+
+	uwCase, err := s.uw.Get(ctx, caseID)
+	if err != nil {
+		return UnderwritingCase{}, err
+	}
+	if err := checkVersion(ctx, uwCase.Version); err != nil {
+		return UnderwritingCase{}, err
+	}
+	if uwCase.Decision != UWDecisionReferred {
+		return UnderwritingCase{}, ErrUWAlreadyDecided
+	}
+
+	// Claiming a case you already hold is a no-op
+	if uwCase.AssignedTo == underwriter {
+		return uwCase, nil
+	}
+	if uwCase.AssignedTo != "" {
+		return UnderwritingCase{}, ErrUWCaseLocked
+	}
+
+	now := s.clock()
+	if err := s.uw.Assign(ctx, caseID, underwriter, uwCase.Version, now); err != nil {
+		return UnderwritingCase{}, versionErr(ctx, err)
+	}
+
+	before := uwCase
+	uwCase.AssignedTo = underwriter
+	uwCase.AssignedAt = &now
+	uwCase.UpdatedAt = now
+	uwCase.Version++
+	if err := s.record(ctx, AuditUnderwritingCase, caseID, AuditClaimed, before, uwCase, "", now); err != nil {
+		return UnderwritingCase{}, err
+	}
+	return uwCase, nil
+}
+
+func (s *underwritingService) AssignCase(ctx context.Context, caseID, assignee string) (UnderwritingCase, error) {
+	if _, err := authorize(ctx, RoleUnderwriter); err != nil {
+		return UnderwritingCase{}, err
+	}
+	if assignee == "" {
+		var v violations
+		v.add("/assignee", FieldRequired, "assignee is required")
+		return UnderwritingCase{}, v.err()
+	}
+
+	uwCase, err := s.uw.Get(ctx, caseID)
+	if err != nil {
+		return UnderwritingCase{}, err
+	}
+	if err := checkVersion(ctx, uwCase.Version); err != nil {
+		return UnderwritingCase{}, err
+	}
+	if uwCase.Decision != UWDecisionReferred {
+		return UnderwritingCase{}, ErrUWAlreadyDecided
+	}
+
+	// Compare-and-set against the version we just read so a concurrent
+	// claim or decision is not silently overwritten
+	now := s.clock()
+	if err := s.uw.Assign(ctx, caseID, assignee, uwCase.Version, now); err != nil {
+		return UnderwritingCase{}, versionErr(ctx, err)
+	}
+
+	before := uwCase
+	uwCase.AssignedTo = assignee
+	uwCase.AssignedAt = &now
+	uwCase.UpdatedAt = now
+	uwCase.Version++
+	if err := s.record(ctx, AuditUnderwritingCase, caseID, AuditAssigned, before, uwCase, "", now); err != nil {
+		return UnderwritingCase{}, err
+	}
+	return uwCase, nil
+}
+
+func (s *underwritingService) EscalateBreached(ctx context.Context, limit int) ([]UnderwritingCase, error) {
+	if _, err := authorize(ctx); err != nil {
+		return nil, err
+	}
+	now := s.clock()
+	breached, err := s.uw.FindSLABreached(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var escalated []UnderwritingCase
+	for _, uwCase := range breached {
+		if s.cfg.EscalationAssignee != "" && uwCase.AssignedTo != s.cfg.EscalationAssignee {
+			if err := s.uw.Assign(ctx, uwCase.ID, s.cfg.EscalationAssignee, uwCase.Version, now); err != nil {
+				if errors.Is(err, ErrChanged) {
+					// Claimed or decided since we read it - pick it up next round
+					continue
+				}
+				return escalated, err
+			}
+			before := uwCase
+			uwCase.AssignedTo = s.cfg.EscalationAssignee
+			uwCase.AssignedAt = &now
+			uwCase.UpdatedAt = now
+			uwCase.Version++
+			if err := s.record(ctx, AuditUnderwritingCase, uwCase.ID, AuditAssigned, before, uwCase, "SLA breached", now); err != nil {
+				return escalated, err
+			}
+		}
+
+		before := uwCase
+		uwCase.EscalatedAt = &now
+		uwCase.UpdatedAt = now
+		if err := s.uw.Update(ctx, uwCase); err != nil {
+			if errors.Is(err, ErrChanged) {
+				continue
+			}
+			return escalated, err
+		}
+		uwCase.Version++
+		if err := s.record(ctx, AuditUnderwritingCase, uwCase.ID, AuditEscalated, before, uwCase, "SLA breached", now); err != nil {
+			return escalated, err
+		}
+		escalated = append(escalated, uwCase)
+	}
+
+	return escalated, nil
+}
+
+func (s *underwritingService) History(ctx context.Context, caseID string, page PageRequest) (Page[AuditEntry], error) {
+	if _, err := authorize(ctx, RoleUnderwriter); err != nil {
+		return Page[AuditEntry]{}, err
+	}
+	if _, err := s.uw.Get(ctx, caseID); err != nil {
+		return Page[AuditEntry]{}, err
+	}
+	return s.audit.History(ctx, AuditUnderwritingCase, caseID, page.Normalize(DefaultPageLimit, MaxPageLimit))
+}
+
+// enqueue sets the SLA and initial owner on a newly referred case.
+func (s *underwritingService) enqueue(uwCase *UnderwritingCase, now time.Time) {
+	if s.cfg.SLA > 0 {
+		due := now.Add(s.cfg.SLA)
+		uwCase.SLADueAt = &due
+	}
+	for _, rule := range s.cfg.Rules {
+		if rule.Matches(*uwCase) {
+			uwCase.AssignedTo = rule.Assignee
+			uwCase.AssignedAt = &now
+			return
+		}
+	}
+}
+
 func (s *underwritingService) determineDecision(factors RiskFactors, score RiskScore) (UWDecision, UWMethod) {
 	// Hard rules - auto-decline
 	if ShouldAutoDecline(factors) {
@@ -243,5 +643,31 @@ func (s *underwritingService) createOffer(ctx context.Context, app Application,
 		ExpiresAt:      now.AddDate(0, 0, OfferValidityDays),
 	}
 
-	return s.offers.Create(ctx, offer)
+	if err := s.offers.Create(ctx, offer); err != nil {
+		return err
+	}
+	return s.record(ctx, AuditOffer, offer.ID, AuditCreated, nil, offer, "", now)
+}
+
+// updateAppStatus moves the application on and records the change. The
+// statuses underwriting sets double as the audit actions.
+func (s *underwritingService) updateAppStatus(ctx context.Context, app Application, status ApplicationStatus, reason string, now time.Time) (Application, error) {
+	if err := s.apps.UpdateStatus(ctx, app.ID, status, now); err != nil {
+		return Application{}, err
+	}
+	before := app
+	app.Status = status
+	app.UpdatedAt = now
+	app.Version++
+	return app, s.record(ctx, AuditApplication, app.ID, AuditAction(status), before, app, reason, now)
+}
+
+// record appends the audit entry for a change. A reason the change carries,
+// such as a decision's, takes the place of the one the caller gave.
+func (s *underwritingService) record(ctx context.Context, resource AuditResource, id string, action AuditAction, before, after any, reason string, now time.Time) error {
+	entry := newAuditEntry(ctx, resource, id, action, before, after, now)
+	if reason != "" {
+		entry.Reason = reason
+	}
+	return appendAudit(ctx, s.audit, entry)
 }
@@ -0,0 +1,123 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/store/memory"
+)
+
+// flakyCases fails the first Create, as a store timeout would.
+type flakyCases struct {
+	*memory.UnderwritingRepo
+	failed bool
+}
+
+func (r *flakyCases) Create(ctx context.Context, uw core.UnderwritingCase) error {
+	if !r.failed {
+		r.failed = true
+		return errors.New("connection reset")
+	}
+	return r.UnderwritingRepo.Create(ctx, uw)
+}
+
+// A retry after a failed attempt finishes underwriting, whatever status the
+// failed attempt left the application in.
+func TestProcessApplicationResumes(t *testing.T) {
+	for _, status := range []core.ApplicationStatus{core.ApplicationStatusSubmitted, core.ApplicationStatusUnderReview} {
+		t.Run(string(status), func(t *testing.T) {
+			ctx := core.WithPrincipal(context.Background(), core.SystemPrincipal)
+			apps, offers := memory.NewApplicationRepo(), memory.NewOfferRepo()
+			svc := core.NewUnderwritingService(&flakyCases{UnderwritingRepo: memory.NewUnderwritingRepo()},
+				apps, offers, memory.NewAuditRepo(), core.UWConfig{})
+
+			app := core.Application{
+				ID: "01JA0000000000000000000001", ProductSlug: "term-life-20", CoverageAmount: 100000, TermYears: 20,
+				Applicant: core.Applicant{Email: "ada@example.com", Age: 35}, Status: status, CreatedAt: time.Now(),
+			}
+			if err := apps.Create(ctx, app); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := svc.ProcessApplication(ctx, app.ID); err == nil {
+				t.Fatal("first attempt succeeded, want the store error")
+			}
+			uwCase, err := svc.ProcessApplication(ctx, app.ID)
+			if err != nil || uwCase.Decision != core.UWDecisionApproved {
+				t.Fatalf("retry = %+v, %v; want an approved case", uwCase, err)
+			}
+			if got, _ := apps.Get(ctx, app.ID); got.Status != core.ApplicationStatusApproved {
+				t.Errorf("application status = %q, want approved", got.Status)
+			}
+			if _, err := offers.GetByApplicationID(ctx, app.ID); err != nil {
+				t.Errorf("offer: %v", err)
+			}
+
+			// A further run finds the work done
+			if again, err := svc.ProcessApplication(ctx, app.ID); err != nil || again.ID != uwCase.ID {
+				t.Errorf("rerun = %+v, %v; want case %s", again, err, uwCase.ID)
+			}
+		})
+	}
+}
+
+// flakyOffers fails the first Create, as a store timeout would.
+type flakyOffers struct {
+	*memory.OfferRepo
+	failed bool
+}
+
+func (r *flakyOffers) Create(ctx context.Context, offer core.Offer) error {
+	if !r.failed {
+		r.failed = true
+		return errors.New("connection reset")
+	}
+	return r.OfferRepo.Create(ctx, offer)
+}
+
+// A retry of a manual approval whose offer failed finishes it: the offer
+// exists and the application is approved.
+func TestMakeDecisionResumes(t *testing.T) {
+	ctx := core.WithPrincipal(context.Background(), core.Principal{Subject: "uw-1", Roles: []core.Role{core.RoleUnderwriter}})
+	apps, cases, offers := memory.NewApplicationRepo(), memory.NewUnderwritingRepo(), memory.NewOfferRepo()
+	svc := core.NewUnderwritingService(cases, apps, &flakyOffers{OfferRepo: offers}, memory.NewAuditRepo(), core.UWConfig{})
+
+	app := core.Application{
+		ID: "01JA0000000000000000000001", ProductSlug: "term-life-20", CoverageAmount: 100000, TermYears: 20,
+		Applicant: core.Applicant{Email: "ada@example.com", Age: 35}, Status: core.ApplicationStatusUnderReview, CreatedAt: time.Now(),
+	}
+	uwCase := core.UnderwritingCase{
+		ID: "01JA0000000000000000000002", ApplicationID: app.ID, Decision: core.UWDecisionReferred,
+		Method: core.UWMethodAuto, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	if err := apps.Create(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := cases.Create(ctx, uwCase); err != nil {
+		t.Fatal(err)
+	}
+
+	input := core.UWDecisionInput{Decision: core.UWDecisionApproved, Reason: "clean history"}
+	if _, err := svc.MakeDecision(ctx, uwCase.ID, input); err == nil {
+		t.Fatal("first attempt succeeded, want the store error")
+	}
+	decided, err := svc.MakeDecision(ctx, uwCase.ID, input)
+	if err != nil || decided.Decision != core.UWDecisionApproved {
+		t.Fatalf("retry = %+v, %v; want an approved case", decided, err)
+	}
+	if got, _ := apps.Get(ctx, app.ID); got.Status != core.ApplicationStatusApproved {
+		t.Errorf("application status = %q, want approved", got.Status)
+	}
+	if _, err := offers.GetByApplicationID(ctx, app.ID); err != nil {
+		t.Errorf("offer: %v", err)
+	}
+
+	// A different decision is still refused
+	input.Decision = core.UWDecisionDeclined
+	if _, err := svc.MakeDecision(ctx, uwCase.ID, input); !errors.Is(err, core.ErrUWAlreadyDecided) {
+		t.Errorf("declining after approval: %v, want ErrUWAlreadyDecided", err)
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// Applications, offers and underwriting cases carry a version that every
+// write bumps. Their repos only replace a record while it is still at the
+// version it was read at, so of two writers that read the same version
+// only one succeeds; the other gets ErrChanged.
+
+var (
+	ErrChanged            = NewError(ErrConflict, "changed_concurrently", "record was changed concurrently; fetch it again and retry")
+	ErrPreconditionFailed = NewError(ErrConflict, "precondition_failed", "record has changed since the version the request expected; fetch it again and retry")
+)
+
+type expectedVersionKey struct{}
+
+// WithExpectedVersion makes the changes made with ctx apply only to a
+// record still at version, e.g. the one a request's If-Match ETag was
+// checked against. Otherwise they fail with ErrPreconditionFailed.
+func WithExpectedVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, expectedVersionKey{}, version)
+}
+
+// checkVersion is called with the version of a record about to be changed.
+func checkVersion(ctx context.Context, version int) error {
+	if want, ok := ctx.Value(expectedVersionKey{}).(int); ok && want != version {
+		return ErrPreconditionFailed
+	}
+	return nil
+}
+
+// versionErr reports a conditional write that lost a race as a failed
+// precondition when the caller expected a version, since the record has
+// moved on from it.
+func versionErr(ctx context.Context, err error) error {
+	if _, ok := ctx.Value(expectedVersionKey{}).(int); ok && errors.Is(err, ErrChanged) {
+		return ErrPreconditionFailed
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package transportgraphql
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	gqlerrors "github.com/graphql-go/graphql/gqlerrors"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// gqlError is a GraphQL error with a machine-readable code in its
+// extensions, and the domain error's code (core.CodeOf) as its reason, e.g.
+// {"message": "...", "extensions": {"code": "CONFLICT", "reason": "offer_not_pending"}}.
+type gqlError struct {
+	message string
+	code    string
+	reason  string
+}
+
+func (e gqlError) Error() string { return e.message }
+
+func (e gqlError) Extensions() map[string]any {
+	ext := map[string]any{"code": e.code}
+	if e.reason != "" {
+		ext["reason"] = e.reason
+	}
+	return ext
+}
+
+// toError maps domain errors to GraphQL error codes the way writeError maps
+// them to HTTP statuses. detail replaces the message of unauthorized,
+// forbidden and internal errors so they don't leak internals.
+func toError(ctx context.Context, log *slog.Logger, err error, detail string) error {
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		log.WarnContext(ctx, "resource not found", "err", err)
+		return gqlError{err.Error(), "NOT_FOUND", core.CodeOf(err)}
+
+	case errors.Is(err, core.ErrValidation):
+		log.WarnContext(ctx, "validation failed", "err", err)
+		return gqlError{err.Error(), "BAD_USER_INPUT", core.CodeOf(err)}
+
+	case errors.Is(err, core.ErrConflict), errors.Is(err, core.ErrInvalidState):
+		log.WarnContext(ctx, "resource conflict", "err", err)
+		return gqlError{err.Error(), "CONFLICT", core.CodeOf(err)}
+
+	case errors.Is(err, core.ErrUnauthorized):
+		log.WarnContext(ctx, "unauthorized request", "err", err)
+		return gqlError{detail, "UNAUTHENTICATED", core.CodeOf(err)}
+
+	case errors.Is(err, core.ErrForbidden):
+		log.WarnContext(ctx, "forbidden operation", "err", err)
+		return gqlError{detail, "FORBIDDEN", core.CodeOf(err)}
+
+	case errors.Is(err, context.DeadlineExceeded):
+		log.ErrorContext(ctx, "operation timeout", "err", err)
+		return gqlError{"Operation took too long.", "TIMEOUT", core.CodeOf(err)}
+
+	default:
+		log.ErrorContext(ctx, "internal server error", "err", err)
+		return gqlError{detail, "INTERNAL_SERVER_ERROR", core.CodeOf(err)}
+	}
+}
+
+// linkErrors collects, for one request, the errors of links that failed to
+// load. The executor drops the extensions of errors returned by thunks, so
+// link resolves a failed link to null and records its error here, located
+// at the field, for the handler to add to the result.
+type linkErrors struct {
+	mu   sync.Mutex
+	errs []gqlerrors.FormattedError
+}
+
+func (e *linkErrors) add(p graphql.ResolveParams, err error) {
+	located := graphql.NewLocatedErrorWithPath(err, graphql.FieldASTsToNodeASTs(p.Info.FieldASTs), p.Info.Path.AsArray())
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.errs = append(e.errs, gqlerrors.FormatError(located))
+}
+
+func (e *linkErrors) list() []gqlerrors.FormattedError {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.errs
+}
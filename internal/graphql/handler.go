@@ -0,0 +1,96 @@
+// Package transportgraphql serves a read-only GraphQL API over the core
+// services, for reading a whole journey (quote, application, underwriting
+// case, offer and policy) in one request.
+package transportgraphql
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/graphql-go/graphql"
+	gqlerrors "github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/MrKriegler/go-insurance/pkg/problem"
+)
+
+type Handler struct {
+	schema graphql.Schema
+	deps   Deps
+	Limits Limits
+	Log    *slog.Logger
+}
+
+// NewHandler builds the schema over d. It panics if the schema is invalid,
+// which is a programming error.
+func NewHandler(d Deps, limits Limits, log *slog.Logger) *Handler {
+	schema, err := newSchema(d, log)
+	if err != nil {
+		panic("graphql schema: " + err.Error())
+	}
+	return &Handler{schema: schema, deps: d, Limits: limits, Log: log}
+}
+
+func (h *Handler) Mount(r chi.Router) {
+	r.Post("/graphql", h.Query)
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Query runs a GraphQL query. Linked entities are loaded in batches per
+// level of the query, and queries deeper or costlier than Limits are refused.
+// 200: JSON {data, errors}, with error codes in errors[].extensions.code; 400: bad JSON.
+func (h *Handler) Query(w http.ResponseWriter, r *http.Request) {
+	var in request
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
+		return
+	}
+
+	result := h.execute(r, in)
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode graphql result", "err", err)
+	}
+}
+
+func (h *Handler) execute(r *http.Request, in request) *graphql.Result {
+	// 1) Parse and validate against the schema
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(in.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	if v := graphql.ValidateDocument(&h.schema, doc, nil); !v.IsValid {
+		return &graphql.Result{Errors: v.Errors}
+	}
+
+	// 2) Refuse queries that ask for too much before resolving anything
+	if err := checkLimits(doc, in.OperationName, in.Variables, h.Limits); err != nil {
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{
+			{Message: err.Error(), Locations: []location.SourceLocation{}, Extensions: err.Extensions()},
+		}}
+	}
+
+	// 3) Execute with loaders scoped to this request
+	l := newLoaders(h.deps)
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: in.OperationName,
+		Args:          in.Variables,
+		Context:       withLoaders(r.Context(), l),
+	})
+	result.Errors = append(result.Errors, l.failed.list()...)
+	return result
+}
@@ -0,0 +1,114 @@
+package transportgraphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Limits bound how much work one query may ask for.
+type Limits struct {
+	MaxDepth      int // Deepest field nesting, e.g. application { offer { policy } } is 3
+	MaxComplexity int // Fields resolved, with paged lists counted once per item requested
+}
+
+// checkLimits measures the operation that will run against the limits.
+// Introspection fields (__schema, __type, __typename) are not counted.
+func checkLimits(doc *ast.Document, operationName string, vars map[string]any, limits Limits) *gqlError {
+	w := walker{fragments: make(map[string]*ast.FragmentDefinition), vars: vars}
+	var ops []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			w.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				ops = append(ops, def)
+			}
+		}
+	}
+
+	for _, op := range ops {
+		depth, cost := w.measure(op.SelectionSet, nil)
+		if limits.MaxDepth > 0 && depth > limits.MaxDepth {
+			return &gqlError{fmt.Sprintf("query depth %d exceeds the limit of %d", depth, limits.MaxDepth), "QUERY_TOO_DEEP", ""}
+		}
+		if limits.MaxComplexity > 0 && cost > limits.MaxComplexity {
+			return &gqlError{fmt.Sprintf("query complexity %d exceeds the limit of %d", cost, limits.MaxComplexity), "QUERY_TOO_COMPLEX", ""}
+		}
+	}
+	return nil
+}
+
+type walker struct {
+	fragments map[string]*ast.FragmentDefinition
+	vars      map[string]any
+}
+
+// measure returns the depth and cost of a selection set. visiting guards
+// against fragments that spread themselves, which validation rejects anyway.
+func (w walker) measure(set *ast.SelectionSet, visiting map[string]bool) (depth, cost int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, sel := range set.Selections {
+		var d, c int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name.Value, "__") {
+				continue
+			}
+			d, c = w.measure(sel.SelectionSet, visiting)
+			d, c = d+1, 1+c*w.listSize(sel)
+		case *ast.InlineFragment:
+			d, c = w.measure(sel.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			frag, ok := w.fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			next := map[string]bool{name: true}
+			for k := range visiting {
+				next[k] = true
+			}
+			d, c = w.measure(frag.SelectionSet, next)
+		}
+		depth = max(depth, d)
+		cost += c
+	}
+	return depth, cost
+}
+
+// pagedFields are the fields returning a page of items.
+var pagedFields = map[string]bool{"applications": true}
+
+// listSize is how many items a field's selection is resolved for: the
+// requested page size for paged lists, otherwise one.
+func (w walker) listSize(field *ast.Field) int {
+	if !pagedFields[field.Name.Value] {
+		return 1
+	}
+	n := core.DefaultPageLimit
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			n, _ = strconv.Atoi(v.Value)
+		case *ast.Variable:
+			switch limit := w.vars[v.Name.Value].(type) {
+			case float64:
+				n = int(limit)
+			case int:
+				n = limit
+			}
+		}
+	}
+	return min(max(n, 1), core.MaxPageLimit)
+}
@@ -0,0 +1,118 @@
+package transportgraphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// loader batches lookups by key, dataloader style. Resolvers queue keys with
+// load and return thunks; the executor resolves a whole level of the query
+// before calling any thunk, so the first thunk fetches every queued key in
+// one repo call. Results are cached for the rest of the request.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) ([]V, error)
+	key   func(V) string // The key a fetched value answers
+
+	mu      sync.Mutex
+	pending []string
+	fetched map[string]bool
+	values  map[string]V
+	errs    map[string]error
+}
+
+func newLoader[V any](fetch func(context.Context, []string) ([]V, error), key func(V) string) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		key:     key,
+		fetched: make(map[string]bool),
+		values:  make(map[string]V),
+		errs:    make(map[string]error),
+	}
+}
+
+// load queues key and returns a thunk for its value; ok is false when
+// nothing was found for the key.
+func (l *loader[V]) load(ctx context.Context, key string) func() (V, bool, error) {
+	l.mu.Lock()
+	if !l.fetched[key] {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			l.dispatch(ctx)
+		}
+		if err := l.errs[key]; err != nil {
+			var zero V
+			return zero, false, err
+		}
+		v, ok := l.values[key]
+		return v, ok, nil
+	}
+}
+
+// dispatch fetches every pending key at once.
+func (l *loader[V]) dispatch(ctx context.Context) {
+	keys := unique(l.pending)
+	l.pending = nil
+
+	found, err := l.fetch(ctx, keys)
+	for _, k := range keys {
+		l.fetched[k] = true
+		if err != nil {
+			l.errs[k] = err
+		}
+	}
+	for _, v := range found {
+		l.values[l.key(v)] = v
+	}
+}
+
+func unique(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	out := keys[:0:0]
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// loaders are the per-request batch lookups for linked entities.
+type loaders struct {
+	applications *loader[core.Application]      // By ID
+	quotes       *loader[core.Quote]            // By ID
+	cases        *loader[core.UnderwritingCase] // By application ID
+	offers       *loader[core.Offer]            // By application ID
+	policies     *loader[core.Policy]           // By application ID
+
+	failed linkErrors
+}
+
+func newLoaders(d Deps) *loaders {
+	return &loaders{
+		applications: newLoader(d.ApplicationRepo.GetMany, func(a core.Application) string { return a.ID }),
+		quotes:       newLoader(d.Quotes.GetMany, func(q core.Quote) string { return q.ID }),
+		cases:        newLoader(d.UnderwritingRepo.FindByApplicationIDs, func(c core.UnderwritingCase) string { return c.ApplicationID }),
+		offers:       newLoader(d.OfferRepo.FindByApplicationIDs, func(o core.Offer) string { return o.ApplicationID }),
+		policies:     newLoader(d.PolicyRepo.FindByApplicationIDs, func(p core.Policy) string { return p.ApplicationID }),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
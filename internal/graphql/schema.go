@@ -0,0 +1,373 @@
+package transportgraphql
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Deps are what the schema reads through. Root fields go through the
+// services, which apply their access rules; linked entities are fetched in
+// batches from the repos, under the access already granted to their parent.
+type Deps struct {
+	Applications core.ApplicationService
+	Underwriting core.UnderwritingService
+	Offers       core.OfferService
+	Policies     core.PolicyService
+	Products     core.ProductRepo
+	Quotes       core.QuoteRepo // Root and linked quotes; quotes have no owner
+
+	ApplicationRepo  core.ApplicationRepo
+	UnderwritingRepo core.UnderwritingRepo
+	OfferRepo        core.OfferRepo
+	PolicyRepo       core.PolicyRepo
+}
+
+type resolver struct {
+	deps Deps
+	log  *slog.Logger
+}
+
+// newSchema builds the query schema. Applications link to their quote,
+// underwriting case, offer and policy; cases, offers and policies link back
+// to their application, so a whole journey resolves in one request.
+func newSchema(d Deps, log *slog.Logger) (graphql.Schema, error) {
+	r := &resolver{deps: d, log: log}
+
+	applicant := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Applicant",
+		Fields: graphql.Fields{
+			"firstName":   prop(graphql.String, func(a core.Applicant) any { return a.FirstName }),
+			"lastName":    prop(graphql.String, func(a core.Applicant) any { return a.LastName }),
+			"email":       prop(graphql.String, func(a core.Applicant) any { return a.Email }),
+			"dateOfBirth": prop(graphql.String, func(a core.Applicant) any { return a.DateOfBirth }),
+			"age":         prop(graphql.Int, func(a core.Applicant) any { return a.Age }),
+			"smoker":      prop(graphql.Boolean, func(a core.Applicant) any { return a.Smoker }),
+			"state":       prop(graphql.String, func(a core.Applicant) any { return a.State }),
+		},
+	})
+
+	product := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.Fields{
+			"id":          prop(graphql.NewNonNull(graphql.ID), func(p core.Product) any { return p.ID }),
+			"slug":        prop(graphql.String, func(p core.Product) any { return p.Slug }),
+			"name":        prop(graphql.String, func(p core.Product) any { return p.Name }),
+			"termYears":   prop(graphql.Int, func(p core.Product) any { return p.TermYears }),
+			"minCoverage": prop(graphql.Float, func(p core.Product) any { return p.MinCoverage }),
+			"maxCoverage": prop(graphql.Float, func(p core.Product) any { return p.MaxCoverage }),
+			"baseRate":    prop(graphql.Float, func(p core.Product) any { return p.BaseRate }),
+		},
+	})
+
+	quote := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Quote",
+		Fields: graphql.Fields{
+			"id":             prop(graphql.NewNonNull(graphql.ID), func(q core.Quote) any { return q.ID }),
+			"productSlug":    prop(graphql.String, func(q core.Quote) any { return q.ProductSlug }),
+			"coverageAmount": prop(graphql.Float, func(q core.Quote) any { return q.CoverageAmount }),
+			"termYears":      prop(graphql.Int, func(q core.Quote) any { return q.TermYears }),
+			"monthlyPremium": prop(graphql.Float, func(q core.Quote) any { return q.MonthlyPremium }),
+			"status":         prop(graphql.String, func(q core.Quote) any { return q.Status }),
+			"createdAt":      prop(graphql.DateTime, func(q core.Quote) any { return q.CreatedAt }),
+			"expiresAt":      prop(graphql.DateTime, func(q core.Quote) any { return q.ExpiresAt }),
+		},
+	})
+
+	application := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Application",
+		Fields: graphql.Fields{
+			"id":             prop(graphql.NewNonNull(graphql.ID), func(a core.Application) any { return a.ID }),
+			"quoteId":        prop(graphql.ID, func(a core.Application) any { return a.QuoteID }),
+			"productSlug":    prop(graphql.String, func(a core.Application) any { return a.ProductSlug }),
+			"coverageAmount": prop(graphql.Float, func(a core.Application) any { return a.CoverageAmount }),
+			"termYears":      prop(graphql.Int, func(a core.Application) any { return a.TermYears }),
+			"monthlyPremium": prop(graphql.Float, func(a core.Application) any { return a.MonthlyPremium }),
+			"applicant":      prop(applicant, func(a core.Application) any { return a.Applicant }),
+			"status":         prop(graphql.String, func(a core.Application) any { return a.Status }),
+			"createdAt":      prop(graphql.DateTime, func(a core.Application) any { return a.CreatedAt }),
+			"updatedAt":      prop(graphql.DateTime, func(a core.Application) any { return a.UpdatedAt }),
+			"submittedAt":    prop(graphql.DateTime, func(a core.Application) any { return a.SubmittedAt }),
+		},
+	})
+
+	riskScore := graphql.NewObject(graphql.ObjectConfig{
+		Name: "RiskScore",
+		Fields: graphql.Fields{
+			"score":       prop(graphql.Int, func(s core.RiskScore) any { return s.Score }),
+			"flags":       prop(graphql.NewList(graphql.String), func(s core.RiskScore) any { return s.Flags }),
+			"recommended": prop(graphql.String, func(s core.RiskScore) any { return s.Recommended }),
+		},
+	})
+
+	uwCase := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UnderwritingCase",
+		Fields: graphql.Fields{
+			"id":            prop(graphql.NewNonNull(graphql.ID), func(c core.UnderwritingCase) any { return c.ID }),
+			"applicationId": prop(graphql.ID, func(c core.UnderwritingCase) any { return c.ApplicationID }),
+			"riskScore":     prop(riskScore, func(c core.UnderwritingCase) any { return c.RiskScore }),
+			"decision":      prop(graphql.String, func(c core.UnderwritingCase) any { return c.Decision }),
+			"method":        prop(graphql.String, func(c core.UnderwritingCase) any { return c.Method }),
+			"decidedBy":     prop(graphql.String, func(c core.UnderwritingCase) any { return c.DecidedBy }),
+			"reason":        prop(graphql.String, func(c core.UnderwritingCase) any { return c.Reason }),
+			"assignedTo":    prop(graphql.String, func(c core.UnderwritingCase) any { return c.AssignedTo }),
+			"slaDueAt":      prop(graphql.DateTime, func(c core.UnderwritingCase) any { return c.SLADueAt }),
+			"createdAt":     prop(graphql.DateTime, func(c core.UnderwritingCase) any { return c.CreatedAt }),
+			"updatedAt":     prop(graphql.DateTime, func(c core.UnderwritingCase) any { return c.UpdatedAt }),
+			"decidedAt":     prop(graphql.DateTime, func(c core.UnderwritingCase) any { return c.DecidedAt }),
+		},
+	})
+
+	offer := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Offer",
+		Fields: graphql.Fields{
+			"id":             prop(graphql.NewNonNull(graphql.ID), func(o core.Offer) any { return o.ID }),
+			"applicationId":  prop(graphql.ID, func(o core.Offer) any { return o.ApplicationID }),
+			"productSlug":    prop(graphql.String, func(o core.Offer) any { return o.ProductSlug }),
+			"coverageAmount": prop(graphql.Float, func(o core.Offer) any { return o.CoverageAmount }),
+			"termYears":      prop(graphql.Int, func(o core.Offer) any { return o.TermYears }),
+			"monthlyPremium": prop(graphql.Float, func(o core.Offer) any { return o.MonthlyPremium }),
+			"status":         prop(graphql.String, func(o core.Offer) any { return o.Status }),
+			"createdAt":      prop(graphql.DateTime, func(o core.Offer) any { return o.CreatedAt }),
+			"expiresAt":      prop(graphql.DateTime, func(o core.Offer) any { return o.ExpiresAt }),
+			"acceptedAt":     prop(graphql.DateTime, func(o core.Offer) any { return o.AcceptedAt }),
+			"declinedAt":     prop(graphql.DateTime, func(o core.Offer) any { return o.DeclinedAt }),
+		},
+	})
+
+	policy := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Policy",
+		Fields: graphql.Fields{
+			"id":             prop(graphql.NewNonNull(graphql.ID), func(p core.Policy) any { return p.ID }),
+			"number":         prop(graphql.String, func(p core.Policy) any { return p.Number }),
+			"applicationId":  prop(graphql.ID, func(p core.Policy) any { return p.ApplicationID }),
+			"offerId":        prop(graphql.ID, func(p core.Policy) any { return p.OfferID }),
+			"productSlug":    prop(graphql.String, func(p core.Policy) any { return p.ProductSlug }),
+			"coverageAmount": prop(graphql.Float, func(p core.Policy) any { return p.CoverageAmount }),
+			"termYears":      prop(graphql.Int, func(p core.Policy) any { return p.TermYears }),
+			"monthlyPremium": prop(graphql.Float, func(p core.Policy) any { return p.MonthlyPremium }),
+			"insured":        prop(applicant, func(p core.Policy) any { return p.Insured }),
+			"status":         prop(graphql.String, func(p core.Policy) any { return p.Status }),
+			"effectiveDate":  prop(graphql.DateTime, func(p core.Policy) any { return p.EffectiveDate }),
+			"expiryDate":     prop(graphql.DateTime, func(p core.Policy) any { return p.ExpiryDate }),
+			"issuedAt":       prop(graphql.DateTime, func(p core.Policy) any { return p.IssuedAt }),
+		},
+	})
+
+	// --- Links between the journey's entities, resolved in batches ---
+	appID := func(a core.Application) string { return a.ID }
+	application.AddFieldConfig("quote", &graphql.Field{Type: quote,
+		Resolve: link(r, "quotes:read", func(l *loaders) *loader[core.Quote] { return l.quotes },
+			func(a core.Application) string { return a.QuoteID })})
+	application.AddFieldConfig("underwritingCase", &graphql.Field{Type: uwCase,
+		Resolve: underwritersOnly(r, link(r, "underwriting:read", func(l *loaders) *loader[core.UnderwritingCase] { return l.cases }, appID))})
+	application.AddFieldConfig("offer", &graphql.Field{Type: offer,
+		Resolve: link(r, "offers:read", func(l *loaders) *loader[core.Offer] { return l.offers }, appID)})
+	application.AddFieldConfig("policy", &graphql.Field{Type: policy,
+		Resolve: link(r, "policies:read", func(l *loaders) *loader[core.Policy] { return l.policies }, appID)})
+
+	applications := func(l *loaders) *loader[core.Application] { return l.applications }
+	uwCase.AddFieldConfig("application", &graphql.Field{Type: application,
+		Resolve: link(r, "applications:read", applications, func(c core.UnderwritingCase) string { return c.ApplicationID })})
+	offer.AddFieldConfig("application", &graphql.Field{Type: application,
+		Resolve: link(r, "applications:read", applications, func(o core.Offer) string { return o.ApplicationID })})
+	offer.AddFieldConfig("policy", &graphql.Field{Type: policy,
+		Resolve: link(r, "policies:read", func(l *loaders) *loader[core.Policy] { return l.policies },
+			func(o core.Offer) string { return o.ApplicationID })})
+	policy.AddFieldConfig("application", &graphql.Field{Type: application,
+		Resolve: link(r, "applications:read", applications, func(p core.Policy) string { return p.ApplicationID })})
+	policy.AddFieldConfig("offer", &graphql.Field{Type: offer,
+		Resolve: link(r, "offers:read", func(l *loaders) *loader[core.Offer] { return l.offers },
+			func(p core.Policy) string { return p.ApplicationID })})
+
+	applicationPage := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ApplicationPage",
+		Fields: graphql.Fields{
+			"items": prop(graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(application))),
+				func(p core.Page[core.Application]) any { return p.Items }),
+			"nextCursor": prop(graphql.String, func(p core.Page[core.Application]) any {
+				if p.NextCursor == "" {
+					return nil
+				}
+				return p.NextCursor
+			}),
+		},
+	})
+
+	idArg := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}}
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"products": &graphql.Field{
+				Type:    graphql.NewList(product),
+				Resolve: r.products,
+			},
+			"quote": &graphql.Field{
+				Type: quote,
+				Args: idArg,
+				Resolve: get(r, "quotes:read", "Failed to get quote",
+					func(ctx context.Context, p graphql.ResolveParams) (core.Quote, error) {
+						return d.Quotes.Get(ctx, p.Args["id"].(string))
+					}),
+			},
+			"application": &graphql.Field{
+				Type: application,
+				Args: idArg,
+				Resolve: get(r, "applications:read", "Failed to get application",
+					func(ctx context.Context, p graphql.ResolveParams) (core.Application, error) {
+						return d.Applications.Get(ctx, p.Args["id"].(string))
+					}),
+			},
+			"applications": &graphql.Field{
+				Type: applicationPage,
+				Args: graphql.FieldConfigArgument{
+					"status":  &graphql.ArgumentConfig{Type: graphql.String},
+					"product": &graphql.ArgumentConfig{Type: graphql.String},
+					"email":   &graphql.ArgumentConfig{Type: graphql.String},
+					"from":    &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"to":      &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"limit":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"cursor":  &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: get(r, "applications:read", "Failed to list applications", r.listApplications),
+			},
+			"underwritingCase": &graphql.Field{
+				Type: uwCase,
+				Args: idArg,
+				Resolve: get(r, "underwriting:read", "Failed to get underwriting case",
+					func(ctx context.Context, p graphql.ResolveParams) (core.UnderwritingCase, error) {
+						return d.Underwriting.GetCase(ctx, p.Args["id"].(string))
+					}),
+			},
+			"offer": &graphql.Field{
+				Type: offer,
+				Args: idArg,
+				Resolve: get(r, "offers:read", "Failed to get offer",
+					func(ctx context.Context, p graphql.ResolveParams) (core.Offer, error) {
+						return d.Offers.Get(ctx, p.Args["id"].(string))
+					}),
+			},
+			"policy": &graphql.Field{
+				Type: policy,
+				Args: graphql.FieldConfigArgument{"number": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: get(r, "policies:read", "Failed to get policy",
+					func(ctx context.Context, p graphql.ResolveParams) (core.Policy, error) {
+						return d.Policies.GetByNumber(ctx, p.Args["number"].(string))
+					}),
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// prop resolves a field from the parent value S.
+func prop[S any](t graphql.Output, get func(S) any) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return get(p.Source.(S)), nil
+		},
+	}
+}
+
+// get resolves a root field through a service after checking the API key
+// scope for the entity it returns.
+func get[V any](r *resolver, scope, detail string, fetch func(context.Context, graphql.ResolveParams) (V, error)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		if err := r.allow(p.Context, scope); err != nil {
+			return nil, err
+		}
+		v, err := fetch(p.Context, p)
+		if err != nil {
+			return nil, toError(p.Context, r.log, err, detail)
+		}
+		return v, nil
+	}
+}
+
+// link resolves an entity linked from S through a loader, so the link is
+// fetched in one batch for every parent at the same level of the query.
+// A missing link (e.g. no offer yet) resolves to null, and so does one that
+// fails to load, with its error recorded in the request's linkErrors.
+func link[S any, V any](r *resolver, scope string, pick func(*loaders) *loader[V], key func(S) string) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		if err := r.allow(p.Context, scope); err != nil {
+			return nil, err
+		}
+		k := key(p.Source.(S))
+		if k == "" {
+			return nil, nil
+		}
+
+		l := loadersFrom(p.Context)
+		thunk := pick(l).load(p.Context, k)
+		return func() (any, error) {
+			v, ok, err := thunk()
+			if err != nil {
+				l.failed.add(p, toError(p.Context, r.log, err, "Failed to load "+p.Info.FieldName))
+				return nil, nil
+			}
+			if !ok {
+				return nil, nil
+			}
+			return v, nil
+		}, nil
+	}
+}
+
+// underwritersOnly limits a field to underwriters, as UnderwritingService does.
+func underwritersOnly(r *resolver, next graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		if principal, _ := core.PrincipalFrom(p.Context); !principal.HasRole(core.RoleUnderwriter) {
+			err := fmt.Errorf("%w: underwriting cases are only visible to underwriters", core.ErrForbidden)
+			return nil, toError(p.Context, r.log, err, "Underwriting cases are only visible to underwriters")
+		}
+		return next(p)
+	}
+}
+
+// allow enforces API key scopes per entity read, since one GraphQL request
+// can read any of them.
+func (r *resolver) allow(ctx context.Context, scope string) error {
+	p, ok := core.PrincipalFrom(ctx)
+	if !ok {
+		err := fmt.Errorf("%w: no principal", core.ErrUnauthorized)
+		return toError(ctx, r.log, err, "Missing API key or bearer token")
+	}
+	if !p.AllowsScope(scope) {
+		err := fmt.Errorf("%w: API key lacks scope %s", core.ErrForbidden, scope)
+		return toError(ctx, r.log, err, "API key lacks scope "+scope)
+	}
+	return nil
+}
+
+func (r *resolver) products(p graphql.ResolveParams) (any, error) {
+	if err := r.allow(p.Context, "products:read"); err != nil {
+		return nil, err
+	}
+	products, err := r.deps.Products.List(p.Context)
+	if err != nil {
+		return nil, toError(p.Context, r.log, err, "Failed to list products")
+	}
+	return products, nil
+}
+
+func (r *resolver) listApplications(ctx context.Context, p graphql.ResolveParams) (core.Page[core.Application], error) {
+	str := func(name string) string { s, _ := p.Args[name].(string); return s }
+	when := func(name string) time.Time { t, _ := p.Args[name].(time.Time); return t }
+	limit, _ := p.Args["limit"].(int)
+
+	filter := core.ApplicationFilter{
+		Status:         core.ApplicationStatus(str("status")),
+		ProductSlug:    str("product"),
+		ApplicantEmail: str("email"),
+		Created:        core.TimeRange{From: when("from"), To: when("to")},
+	}
+	return r.deps.Applications.List(ctx, filter, core.PageRequest{Limit: limit, Cursor: str("cursor")})
+}
@@ -0,0 +1,102 @@
+package transportgraphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type stubApplications struct{ core.ApplicationService }
+
+func (stubApplications) Get(_ context.Context, id string) (core.Application, error) {
+	return core.Application{ID: id, QuoteID: "q1"}, nil
+}
+
+// The repos behind the loaders: quotes fail, the rest find nothing.
+type failingQuotes struct{ core.QuoteRepo }
+
+func (failingQuotes) GetMany(context.Context, []string) ([]core.Quote, error) {
+	return nil, errors.New("connection reset")
+}
+
+type noApplications struct{ core.ApplicationRepo }
+
+func (noApplications) GetMany(context.Context, []string) ([]core.Application, error) { return nil, nil }
+
+type noCases struct{ core.UnderwritingRepo }
+
+func (noCases) FindByApplicationIDs(context.Context, []string) ([]core.UnderwritingCase, error) {
+	return nil, nil
+}
+
+type noOffers struct{ core.OfferRepo }
+
+func (noOffers) FindByApplicationIDs(context.Context, []string) ([]core.Offer, error) {
+	return nil, nil
+}
+
+type noPolicies struct{ core.PolicyRepo }
+
+func (noPolicies) FindByApplicationIDs(context.Context, []string) ([]core.Policy, error) {
+	return nil, nil
+}
+
+// A link that fails to load resolves to null with an error that keeps its
+// extensions and is located at the field.
+func TestLinkErrorKeepsExtensions(t *testing.T) {
+	h := NewHandler(Deps{
+		Applications:     stubApplications{},
+		Quotes:           failingQuotes{},
+		ApplicationRepo:  noApplications{},
+		UnderwritingRepo: noCases{},
+		OfferRepo:        noOffers{},
+		PolicyRepo:       noPolicies{},
+	}, Limits{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql",
+		strings.NewReader(`{"query": "{ application(id: \"a1\") { id quote { id } } }"}`))
+	req = req.WithContext(core.WithPrincipal(req.Context(), core.Principal{Subject: "agent", Roles: []core.Role{core.RoleAgent}}))
+	rec := httptest.NewRecorder()
+	h.Query(rec, req)
+
+	var res struct {
+		Data struct {
+			Application struct {
+				ID    string `json:"id"`
+				Quote *struct {
+					ID string `json:"id"`
+				} `json:"quote"`
+			} `json:"application"`
+		} `json:"data"`
+		Errors []struct {
+			Message    string         `json:"message"`
+			Path       []any          `json:"path"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body, err)
+	}
+
+	if res.Data.Application.ID != "a1" || res.Data.Application.Quote != nil {
+		t.Errorf("data = %+v, want application a1 with a null quote", res.Data)
+	}
+	if len(res.Errors) != 1 {
+		t.Fatalf("errors = %+v, want one", res.Errors)
+	}
+	got := res.Errors[0]
+	if got.Message != "Failed to load quote" || got.Extensions["code"] != "INTERNAL_SERVER_ERROR" {
+		t.Errorf("error = %+v, want the internal error for quote with its code", got)
+	}
+	if path, _ := json.Marshal(got.Path); string(path) != `["application","quote"]` {
+		t.Errorf("path = %s, want [\"application\",\"quote\"]", path)
+	}
+}
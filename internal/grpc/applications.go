@@ -0,0 +1,91 @@
+package transportgrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	pb "github.com/MrKriegler/go-insurance/pkg/pb/insurance/v1"
+)
+
+type applicationServer struct {
+	pb.UnimplementedApplicationServiceServer
+	svc core.ApplicationService
+	log *slog.Logger
+}
+
+func (s *applicationServer) CreateApplication(ctx context.Context, req *pb.CreateApplicationRequest) (*pb.Application, error) {
+	app, err := s.svc.Create(ctx, core.ApplicationInput{
+		QuoteID:   req.GetQuoteId(),
+		Applicant: fromApplicant(req.GetApplicant()),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to create application")
+	}
+	return toApplication(app), nil
+}
+
+func (s *applicationServer) GetApplication(ctx context.Context, req *pb.GetApplicationRequest) (*pb.Application, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	app, err := s.svc.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to get application")
+	}
+	return toApplication(app), nil
+}
+
+func (s *applicationServer) UpdateApplication(ctx context.Context, req *pb.UpdateApplicationRequest) (*pb.Application, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	var patch core.ApplicationPatch
+	if req.GetApplicant() != nil {
+		applicant := fromApplicant(req.GetApplicant())
+		patch.Applicant = &applicant
+	}
+
+	app, err := s.svc.Patch(ctx, req.GetId(), patch)
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toApplication(app), nil
+}
+
+func (s *applicationServer) SubmitApplication(ctx context.Context, req *pb.SubmitApplicationRequest) (*pb.Application, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	app, err := s.svc.Submit(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toApplication(app), nil
+}
+
+func (s *applicationServer) ListApplications(ctx context.Context, req *pb.ListApplicationsRequest) (*pb.ListApplicationsResponse, error) {
+	filter := core.ApplicationFilter{
+		Status:         core.ApplicationStatus(req.GetStatus()),
+		ProductSlug:    req.GetProductSlug(),
+		ApplicantEmail: req.GetApplicantEmail(),
+		Created:        timeRange(req.GetCreated()),
+	}
+
+	apps, err := s.svc.List(ctx, filter, pageRequest(req.GetPage()))
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to list applications")
+	}
+
+	resp := &pb.ListApplicationsResponse{Items: make([]*pb.Application, len(apps.Items)), NextCursor: apps.NextCursor}
+	for i, a := range apps.Items {
+		resp.Items[i] = toApplication(a)
+	}
+	return resp, nil
+}
@@ -0,0 +1,91 @@
+package transportgrpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/middleware"
+)
+
+// methodScopes maps each RPC to the API key scope its REST counterpart
+// needs, so a key grants the same access over both transports.
+var methodScopes = map[string]string{
+	"/insurance.v1.ProductService/ListProducts": "products:read",
+	"/insurance.v1.ProductService/GetProduct":   "products:read",
+
+	"/insurance.v1.QuoteService/CreateQuote": "quotes:write",
+	"/insurance.v1.QuoteService/GetQuote":    "quotes:read",
+	"/insurance.v1.QuoteService/ListQuotes":  "quotes:read",
+
+	"/insurance.v1.ApplicationService/CreateApplication": "applications:write",
+	"/insurance.v1.ApplicationService/GetApplication":    "applications:read",
+	"/insurance.v1.ApplicationService/UpdateApplication": "applications:write",
+	"/insurance.v1.ApplicationService/SubmitApplication": "applications:write",
+	"/insurance.v1.ApplicationService/ListApplications":  "applications:read",
+
+	"/insurance.v1.UnderwritingService/GetCase":     "underwriting:read",
+	"/insurance.v1.UnderwritingService/ListCases":   "underwriting:read",
+	"/insurance.v1.UnderwritingService/ClaimCase":   "underwriting:write",
+	"/insurance.v1.UnderwritingService/AssignCase":  "underwriting:write",
+	"/insurance.v1.UnderwritingService/DecideCase":  "underwriting:write",
+	"/insurance.v1.UnderwritingService/ConfirmCase": "underwriting:write",
+
+	// POST /applications/{id}/offers
+	"/insurance.v1.OfferService/CreateOffer":  "applications:write",
+	"/insurance.v1.OfferService/GetOffer":     "offers:read",
+	"/insurance.v1.OfferService/AcceptOffer":  "offers:write",
+	"/insurance.v1.OfferService/DeclineOffer": "offers:write",
+	"/insurance.v1.OfferService/ListOffers":   "offers:read",
+
+	"/insurance.v1.PolicyService/GetPolicy":    "policies:read",
+	"/insurance.v1.PolicyService/ListPolicies": "policies:read",
+}
+
+// authenticate resolves the caller from x-api-key or authorization metadata
+// with middleware.ResolvePrincipal, as middleware.Authenticate does from HTTP
+// headers, and enforces API key scopes. Health checks and reflection are public.
+func authenticate(keys middleware.KeyAuthenticator, tokens middleware.TokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		p, err := middleware.ResolvePrincipal(ctx, keys, tokens, first(md.Get("x-api-key")), first(md.Get("authorization")))
+		if errors.Is(err, middleware.ErrNoCredentials) {
+			return nil, status.Error(codes.Unauthenticated, "Missing API key or bearer token")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		scope, ok := methodScopes[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.Unimplemented, "unknown method "+info.FullMethod)
+		}
+		if !p.AllowsScope(scope) {
+			return nil, status.Error(codes.PermissionDenied, "API key lacks scope "+scope)
+		}
+
+		return handler(core.WithPrincipal(ctx, p), req)
+	}
+}
+
+func isPublicMethod(method string) bool {
+	return strings.HasPrefix(method, "/grpc.health.v1.Health/") ||
+		strings.HasPrefix(method, "/grpc.reflection.")
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,192 @@
+package transportgrpc
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	pb "github.com/MrKriegler/go-insurance/pkg/pb/insurance/v1"
+)
+
+// Conversions between core types and their protobuf messages. Zero and nil
+// times become unset timestamps.
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timestampPtr(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamp(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func pageRequest(p *pb.PageRequest) core.PageRequest {
+	return core.PageRequest{Limit: int(p.GetLimit()), Cursor: p.GetCursor()}
+}
+
+func timeRange(r *pb.TimeRange) core.TimeRange {
+	return core.TimeRange{From: fromTimestamp(r.GetFrom()), To: fromTimestamp(r.GetTo())}
+}
+
+func toApplicant(a core.Applicant) *pb.Applicant {
+	return &pb.Applicant{
+		FirstName:   a.FirstName,
+		LastName:    a.LastName,
+		Email:       a.Email,
+		DateOfBirth: a.DateOfBirth,
+		Age:         int32(a.Age),
+		Smoker:      a.Smoker,
+		State:       a.State,
+	}
+}
+
+func fromApplicant(a *pb.Applicant) core.Applicant {
+	return core.Applicant{
+		FirstName:   a.GetFirstName(),
+		LastName:    a.GetLastName(),
+		Email:       a.GetEmail(),
+		DateOfBirth: a.GetDateOfBirth(),
+		Age:         int(a.GetAge()),
+		Smoker:      a.GetSmoker(),
+		State:       a.GetState(),
+	}
+}
+
+func toProduct(p core.Product) *pb.Product {
+	return &pb.Product{
+		Id:          p.ID,
+		Slug:        p.Slug,
+		Name:        p.Name,
+		TermYears:   int32(p.TermYears),
+		MinCoverage: p.MinCoverage,
+		MaxCoverage: p.MaxCoverage,
+		BaseRate:    p.BaseRate,
+	}
+}
+
+func toQuote(q core.Quote) *pb.Quote {
+	return &pb.Quote{
+		Id:             q.ID,
+		ProductId:      q.ProductID,
+		ProductSlug:    q.ProductSlug,
+		CoverageAmount: q.CoverageAmount,
+		TermYears:      int32(q.TermYears),
+		MonthlyPremium: q.MonthlyPremium,
+		Status:         string(q.Status),
+		CreatedAt:      timestamp(q.CreatedAt),
+		ExpiresAt:      timestamp(q.ExpiresAt),
+	}
+}
+
+func toApplication(a core.Application) *pb.Application {
+	return &pb.Application{
+		Id:             a.ID,
+		QuoteId:        a.QuoteID,
+		ProductId:      a.ProductID,
+		ProductSlug:    a.ProductSlug,
+		CoverageAmount: a.CoverageAmount,
+		TermYears:      int32(a.TermYears),
+		MonthlyPremium: a.MonthlyPremium,
+		Applicant:      toApplicant(a.Applicant),
+		Status:         string(a.Status),
+		OwnerId:        a.OwnerID,
+		CreatedAt:      timestamp(a.CreatedAt),
+		UpdatedAt:      timestamp(a.UpdatedAt),
+		SubmittedAt:    timestampPtr(a.SubmittedAt),
+	}
+}
+
+func toDecisionStep(s core.UWDecisionStep) *pb.DecisionStep {
+	return &pb.DecisionStep{
+		Underwriter: s.Underwriter,
+		Action:      string(s.Action),
+		Decision:    string(s.Decision),
+		Reason:      s.Reason,
+		At:          timestamp(s.At),
+	}
+}
+
+func toCase(c core.UnderwritingCase, now time.Time) *pb.UnderwritingCase {
+	out := &pb.UnderwritingCase{
+		Id:            c.ID,
+		ApplicationId: c.ApplicationID,
+		RiskFactors: &pb.RiskFactors{
+			Age:            int32(c.RiskFactors.Age),
+			Smoker:         c.RiskFactors.Smoker,
+			CoverageAmount: c.RiskFactors.CoverageAmount,
+			TermYears:      int32(c.RiskFactors.TermYears),
+		},
+		RiskScore: &pb.RiskScore{
+			Score:       int32(c.RiskScore.Score),
+			Flags:       c.RiskScore.Flags,
+			Recommended: string(c.RiskScore.Recommended),
+		},
+		Decision:    string(c.Decision),
+		Method:      string(c.Method),
+		DecidedBy:   c.DecidedBy,
+		Reason:      c.Reason,
+		CreatedAt:   timestamp(c.CreatedAt),
+		UpdatedAt:   timestamp(c.UpdatedAt),
+		DecidedAt:   timestampPtr(c.DecidedAt),
+		AssignedTo:  c.AssignedTo,
+		AssignedAt:  timestampPtr(c.AssignedAt),
+		SlaDueAt:    timestampPtr(c.SLADueAt),
+		EscalatedAt: timestampPtr(c.EscalatedAt),
+		AgeSeconds:  int64(c.Age(now).Seconds()),
+		SlaBreached: c.SLABreached(now),
+	}
+	if c.PendingDecision != nil {
+		out.PendingDecision = toDecisionStep(*c.PendingDecision)
+	}
+	for _, s := range c.DecisionChain {
+		out.DecisionChain = append(out.DecisionChain, toDecisionStep(s))
+	}
+	return out
+}
+
+func toOffer(o core.Offer) *pb.Offer {
+	return &pb.Offer{
+		Id:             o.ID,
+		ApplicationId:  o.ApplicationID,
+		ProductSlug:    o.ProductSlug,
+		CoverageAmount: o.CoverageAmount,
+		TermYears:      int32(o.TermYears),
+		MonthlyPremium: o.MonthlyPremium,
+		Status:         string(o.Status),
+		CreatedAt:      timestamp(o.CreatedAt),
+		ExpiresAt:      timestamp(o.ExpiresAt),
+		AcceptedAt:     timestampPtr(o.AcceptedAt),
+		DeclinedAt:     timestampPtr(o.DeclinedAt),
+	}
+}
+
+func toPolicy(p core.Policy) *pb.Policy {
+	return &pb.Policy{
+		Id:             p.ID,
+		Number:         p.Number,
+		ApplicationId:  p.ApplicationID,
+		OfferId:        p.OfferID,
+		ProductSlug:    p.ProductSlug,
+		CoverageAmount: p.CoverageAmount,
+		TermYears:      int32(p.TermYears),
+		MonthlyPremium: p.MonthlyPremium,
+		Insured:        toApplicant(p.Insured),
+		Status:         string(p.Status),
+		EffectiveDate:  timestamp(p.EffectiveDate),
+		ExpiryDate:     timestamp(p.ExpiryDate),
+		IssuedAt:       timestamp(p.IssuedAt),
+	}
+}
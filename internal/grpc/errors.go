@@ -0,0 +1,85 @@
+package transportgrpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// toStatus maps domain errors to gRPC status codes the way writeError maps
+// them to HTTP statuses, with the same error codes as ErrorInfo reasons. detail replaces the message of unauthorized,
+// forbidden and internal errors so they don't leak internals.
+func toStatus(ctx context.Context, log *slog.Logger, err error, detail string) error {
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		log.WarnContext(ctx, "resource not found", "err", err)
+		return coded(codes.NotFound, err.Error(), err)
+
+	case errors.Is(err, core.ErrValidation):
+		log.WarnContext(ctx, "validation failed", "err", err)
+		return coded(codes.InvalidArgument, err.Error(), err)
+
+	case errors.Is(err, core.ErrConflict):
+		log.WarnContext(ctx, "resource conflict", "err", err)
+		return coded(codes.Aborted, err.Error(), err)
+
+	case errors.Is(err, core.ErrInvalidState):
+		log.WarnContext(ctx, "invalid state transition", "err", err)
+		return coded(codes.FailedPrecondition, err.Error(), err)
+
+	case errors.Is(err, core.ErrUnauthorized):
+		log.WarnContext(ctx, "unauthorized request", "err", err)
+		return coded(codes.Unauthenticated, detail, err)
+
+	case errors.Is(err, core.ErrForbidden):
+		log.WarnContext(ctx, "forbidden operation", "err", err)
+		return coded(codes.PermissionDenied, detail, err)
+
+	case errors.Is(err, context.DeadlineExceeded):
+		log.ErrorContext(ctx, "operation timeout", "err", err)
+		return status.Error(codes.DeadlineExceeded, "Operation took too long.")
+
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "Request cancelled.")
+
+	default:
+		log.ErrorContext(ctx, "internal server error", "err", err)
+		return status.Error(codes.Internal, detail)
+	}
+}
+
+// coded builds a status carrying the error's code (core.CodeOf) as an
+// ErrorInfo reason, and its field errors as BadRequest violations.
+func coded(c codes.Code, msg string, err error) error {
+	st := status.New(c, msg)
+	code := core.CodeOf(err)
+	if code == "" {
+		return st.Err()
+	}
+
+	info := &errdetails.ErrorInfo{Reason: code, Domain: "insurance.v1"}
+	var withDetails *status.Status
+	if fields := core.FieldErrorsOf(err); len(fields) > 0 {
+		br := &errdetails.BadRequest{}
+		for _, f := range fields {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       strings.ReplaceAll(strings.TrimPrefix(f.Pointer, "/"), "/", "."), // e.g. applicant.email
+				Description: f.Detail,
+			})
+		}
+		withDetails, err = st.WithDetails(info, br)
+	} else {
+		withDetails, err = st.WithDetails(info)
+	}
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
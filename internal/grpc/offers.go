@@ -0,0 +1,86 @@
+package transportgrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	pb "github.com/MrKriegler/go-insurance/pkg/pb/insurance/v1"
+)
+
+type offerServer struct {
+	pb.UnimplementedOfferServiceServer
+	svc core.OfferService
+	log *slog.Logger
+}
+
+func (s *offerServer) CreateOffer(ctx context.Context, req *pb.CreateOfferRequest) (*pb.Offer, error) {
+	if req.GetApplicationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "application_id is required")
+	}
+
+	offer, err := s.svc.GenerateOffer(ctx, req.GetApplicationId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toOffer(offer), nil
+}
+
+func (s *offerServer) GetOffer(ctx context.Context, req *pb.GetOfferRequest) (*pb.Offer, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	offer, err := s.svc.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to get offer")
+	}
+	return toOffer(offer), nil
+}
+
+func (s *offerServer) AcceptOffer(ctx context.Context, req *pb.AcceptOfferRequest) (*pb.Offer, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	offer, err := s.svc.Accept(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toOffer(offer), nil
+}
+
+func (s *offerServer) DeclineOffer(ctx context.Context, req *pb.DeclineOfferRequest) (*pb.Offer, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	offer, err := s.svc.Decline(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toOffer(offer), nil
+}
+
+func (s *offerServer) ListOffers(ctx context.Context, req *pb.ListOffersRequest) (*pb.ListOffersResponse, error) {
+	filter := core.OfferFilter{
+		Status:        core.OfferStatus(req.GetStatus()),
+		ProductSlug:   req.GetProductSlug(),
+		ApplicationID: req.GetApplicationId(),
+		Created:       timeRange(req.GetCreated()),
+	}
+
+	offers, err := s.svc.List(ctx, filter, pageRequest(req.GetPage()))
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to list offers")
+	}
+
+	resp := &pb.ListOffersResponse{Items: make([]*pb.Offer, len(offers.Items)), NextCursor: offers.NextCursor}
+	for i, o := range offers.Items {
+		resp.Items[i] = toOffer(o)
+	}
+	return resp, nil
+}
@@ -0,0 +1,51 @@
+package transportgrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	pb "github.com/MrKriegler/go-insurance/pkg/pb/insurance/v1"
+)
+
+type policyServer struct {
+	pb.UnimplementedPolicyServiceServer
+	svc core.PolicyService
+	log *slog.Logger
+}
+
+func (s *policyServer) GetPolicy(ctx context.Context, req *pb.GetPolicyRequest) (*pb.Policy, error) {
+	if req.GetNumber() == "" {
+		return nil, status.Error(codes.InvalidArgument, "number is required")
+	}
+
+	policy, err := s.svc.GetByNumber(ctx, req.GetNumber())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to get policy")
+	}
+	return toPolicy(policy), nil
+}
+
+func (s *policyServer) ListPolicies(ctx context.Context, req *pb.ListPoliciesRequest) (*pb.ListPoliciesResponse, error) {
+	filter := core.PolicyFilter{
+		ApplicationID: req.GetApplicationId(),
+		Status:        core.PolicyStatus(req.GetStatus()),
+		ProductSlug:   req.GetProductSlug(),
+		InsuredEmail:  req.GetInsuredEmail(),
+		Issued:        timeRange(req.GetIssued()),
+	}
+
+	policies, err := s.svc.List(ctx, filter, pageRequest(req.GetPage()))
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to list policies")
+	}
+
+	resp := &pb.ListPoliciesResponse{Items: make([]*pb.Policy, len(policies.Items)), NextCursor: policies.NextCursor}
+	for i, p := range policies.Items {
+		resp.Items[i] = toPolicy(p)
+	}
+	return resp, nil
+}
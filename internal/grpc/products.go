@@ -0,0 +1,43 @@
+package transportgrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	pb "github.com/MrKriegler/go-insurance/pkg/pb/insurance/v1"
+)
+
+type productServer struct {
+	pb.UnimplementedProductServiceServer
+	repo core.ProductRepo
+	log  *slog.Logger
+}
+
+func (s *productServer) ListProducts(ctx context.Context, _ *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	products, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to list products")
+	}
+
+	resp := &pb.ListProductsResponse{Products: make([]*pb.Product, len(products))}
+	for i, p := range products {
+		resp.Products[i] = toProduct(p)
+	}
+	return resp, nil
+}
+
+func (s *productServer) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
+	if req.GetSlug() == "" {
+		return nil, status.Error(codes.InvalidArgument, "slug is required")
+	}
+
+	product, err := s.repo.GetBySlug(ctx, req.GetSlug())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to retrieve product "+req.GetSlug())
+	}
+	return toProduct(product), nil
+}
@@ -0,0 +1,64 @@
+package transportgrpc
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	pb "github.com/MrKriegler/go-insurance/pkg/pb/insurance/v1"
+)
+
+type quoteServer struct {
+	pb.UnimplementedQuoteServiceServer
+	svc  core.QuoteService
+	repo core.QuoteRepo
+	log  *slog.Logger
+}
+
+func (s *quoteServer) CreateQuote(ctx context.Context, req *pb.CreateQuoteRequest) (*pb.Quote, error) {
+	quote, err := s.svc.Price(ctx, core.QuoteInput{
+		ProductSlug:    req.GetProductSlug(),
+		CoverageAmount: req.GetCoverageAmount(),
+		TermYears:      int(req.GetTermYears()),
+		Age:            int(req.GetAge()),
+		Smoker:         req.GetSmoker(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to price quote")
+	}
+	return toQuote(quote), nil
+}
+
+func (s *quoteServer) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (*pb.Quote, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	quote, err := s.repo.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to get quote")
+	}
+	return toQuote(quote), nil
+}
+
+func (s *quoteServer) ListQuotes(ctx context.Context, req *pb.ListQuotesRequest) (*pb.ListQuotesResponse, error) {
+	filter := core.QuoteFilter{
+		Status:      core.QuoteStatus(req.GetStatus()),
+		ProductSlug: req.GetProductSlug(),
+		Created:     timeRange(req.GetCreated()),
+	}
+
+	quotes, err := s.svc.List(ctx, filter, pageRequest(req.GetPage()))
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to list quotes")
+	}
+
+	resp := &pb.ListQuotesResponse{Items: make([]*pb.Quote, len(quotes.Items)), NextCursor: quotes.NextCursor}
+	for i, q := range quotes.Items {
+		resp.Items[i] = toQuote(q)
+	}
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+package transportgrpc
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/MrKriegler/go-insurance/internal/middleware"
+	"github.com/MrKriegler/go-insurance/internal/platform/ratelimit"
+)
+
+// preAuthRateLimit limits every call per client IP before it is
+// authenticated, as middleware.RateLimiter.IPMiddleware does, sharing its
+// buckets, so guessed keys count against one limit over both transports.
+func preAuthRateLimit(rl *middleware.RateLimiter, limit ratelimit.Limit) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		res, ok := rl.TakePreAuth(ctx, peerAddr(ctx), limit)
+		if ok && !res.Allowed {
+			return nil, rateLimited(ctx, res, limit)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimit limits calls per API key or subject, after authentication, as
+// middleware.RateLimiter.Middleware does; scope rules apply to the scope
+// of the method. The ratelimit-* headers mirror the HTTP ones.
+func rateLimit(rl *middleware.RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		res, limit, ok := rl.TakeCall(ctx, peerAddr(ctx), methodScopes[info.FullMethod])
+		if !ok {
+			return handler(ctx, req)
+		}
+		if !res.Allowed {
+			return nil, rateLimited(ctx, res, limit)
+		}
+		_ = grpc.SetHeader(ctx, rateLimitHeaders(res, limit))
+		return handler(ctx, req)
+	}
+}
+
+// rateLimited is the ResourceExhausted status of a refused call, with the
+// rate_limited reason and a RetryInfo saying when to try again.
+func rateLimited(ctx context.Context, res ratelimit.Result, limit ratelimit.Limit) error {
+	md := rateLimitHeaders(res, limit)
+	md.Set("retry-after", ceilSeconds(res.RetryAfter))
+	_ = grpc.SetHeader(ctx, md)
+
+	st := status.New(codes.ResourceExhausted, "Too many requests. Please try again later.")
+	withDetails, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: "rate_limited", Domain: "insurance.v1"},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(res.RetryAfter)},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+func rateLimitHeaders(res ratelimit.Result, limit ratelimit.Limit) metadata.MD {
+	return metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(res.Limit),
+		"ratelimit-remaining", strconv.Itoa(res.Remaining),
+		"ratelimit-reset", ceilSeconds(res.Reset),
+		"ratelimit-policy", limit.String(),
+	)
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// peerAddr is the caller's address, "ip:port", or empty when unknown.
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
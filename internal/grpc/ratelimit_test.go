@@ -0,0 +1,89 @@
+package transportgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/middleware"
+	"github.com/MrKriegler/go-insurance/internal/platform/ratelimit"
+)
+
+type keys map[string]core.Principal
+
+func (k keys) Authenticate(_ context.Context, key string) (core.Principal, error) {
+	if p, ok := k[key]; ok {
+		return p, nil
+	}
+	return core.Principal{}, errors.New("invalid API key")
+}
+
+// call runs the interceptors NewServer chains for rate limiting, as ip.
+func call(rl *middleware.RateLimiter, ip, key string) error {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 4242}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", key))
+	info := &grpc.UnaryServerInfo{FullMethod: "/insurance.v1.QuoteService/GetQuote"}
+
+	chain := []grpc.UnaryServerInterceptor{
+		preAuthRateLimit(rl, ratelimit.PerMinute(2)),
+		authenticate(keys{"good": {Subject: "partner", KeyID: "k1", Scopes: []string{"quotes:read"}}}, nil),
+		rateLimit(rl),
+	}
+	handler := func(context.Context, any) (any, error) { return nil, nil }
+	for i := len(chain) - 1; i >= 0; i-- {
+		next, interceptor := handler, chain[i]
+		handler = func(ctx context.Context, req any) (any, error) { return interceptor(ctx, req, info, next) }
+	}
+	_, err := handler(ctx, nil)
+	return err
+}
+
+func TestRateLimitCountsFailedAuthentication(t *testing.T) {
+	rl := middleware.NewRateLimiter(ratelimit.NewMemoryStore(), ratelimit.PerMinute(100), nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for i, want := range []codes.Code{codes.Unauthenticated, codes.Unauthenticated, codes.ResourceExhausted} {
+		if got := status.Code(call(rl, "203.0.113.7", "guess")); got != want {
+			t.Fatalf("call %d: code %v, want %v", i+1, got, want)
+		}
+	}
+	if got := status.Code(call(rl, "198.51.100.1", "guess")); got != codes.Unauthenticated {
+		t.Errorf("other IP: code %v, want %v", got, codes.Unauthenticated)
+	}
+}
+
+func TestRateLimitPerKey(t *testing.T) {
+	rules := []middleware.RateLimitRule{{Name: "quotes:read", Scope: "quotes:read", Limit: ratelimit.PerMinute(1)}}
+	rl := middleware.NewRateLimiter(ratelimit.NewMemoryStore(), ratelimit.PerMinute(100), rules, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := call(rl, "203.0.113.7", "good"); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	err := call(rl, "198.51.100.1", "good") // Same key from elsewhere shares its bucket
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second call: %v, want ResourceExhausted", err)
+	}
+	var reason string
+	var retry bool
+	for _, d := range status.Convert(err).Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			reason = d.Reason
+		case *errdetails.RetryInfo:
+			retry = d.RetryDelay.AsDuration() > 0
+		}
+	}
+	if reason != "rate_limited" || !retry {
+		t.Errorf("details: reason %q, retry delay set %v; want rate_limited with a retry delay", reason, retry)
+	}
+}
@@ -0,0 +1,121 @@
+package transportgrpc
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/middleware"
+	"github.com/MrKriegler/go-insurance/internal/platform/ratelimit"
+	pb "github.com/MrKriegler/go-insurance/pkg/pb/insurance/v1"
+)
+
+// Deps are the services behind the gRPC API; the same ones the REST
+// handlers use.
+type Deps struct {
+	Products     core.ProductRepo
+	Quotes       core.QuoteService
+	QuoteRepo    core.QuoteRepo // used for GetQuote
+	Applications core.ApplicationService
+	Underwriting core.UnderwritingService
+	Offers       core.OfferService
+	Policies     core.PolicyService
+
+	Keys   middleware.KeyAuthenticator
+	Tokens middleware.TokenVerifier // Optional: JWT bearer tokens
+
+	// Optional: limits calls per IP before authentication (PreAuthLimit),
+	// then per API key or subject, in the same buckets as the REST API.
+	RateLimiter  *middleware.RateLimiter
+	PreAuthLimit ratelimit.Limit
+
+	// Optional: backs the standard health service, so its status can be
+	// changed, e.g. when draining. Nil reports serving throughout.
+	Health *health.Server
+
+	Log *slog.Logger
+}
+
+// NewServer registers every service, plus the standard health and
+// reflection services, on a new gRPC server.
+func NewServer(d Deps) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{recoverer(d.Log), logger(d.Log)}
+	if d.RateLimiter != nil {
+		interceptors = append(interceptors,
+			preAuthRateLimit(d.RateLimiter, d.PreAuthLimit),
+			authenticate(d.Keys, d.Tokens),
+			rateLimit(d.RateLimiter))
+	} else {
+		interceptors = append(interceptors, authenticate(d.Keys, d.Tokens))
+	}
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(append(interceptors, auditInfo)...))
+
+	pb.RegisterProductServiceServer(srv, &productServer{repo: d.Products, log: d.Log})
+	pb.RegisterQuoteServiceServer(srv, &quoteServer{svc: d.Quotes, repo: d.QuoteRepo, log: d.Log})
+	pb.RegisterApplicationServiceServer(srv, &applicationServer{svc: d.Applications, log: d.Log})
+	pb.RegisterUnderwritingServiceServer(srv, &underwritingServer{svc: d.Underwriting, log: d.Log})
+	pb.RegisterOfferServiceServer(srv, &offerServer{svc: d.Offers, log: d.Log})
+	pb.RegisterPolicyServiceServer(srv, &policyServer{svc: d.Policies, log: d.Log})
+
+	if d.Health == nil {
+		d.Health = health.NewServer()
+	}
+	healthpb.RegisterHealthServer(srv, d.Health)
+	reflection.Register(srv)
+	return srv
+}
+
+// logger logs each call with its status code and duration.
+func logger(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		log.InfoContext(ctx, "grpc call",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration", time.Since(start))
+		return resp, err
+	}
+}
+
+// auditInfo hands core the x-request-id and x-change-reason metadata, for
+// the audit entries of the changes a call makes, as middleware.AuditInfo
+// does from HTTP headers.
+func auditInfo(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	reason := strings.TrimSpace(first(md.Get("x-change-reason")))
+	if utf8.RuneCountInString(reason) > core.MaxAuditReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "x-change-reason must be at most %d characters", core.MaxAuditReasonLength)
+	}
+	ctx = core.WithAuditInfo(ctx, core.AuditInfo{
+		RequestID: first(md.Get("x-request-id")),
+		Reason:    reason,
+	})
+	return handler(ctx, req)
+}
+
+// recoverer turns a panicking handler into an Internal error.
+func recoverer(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.ErrorContext(ctx, "grpc handler panic",
+					"method", info.FullMethod, "panic", rec, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "Internal Server Error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
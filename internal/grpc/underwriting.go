@@ -0,0 +1,110 @@
+package transportgrpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	pb "github.com/MrKriegler/go-insurance/pkg/pb/insurance/v1"
+)
+
+type underwritingServer struct {
+	pb.UnimplementedUnderwritingServiceServer
+	svc core.UnderwritingService
+	log *slog.Logger
+}
+
+func (s *underwritingServer) GetCase(ctx context.Context, req *pb.GetCaseRequest) (*pb.UnderwritingCase, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	uwCase, err := s.svc.GetCase(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to get underwriting case")
+	}
+	return toCase(uwCase, time.Now()), nil
+}
+
+func (s *underwritingServer) ListCases(ctx context.Context, req *pb.ListCasesRequest) (*pb.ListCasesResponse, error) {
+	created := timeRange(req.GetCreated())
+	filter := core.UWCaseFilter{
+		Assignee:      req.GetAssignee(),
+		Decision:      core.UWDecisionReferred,
+		CreatedAfter:  created.From,
+		CreatedBefore: created.To,
+	}
+	if req.Decision != nil {
+		filter.Decision = core.UWDecision(req.GetDecision())
+	}
+
+	cases, err := s.svc.ListCases(ctx, filter, pageRequest(req.GetPage()))
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, "Failed to list underwriting cases")
+	}
+
+	now := time.Now()
+	resp := &pb.ListCasesResponse{Items: make([]*pb.UnderwritingCase, len(cases.Items)), NextCursor: cases.NextCursor}
+	for i, c := range cases.Items {
+		resp.Items[i] = toCase(c, now)
+	}
+	return resp, nil
+}
+
+func (s *underwritingServer) ClaimCase(ctx context.Context, req *pb.ClaimCaseRequest) (*pb.UnderwritingCase, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	uwCase, err := s.svc.ClaimCase(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toCase(uwCase, time.Now()), nil
+}
+
+func (s *underwritingServer) AssignCase(ctx context.Context, req *pb.AssignCaseRequest) (*pb.UnderwritingCase, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	uwCase, err := s.svc.AssignCase(ctx, req.GetId(), req.GetAssignee())
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toCase(uwCase, time.Now()), nil
+}
+
+func (s *underwritingServer) DecideCase(ctx context.Context, req *pb.DecideCaseRequest) (*pb.UnderwritingCase, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	uwCase, err := s.svc.MakeDecision(ctx, req.GetId(), core.UWDecisionInput{
+		Decision: core.UWDecision(req.GetDecision()),
+		Reason:   req.GetReason(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toCase(uwCase, time.Now()), nil
+}
+
+func (s *underwritingServer) ConfirmCase(ctx context.Context, req *pb.ConfirmCaseRequest) (*pb.UnderwritingCase, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	uwCase, err := s.svc.ConfirmDecision(ctx, req.GetId(), core.UWConfirmInput{
+		Confirm: req.GetConfirm(),
+		Reason:  req.GetReason(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.log, err, err.Error())
+	}
+	return toCase(uwCase, time.Now()), nil
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/logging"
+	"github.com/MrKriegler/go-insurance/pkg/problem"
+)
+
+type APIKeyHandler struct {
+	Svc core.APIKeyService
+	Log *slog.Logger
+}
+
+func NewAPIKeyHandler(svc core.APIKeyService, log *slog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{Svc: svc, Log: log}
+}
+
+func (h *APIKeyHandler) Mount(r chi.Router) {
+	r.Route("/admin/api-keys", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+		r.Get("/{key_id}", h.Get)
+		r.Post("/{key_id}:rotate", h.Rotate)
+		r.Post("/{key_id}:revoke", h.Revoke)
+	})
+}
+
+// List returns all API keys without their secrets.
+// 200: JSON array; 403: not an admin; 500: internal error.
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.Svc.List(r.Context())
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to list API keys")
+		return
+	}
+
+	// Return empty array instead of null
+	if keys == nil {
+		keys = []core.APIKey{}
+	}
+
+	if err := writeResource(w, r, http.StatusOK, keys); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode api keys", "err", err)
+	}
+}
+
+// Create issues a new API key. The plaintext key is only in this response.
+// 201: JSON; 400: bad JSON/validation; 403: not an admin; 500: internal error.
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var in core.APIKeyInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
+		return
+	}
+
+	issued, err := h.Svc.Create(r.Context(), in)
+	if err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	logging.Add(r.Context(), "key_id", issued.ID)
+	h.Log.InfoContext(r.Context(), "api key issued", "key_id", issued.ID, "name", issued.Name, "scopes", issued.Scopes)
+	// The plaintext key must not be cached (or stored for idempotent replay)
+	w.Header().Set("Cache-Control", "no-store")
+	if err := writeResource(w, r, http.StatusCreated, issued); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode api key", "err", err)
+	}
+}
+
+// Get retrieves an API key's metadata.
+// 200: JSON; 403: not an admin; 404: not found; 500: internal error.
+func (h *APIKeyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "key_id")
+
+	key, err := h.Svc.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to get API key")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, key); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode api key", "key_id", id, "err", err)
+	}
+}
+
+// Rotate issues a replacement key. Body (optional): {"grace_seconds": N}
+// keeps the old key valid for N seconds; otherwise it is revoked immediately.
+// 201: JSON; 400: bad JSON/validation; 403: not an admin; 404: not found; 409: revoked, expired or already rotated; 500: internal error.
+func (h *APIKeyHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "key_id")
+
+	var input struct {
+		GraceSeconds int `json:"grace_seconds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
+		return
+	}
+
+	issued, err := h.Svc.Rotate(r.Context(), id, time.Duration(input.GraceSeconds)*time.Second)
+	if err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	h.Log.InfoContext(r.Context(), "api key rotated", "old_key_id", id, "key_id", issued.ID, "grace_seconds", input.GraceSeconds)
+	// The plaintext key must not be cached (or stored for idempotent replay)
+	w.Header().Set("Cache-Control", "no-store")
+	if err := writeResource(w, r, http.StatusCreated, issued); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode api key", "err", err)
+	}
+}
+
+// Revoke disables an API key immediately.
+// 200: JSON; 403: not an admin; 404: not found; 500: internal error.
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "key_id")
+
+	key, err := h.Svc.Revoke(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	h.Log.InfoContext(r.Context(), "api key revoked", "key_id", id)
+	if err := writeResource(w, r, http.StatusOK, key); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode api key", "key_id", id, "err", err)
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -8,12 +9,16 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/logging"
 	"github.com/MrKriegler/go-insurance/pkg/problem"
 )
 
 type ApplicationHandler struct {
 	Svc core.ApplicationService
 	Log *slog.Logger
+
+	// RequireIfMatch rejects state changes without an If-Match header (428)
+	RequireIfMatch bool
 }
 
 func NewApplicationHandler(svc core.ApplicationService, log *slog.Logger) *ApplicationHandler {
@@ -22,96 +27,163 @@ func NewApplicationHandler(svc core.ApplicationService, log *slog.Logger) *Appli
 
 func (h *ApplicationHandler) Mount(r chi.Router) {
 	r.Route("/applications", func(r chi.Router) {
+		r.Get("/", h.List)
 		r.Post("/", h.Create)
 		r.Get("/{application_id}", h.Get)
 		r.Patch("/{application_id}", h.Patch)
 		r.Post("/{application_id}:submit", h.Submit)
+		r.Get("/{application_id}/history", h.History)
 	})
 }
 
 // Create creates a new application from a quote.
-// 201: JSON; 400: bad JSON/validation; 404: quote not found; 409: quote already used; 500: internal error.
+// 201: JSON; 400: bad JSON/validation; 403: role not allowed; 404: quote not found; 409: quote already used; 500: internal error.
 func (h *ApplicationHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var in core.ApplicationInput
 	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		problem.Write(w, http.StatusBadRequest, "Invalid JSON", "Body could not be decoded.")
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
 		return
 	}
 
 	app, err := h.Svc.Create(r.Context(), in)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, err.Error())
+		writeError(w, r, h.Log, err, err.Error())
 		return
 	}
+	logging.Add(r.Context(), "application_id", app.ID)
 
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(app); err != nil {
-		h.Log.Error("failed to encode application", "err", err)
+	if err := writeResource(w, r, http.StatusCreated, app); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode application", "err", err)
 	}
 }
 
 // Get retrieves an application by ID.
-// 200: JSON; 400: missing ID; 404: not found; 500: internal error.
+// 200: JSON; 304: unchanged since If-None-Match ETag; 400: missing ID; 403: another applicant's; 404: not found; 500: internal error.
 func (h *ApplicationHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "application_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Application ID", "Path parameter application_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Application ID", "Path parameter application_id is required.")
 		return
 	}
 
 	app, err := h.Svc.Get(r.Context(), id)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to get application")
+		writeError(w, r, h.Log, err, "Failed to get application")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(app); err != nil {
-		h.Log.Error("failed to encode application", "application_id", id, "err", err)
+	if err := writeResource(w, r, http.StatusOK, app); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode application", "application_id", id, "err", err)
 	}
 }
 
 // Patch updates an application (only in draft status).
-// 200: JSON; 400: bad JSON/validation; 404: not found; 409: not in draft status; 500: internal error.
+// 200: JSON; 400: bad JSON/validation; 403: another applicant's; 404: not found; 409: not in draft status; 412: ETag mismatch; 428: If-Match required; 500: internal error.
 func (h *ApplicationHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "application_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Application ID", "Path parameter application_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Application ID", "Path parameter application_id is required.")
+		return
+	}
+
+	load := func(ctx context.Context) (core.Application, error) { return h.Svc.Get(ctx, id) }
+	r, ok := ifMatch(w, r, h.Log, h.RequireIfMatch, load, applicationVersion)
+	if !ok {
 		return
 	}
 
 	var patch core.ApplicationPatch
 	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
-		problem.Write(w, http.StatusBadRequest, "Invalid JSON", "Body could not be decoded.")
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
 		return
 	}
 
 	app, err := h.Svc.Patch(r.Context(), id, patch)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, err.Error())
+		writeError(w, r, h.Log, err, err.Error())
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(app); err != nil {
-		h.Log.Error("failed to encode application", "application_id", id, "err", err)
+	if err := writeResource(w, r, http.StatusOK, app); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode application", "application_id", id, "err", err)
 	}
 }
 
 // Submit submits an application for underwriting.
-// 200: JSON; 400: incomplete application; 404: not found; 409: already submitted; 500: internal error.
+// 200: JSON; 400: incomplete application; 403: another applicant's; 404: not found; 409: already submitted; 412: ETag mismatch; 428: If-Match required; 500: internal error.
 func (h *ApplicationHandler) Submit(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "application_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Application ID", "Path parameter application_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Application ID", "Path parameter application_id is required.")
+		return
+	}
+
+	load := func(ctx context.Context) (core.Application, error) { return h.Svc.Get(ctx, id) }
+	r, ok := ifMatch(w, r, h.Log, h.RequireIfMatch, load, applicationVersion)
+	if !ok {
 		return
 	}
 
 	app, err := h.Svc.Submit(r.Context(), id)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, err.Error())
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, app); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode application", "application_id", id, "err", err)
+	}
+}
+
+// List returns applications, newest first, one page at a time. Applicants
+// only see their own.
+// Query: status, product, email, from/to (creation time), limit, cursor.
+// 200: JSON page; 304: unchanged since If-None-Match ETag; 400: bad filter or cursor; 403: forbidden; 500: internal error.
+func (h *ApplicationHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, created, ok := listParams(w, r, q)
+	if !ok {
+		return
+	}
+
+	filter := core.ApplicationFilter{
+		Status:         core.ApplicationStatus(q.Get("status")),
+		ProductSlug:    q.Get("product"),
+		ApplicantEmail: q.Get("email"),
+		Created:        created,
+	}
+
+	apps, err := h.Svc.List(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to list applications")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, apps); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode applications", "err", err)
+	}
+}
+
+// History returns the audit trail of an application, oldest first.
+// 200: JSON page; 400: missing ID or bad cursor; 403: not staff; 404: not found; 500: internal error.
+func (h *ApplicationHandler) History(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "application_id")
+	if id == "" {
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Application ID", "Path parameter application_id is required.")
+		return
+	}
+	page, _, ok := listParams(w, r, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	history, err := h.Svc.History(r.Context(), id, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to get application history")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(app); err != nil {
-		h.Log.Error("failed to encode application", "application_id", id, "err", err)
+	if err := writeResource(w, r, http.StatusOK, history); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode history", "application_id", id, "err", err)
 	}
 }
@@ -10,38 +10,52 @@ import (
 	"github.com/MrKriegler/go-insurance/pkg/problem"
 )
 
-func writeError(ctx context.Context, log *slog.Logger, w http.ResponseWriter, err error, detail string) {
+// writeError writes err as a problem response whose code is the error's
+// (see core.CodeOf), with field errors for validation failures. detail
+// replaces the message of unauthorized, forbidden and internal errors.
+func writeError(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error, detail string) {
+	ctx := r.Context()
+	code := core.CodeOf(err)
+
 	switch {
 	case errors.Is(err, core.ErrNotFound):
 		log.WarnContext(ctx, "resource not found", "err", err)
-		problem.Write(w, http.StatusNotFound, "Not Found", err.Error())
+		problem.Write(w, r, http.StatusNotFound, code, "Not Found", err.Error())
 
 	case errors.Is(err, core.ErrValidation):
 		log.WarnContext(ctx, "validation failed", "err", err)
-		problem.Write(w, http.StatusBadRequest, "Validation Error", err.Error())
+		p := problem.New(http.StatusBadRequest, code, "Validation Error", err.Error())
+		for _, f := range core.FieldErrorsOf(err) {
+			p.Errors = append(p.Errors, problem.FieldError(f))
+		}
+		p.Write(w, r)
+
+	case errors.Is(err, core.ErrPreconditionFailed):
+		log.WarnContext(ctx, "precondition failed", "err", err)
+		problem.Write(w, r, http.StatusPreconditionFailed, code, "Precondition Failed", err.Error())
 
 	case errors.Is(err, core.ErrConflict):
 		log.WarnContext(ctx, "resource conflict", "err", err)
-		problem.Write(w, http.StatusConflict, "Conflict", err.Error())
+		problem.Write(w, r, http.StatusConflict, code, "Conflict", err.Error())
 
 	case errors.Is(err, core.ErrInvalidState):
 		log.WarnContext(ctx, "invalid state transition", "err", err)
-		problem.Write(w, http.StatusConflict, "Invalid State", err.Error())
+		problem.Write(w, r, http.StatusConflict, code, "Invalid State", err.Error())
 
 	case errors.Is(err, core.ErrUnauthorized):
 		log.WarnContext(ctx, "unauthorized request", "err", err)
-		problem.Write(w, http.StatusUnauthorized, "Unauthorized", detail)
+		problem.Write(w, r, http.StatusUnauthorized, code, "Unauthorized", detail)
 
 	case errors.Is(err, core.ErrForbidden):
 		log.WarnContext(ctx, "forbidden operation", "err", err)
-		problem.Write(w, http.StatusForbidden, "Forbidden", detail)
+		problem.Write(w, r, http.StatusForbidden, code, "Forbidden", detail)
 
 	case errors.Is(err, context.DeadlineExceeded):
 		log.ErrorContext(ctx, "operation timeout", "err", err)
-		problem.Write(w, http.StatusGatewayTimeout, "Timeout", "Operation took too long.")
+		problem.Write(w, r, http.StatusGatewayTimeout, "timeout", "Timeout", "Operation took too long.")
 
 	default:
 		log.ErrorContext(ctx, "internal server error", "err", err)
-		problem.Write(w, http.StatusInternalServerError, "Internal Server Error", detail)
+		problem.Write(w, r, http.StatusInternalServerError, "internal_error", "Internal Server Error", detail)
 	}
 }
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/pkg/problem"
+)
+
+// etagOf returns the JSON body for v and its strong ETag, a hash of that body.
+func etagOf(v any) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// writeResource writes v as JSON, in the shape of the request's API version,
+// with its ETag. GET requests whose If-None-Match names the current ETag get
+// 304 Not Modified instead.
+func writeResource(w http.ResponseWriter, r *http.Request, status int, v any) error {
+	body, etag, err := etagOf(render(r, v))
+	if err != nil {
+		return err
+	}
+	w.Header().Set("ETag", etag)
+
+	if r.Method == http.MethodGet && etagListMatches(r.Header.Get("If-None-Match"), etag, false) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
+
+// ifMatch checks the If-Match precondition of a state-changing request
+// against the current resource, which load fetches only when needed.
+// It writes 428 when required and the header is missing, 412 when the
+// resource has changed, and the usual error response when load fails.
+// Otherwise it returns the request with the checked version in its
+// context, so the service only writes that version and a change made
+// since this check also gets 412.
+func ifMatch[T any](w http.ResponseWriter, r *http.Request, log *slog.Logger, required bool,
+	load func(context.Context) (T, error), version func(T) int) (*http.Request, bool) {
+
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		if required {
+			problem.Write(w, r, http.StatusPreconditionRequired, "precondition_required", "Precondition Required",
+				"Send If-Match with the resource's current ETag.")
+			return r, false
+		}
+		return r, true
+	}
+
+	current, err := load(r.Context())
+	if err != nil {
+		writeError(w, r, log, err, err.Error())
+		return r, false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return r, true // Any version will do
+	}
+	_, etag, err := etagOf(render(r, current))
+	if err != nil {
+		writeError(w, r, log, err, "Failed to check precondition")
+		return r, false
+	}
+
+	if !etagListMatches(header, etag, true) {
+		w.Header().Set("ETag", etag)
+		problem.Write(w, r, http.StatusPreconditionFailed, "precondition_failed", "Precondition Failed",
+			"The resource has changed; fetch it again and retry with its current ETag.")
+		return r, false
+	}
+	return r.WithContext(core.WithExpectedVersion(r.Context(), version(current))), true
+}
+
+// Versions of the resources conditional requests change.
+func applicationVersion(app core.Application) int  { return app.Version }
+func offerVersion(offer core.Offer) int            { return offer.Version }
+func caseVersion(uwCase core.UnderwritingCase) int { return uwCase.Version }
+
+// etagListMatches reports whether an If-Match/If-None-Match header value
+// names etag. Strong comparison (If-Match) never matches weak validators.
+func etagListMatches(header, etag string, strong bool) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak, ok := strings.CutPrefix(candidate, "W/"); ok {
+			if strong {
+				continue
+			}
+			candidate = weak
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type JobHandler struct {
+	Svc core.JobService
+	Log *slog.Logger
+}
+
+func NewJobHandler(svc core.JobService, log *slog.Logger) *JobHandler {
+	return &JobHandler{Svc: svc, Log: log}
+}
+
+func (h *JobHandler) Mount(r chi.Router) {
+	r.Route("/admin/jobs", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Get("/{job_id}", h.Get)
+		r.Post("/{job_id}:retry", h.Retry)
+		r.Post("/{job_id}:discard", h.Discard)
+	})
+}
+
+// List returns background jobs. Filters: status (e.g. dead), kind.
+// 200: JSON page; 400: bad filter or cursor; 403: not an admin; 500: internal error.
+func (h *JobHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, _, ok := listParams(w, r, q)
+	if !ok {
+		return
+	}
+
+	filter := core.JobFilter{
+		Status: core.JobStatus(q.Get("status")),
+		Kind:   core.JobKind(q.Get("kind")),
+	}
+
+	jobs, err := h.Svc.List(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to list jobs")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, jobs); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode jobs", "err", err)
+	}
+}
+
+// Get retrieves a job, including its attempts and last error.
+// 200: JSON; 403: not an admin; 404: not found; 500: internal error.
+func (h *JobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "job_id")
+
+	job, err := h.Svc.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to get job")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, job); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode job", "job_id", id, "err", err)
+	}
+}
+
+// Retry queues a dead job to run now with a fresh set of attempts.
+// 200: JSON; 403: not an admin; 404: not found; 409: not dead; 500: internal error.
+func (h *JobHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "job_id")
+
+	job, err := h.Svc.Retry(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	h.Log.InfoContext(r.Context(), "job retried", "job_id", id)
+	if err := writeResource(w, r, http.StatusOK, job); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode job", "job_id", id, "err", err)
+	}
+}
+
+// Discard gives up on a dead job.
+// 200: JSON; 403: not an admin; 404: not found; 409: not dead; 500: internal error.
+func (h *JobHandler) Discard(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "job_id")
+
+	job, err := h.Svc.Discard(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	h.Log.InfoContext(r.Context(), "job discarded", "job_id", id)
+	if err := writeResource(w, r, http.StatusOK, job); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode job", "job_id", id, "err", err)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/pkg/problem"
+)
+
+type LogLevelHandler struct {
+	Level *slog.LevelVar
+	Log   *slog.Logger
+}
+
+func NewLogLevelHandler(level *slog.LevelVar, log *slog.Logger) *LogLevelHandler {
+	return &LogLevelHandler{Level: level, Log: log}
+}
+
+func (h *LogLevelHandler) Mount(r chi.Router) {
+	r.Get("/admin/log-level", h.Get)
+	r.Put("/admin/log-level", h.Set)
+}
+
+// logLevel is the body of both endpoints, e.g. {"level": "debug"}.
+type logLevel struct {
+	Level string `json:"level"`
+}
+
+// Get returns the level this replica logs at.
+// 200: JSON; 403: not an admin.
+func (h *LogLevelHandler) Get(w http.ResponseWriter, r *http.Request) {
+	if err := authorizeAdmin(r); err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+	h.write(w, r)
+}
+
+// Set changes the level this replica logs at until it restarts: debug,
+// info, warn or error. Other replicas keep theirs.
+// 200: JSON; 400: bad JSON or unknown level; 403: not an admin.
+func (h *LogLevelHandler) Set(w http.ResponseWriter, r *http.Request) {
+	if err := authorizeAdmin(r); err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	var in logLevel
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
+		return
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(in.Level)); err != nil {
+		writeError(w, r, h.Log, &core.ValidationError{Fields: []core.FieldError{
+			{Pointer: "/level", Code: core.FieldInvalid, Detail: "level must be debug, info, warn or error"},
+		}}, "")
+		return
+	}
+
+	from := h.Level.Level()
+	h.Level.Set(level)
+	h.Log.WarnContext(r.Context(), "log level changed", "from", from, "to", level)
+	h.write(w, r)
+}
+
+func (h *LogLevelHandler) write(w http.ResponseWriter, r *http.Request) {
+	body := logLevel{Level: strings.ToLower(h.Level.Level().String())}
+	if err := writeResource(w, r, http.StatusOK, body); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode log level", "err", err)
+	}
+}
+
+// authorizeAdmin allows admins only, for endpoints that act on this
+// process rather than through a core service.
+func authorizeAdmin(r *http.Request) error {
+	p, ok := core.PrincipalFrom(r.Context())
+	if !ok {
+		return fmt.Errorf("%w: no authenticated principal", core.ErrUnauthorized)
+	}
+	if !p.HasRole(core.RoleAdmin) {
+		return fmt.Errorf("%w: requires role %v", core.ErrForbidden, []core.Role{core.RoleAdmin})
+	}
+	return nil
+}
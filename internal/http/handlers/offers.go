@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
 	"log/slog"
 	"net/http"
 
@@ -14,6 +14,9 @@ import (
 type OfferHandler struct {
 	Svc core.OfferService
 	Log *slog.Logger
+
+	// RequireIfMatch rejects state changes without an If-Match header (428)
+	RequireIfMatch bool
 }
 
 func NewOfferHandler(svc core.OfferService, log *slog.Logger) *OfferHandler {
@@ -26,89 +29,154 @@ func (h *OfferHandler) Mount(r chi.Router) {
 
 	// Manage offers via /offers
 	r.Route("/offers", func(r chi.Router) {
+		r.Get("/", h.List)
 		r.Get("/{offer_id}", h.Get)
 		r.Post("/{offer_id}:accept", h.Accept)
 		r.Post("/{offer_id}:decline", h.Decline)
+		r.Get("/{offer_id}/history", h.History)
 	})
 }
 
 // Create generates an offer from an approved application.
-// 201: JSON; 404: application not found; 409: not approved or offer exists; 500: internal error.
+// 201: JSON; 403: not staff; 404: application not found; 409: not approved or offer exists; 500: internal error.
 func (h *OfferHandler) Create(w http.ResponseWriter, r *http.Request) {
 	appID := chi.URLParam(r, "application_id")
 	if appID == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Application ID", "Path parameter application_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Application ID", "Path parameter application_id is required.")
 		return
 	}
 
 	offer, err := h.Svc.GenerateOffer(r.Context(), appID)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, err.Error())
+		writeError(w, r, h.Log, err, err.Error())
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(offer); err != nil {
-		h.Log.Error("failed to encode offer", "err", err)
+	if err := writeResource(w, r, http.StatusCreated, offer); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode offer", "err", err)
 	}
 }
 
 // Get retrieves an offer by ID.
-// 200: JSON; 400: missing ID; 404: not found; 500: internal error.
+// 200: JSON; 304: unchanged since If-None-Match ETag; 400: missing ID; 403: another applicant's; 404: not found; 500: internal error.
 func (h *OfferHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "offer_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Offer ID", "Path parameter offer_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Offer ID", "Path parameter offer_id is required.")
 		return
 	}
 
 	offer, err := h.Svc.Get(r.Context(), id)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to get offer")
+		writeError(w, r, h.Log, err, "Failed to get offer")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(offer); err != nil {
-		h.Log.Error("failed to encode offer", "offer_id", id, "err", err)
+	if err := writeResource(w, r, http.StatusOK, offer); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode offer", "offer_id", id, "err", err)
 	}
 }
 
 // Accept accepts an offer.
-// 200: JSON; 400: missing ID; 404: not found; 409: expired or not pending; 500: internal error.
+// 200: JSON; 400: missing ID; 403: another applicant's; 404: not found; 409: expired or not pending; 412: ETag mismatch; 428: If-Match required; 500: internal error.
 func (h *OfferHandler) Accept(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "offer_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Offer ID", "Path parameter offer_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Offer ID", "Path parameter offer_id is required.")
+		return
+	}
+
+	load := func(ctx context.Context) (core.Offer, error) { return h.Svc.Get(ctx, id) }
+	r, ok := ifMatch(w, r, h.Log, h.RequireIfMatch, load, offerVersion)
+	if !ok {
 		return
 	}
 
 	offer, err := h.Svc.Accept(r.Context(), id)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, err.Error())
+		writeError(w, r, h.Log, err, err.Error())
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(offer); err != nil {
-		h.Log.Error("failed to encode offer", "offer_id", id, "err", err)
+	if err := writeResource(w, r, http.StatusOK, offer); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode offer", "offer_id", id, "err", err)
 	}
 }
 
 // Decline declines an offer.
-// 200: JSON; 400: missing ID; 404: not found; 409: not pending; 500: internal error.
+// 200: JSON; 400: missing ID; 403: another applicant's; 404: not found; 409: not pending; 412: ETag mismatch; 428: If-Match required; 500: internal error.
 func (h *OfferHandler) Decline(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "offer_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Offer ID", "Path parameter offer_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Offer ID", "Path parameter offer_id is required.")
+		return
+	}
+
+	load := func(ctx context.Context) (core.Offer, error) { return h.Svc.Get(ctx, id) }
+	r, ok := ifMatch(w, r, h.Log, h.RequireIfMatch, load, offerVersion)
+	if !ok {
 		return
 	}
 
 	offer, err := h.Svc.Decline(r.Context(), id)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, err.Error())
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, offer); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode offer", "offer_id", id, "err", err)
+	}
+}
+
+// List returns offers, newest first, one page at a time.
+// Query: status, product, application_id, from/to (creation time), limit, cursor.
+// 200: JSON page; 304: unchanged since If-None-Match ETag; 400: bad filter or cursor; 403: not staff; 500: internal error.
+func (h *OfferHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, created, ok := listParams(w, r, q)
+	if !ok {
+		return
+	}
+
+	filter := core.OfferFilter{
+		Status:        core.OfferStatus(q.Get("status")),
+		ProductSlug:   q.Get("product"),
+		ApplicationID: q.Get("application_id"),
+		Created:       created,
+	}
+
+	offers, err := h.Svc.List(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to list offers")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, offers); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode offers", "err", err)
+	}
+}
+
+// History returns the audit trail of an offer, oldest first.
+// 200: JSON page; 400: missing ID or bad cursor; 403: not staff; 404: not found; 500: internal error.
+func (h *OfferHandler) History(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "offer_id")
+	if id == "" {
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Offer ID", "Path parameter offer_id is required.")
+		return
+	}
+	page, _, ok := listParams(w, r, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	history, err := h.Svc.History(r.Context(), id, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to get offer history")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(offer); err != nil {
-		h.Log.Error("failed to encode offer", "offer_id", id, "err", err)
+	if err := writeResource(w, r, http.StatusOK, history); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode history", "offer_id", id, "err", err)
 	}
 }
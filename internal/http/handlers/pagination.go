@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/pkg/problem"
+)
+
+// listParams reads the query parameters shared by every list endpoint:
+// limit, cursor and the from/to range (RFC3339 or YYYY-MM-DD). It writes
+// 400 and returns false when one is malformed.
+func listParams(w http.ResponseWriter, r *http.Request, q url.Values) (core.PageRequest, core.TimeRange, bool) {
+	var page core.PageRequest
+	var rng core.TimeRange
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			problem.Write(w, r, http.StatusBadRequest, "invalid_filter", "Invalid Filter", "limit must be a positive integer.")
+			return page, rng, false
+		}
+		page.Limit = limit
+	}
+	page.Cursor = q.Get("cursor")
+
+	var err error
+	if rng.From, err = parseTimeParam(q.Get("from")); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_filter", "Invalid Filter", "from must be an RFC3339 time or a YYYY-MM-DD date.")
+		return page, rng, false
+	}
+	if rng.To, err = parseTimeParam(q.Get("to")); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_filter", "Invalid Filter", "to must be an RFC3339 time or a YYYY-MM-DD date.")
+		return page, rng, false
+	}
+	return page, rng, true
+}
+
+// parseTimeParam accepts an RFC3339 time or a date (midnight UTC).
+func parseTimeParam(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, v)
+}
+
+// offsetList is the v1 body of GET /policies, from before cursors.
+type offsetList[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// offsetParam reads the v1 offset parameter. It writes 400 and returns
+// false when it is malformed.
+func offsetParam(w http.ResponseWriter, r *http.Request, q url.Values) (int, bool) {
+	v := q.Get("offset")
+	if v == "" {
+		return 0, true
+	}
+	offset, err := strconv.Atoi(v)
+	if err != nil || offset < 0 {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_filter", "Invalid Filter", "offset must be a non-negative integer.")
+		return 0, false
+	}
+	return offset, true
+}
+
+// offsetPage reads page.Limit items after skipping offset, starting at
+// page.Cursor, and counts every item from there on as the total. That
+// reads the whole rest of the list, which is why only v1 does it.
+func offsetPage[T any](page core.PageRequest, offset int, list func(core.PageRequest) (core.Page[T], error)) (offsetList[T], error) {
+	out := offsetList[T]{Items: []T{}, Limit: page.Normalize(core.DefaultPageLimit, core.MaxPageLimit).Limit, Offset: offset}
+	cursor, more := page.Cursor, true
+
+	// walk reads n items, or all of them when n < 0. It never asks for more
+	// than it needs, so cursor ends up right after the last item read.
+	walk := func(n int, visit func([]T)) error {
+		for read := 0; more && (n < 0 || read < n); {
+			size := core.MaxPageLimit
+			if n >= 0 {
+				size = min(size, n-read)
+			}
+			p, err := list(core.PageRequest{Limit: size, Cursor: cursor})
+			if err != nil {
+				return err
+			}
+			visit(p.Items)
+			read += len(p.Items)
+			cursor, more = p.NextCursor, p.NextCursor != ""
+		}
+		return nil
+	}
+
+	count := func(items []T) { out.Total += len(items) }
+	if err := walk(offset, count); err != nil {
+		return out, err
+	}
+	if err := walk(out.Limit, func(items []T) {
+		out.Items = append(out.Items, items...)
+		count(items)
+	}); err != nil {
+		return out, err
+	}
+	if more {
+		out.NextCursor = cursor
+	}
+	return out, walk(-1, count)
+}
+
+// setNextLink points a Link header at the page after this one, for v1
+// lists whose body is a bare array.
+func setNextLink(w http.ResponseWriter, r *http.Request, cursor string) {
+	if cursor == "" {
+		return
+	}
+	next := *r.URL
+	q := next.Query()
+	q.Set("cursor", cursor)
+	next.RawQuery = q.Encode()
+	w.Header().Add("Link", "<"+next.RequestURI()+">; rel=\"next\"")
+}
@@ -1,10 +1,8 @@
 package handlers
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
@@ -24,74 +22,99 @@ func NewPolicyHandler(svc core.PolicyService, log *slog.Logger) *PolicyHandler {
 func (h *PolicyHandler) Mount(r chi.Router) {
 	r.Route("/policies", func(r chi.Router) {
 		r.Get("/{policy_number}", h.Get)
+		r.Get("/{policy_number}/history", h.History)
 		r.Get("/", h.List)
 	})
 }
 
 // Get retrieves a policy by its number.
-// 200: JSON; 400: missing number; 404: not found; 500: internal error.
+// 200: JSON; 304: unchanged since If-None-Match ETag; 400: missing number; 403: another applicant's; 404: not found; 500: internal error.
 func (h *PolicyHandler) Get(w http.ResponseWriter, r *http.Request) {
 	number := chi.URLParam(r, "policy_number")
 	if number == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Policy Number", "Path parameter policy_number is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Policy Number", "Path parameter policy_number is required.")
 		return
 	}
 
 	policy, err := h.Svc.GetByNumber(r.Context(), number)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to get policy")
+		writeError(w, r, h.Log, err, "Failed to get policy")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(policy); err != nil {
-		h.Log.Error("failed to encode policy", "policy_number", number, "err", err)
+	if err := writeResource(w, r, http.StatusOK, policy); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode policy", "policy_number", number, "err", err)
 	}
 }
 
-// List returns policies with optional filtering and pagination.
-// 200: JSON; 500: internal error.
+// List returns policies, newest first, one page at a time.
+// Query: status, product, email, application_id, from/to (issue time), limit, cursor; v1 also offset.
+// 200: JSON page (v1: with total, limit and offset); 304: unchanged since If-None-Match ETag; 400: bad filter or cursor; 403: not staff; 500: internal error.
 func (h *PolicyHandler) List(w http.ResponseWriter, r *http.Request) {
-	// Parse query parameters
-	filter := core.PolicyFilter{
-		ApplicationID: r.URL.Query().Get("application_id"),
-	}
-	if status := r.URL.Query().Get("status"); status != "" {
-		filter.Status = core.PolicyStatus(status)
+	q := r.URL.Query()
+	page, issued, ok := listParams(w, r, q)
+	if !ok {
+		return
 	}
 
-	limit := 20
-	if l := r.URL.Query().Get("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
-			limit = parsed
-		}
+	filter := core.PolicyFilter{
+		ApplicationID: q.Get("application_id"),
+		Status:        core.PolicyStatus(q.Get("status")),
+		ProductSlug:   q.Get("product"),
+		InsuredEmail:  q.Get("email"),
+		Issued:        issued,
 	}
 
-	offset := 0
-	if o := r.URL.Query().Get("offset"); o != "" {
-		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
-			offset = parsed
+	var policies any
+	var err error
+	if apiVersion(r) == "v1" {
+		// v1 keeps its {items, total, limit, offset} body; offset skips
+		// from the cursor, or from the start without one
+		offset, ok := offsetParam(w, r, q)
+		if !ok {
+			return
 		}
+		policies, err = offsetPage(page, offset, func(p core.PageRequest) (core.Page[core.Policy], error) {
+			return h.Svc.List(r.Context(), filter, p)
+		})
+	} else {
+		policies, err = h.Svc.List(r.Context(), filter, page)
 	}
-
-	policies, total, err := h.Svc.List(r.Context(), filter, limit, offset)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to list policies")
+		writeError(w, r, h.Log, err, "Failed to list policies")
 		return
 	}
 
-	// Return empty array instead of null
-	if policies == nil {
-		policies = []core.Policy{}
+	if err := writeResource(w, r, http.StatusOK, policies); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode policies", "err", err)
+	}
+}
+
+// History returns the audit trail of a policy, by its number, oldest first.
+// 200: JSON page; 400: missing number or bad cursor; 403: not staff; 404: not found; 500: internal error.
+func (h *PolicyHandler) History(w http.ResponseWriter, r *http.Request) {
+	number := chi.URLParam(r, "policy_number")
+	if number == "" {
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Policy Number", "Path parameter policy_number is required.")
+		return
+	}
+	page, _, ok := listParams(w, r, r.URL.Query())
+	if !ok {
+		return
 	}
 
-	response := map[string]interface{}{
-		"items": policies,
-		"total": total,
-		"limit": limit,
-		"offset": offset,
+	policy, err := h.Svc.GetByNumber(r.Context(), number)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to get policy")
+		return
+	}
+	history, err := h.Svc.History(r.Context(), policy.ID, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to get policy history")
+		return
 	}
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.Log.Error("failed to encode policies", "err", err)
+	if err := writeResource(w, r, http.StatusOK, history); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode history", "policy_number", number, "err", err)
 	}
 }
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"log/slog"
 	"net/http"
 
@@ -36,32 +35,32 @@ func (h *ProductHandler) Mount(r chi.Router) {
 func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 	products, err := h.Repo.List(r.Context())
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to list products")
+		writeError(w, r, h.Log, err, "Failed to list products")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(products); err != nil {
-		h.Log.Error("failed to encode products list", "err", err)
+	if err := writeResource(w, r, http.StatusOK, products); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode products list", "err", err)
 	}
 }
 
 // Get returns a single product by slug.
-// 200: JSON object; 400: missing slug; 404: not found; 500: internal error.
+// 200: JSON object; 304: unchanged since If-None-Match ETag; 400: missing slug; 404: not found; 500: internal error.
 func (h *ProductHandler) Get(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "product_slug")
 	if slug == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Product Slug",
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Product Slug",
 			"The URL must include a product_slug path parameter.")
 		return
 	}
 
 	product, err := h.Repo.GetBySlug(r.Context(), slug)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to retrieve product "+slug)
+		writeError(w, r, h.Log, err, "Failed to retrieve product "+slug)
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(product); err != nil {
-		h.Log.Error("failed to encode product", "product_slug", slug, "err", err)
+	if err := writeResource(w, r, http.StatusOK, product); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode product", "product_slug", slug, "err", err)
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/chi/v5"
 
 	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/logging"
 	"github.com/MrKriegler/go-insurance/pkg/problem"
 )
 
@@ -23,6 +24,7 @@ func NewQuoteHandler(svc core.QuoteService, repo core.QuoteRepo, log *slog.Logge
 
 func (h *QuoteHandler) Mount(r chi.Router) {
 	r.Route("/quotes", func(r chi.Router) {
+		r.Get("/", h.List)          // GET  /quotes  (staff)
 		r.Post("/", h.Create)       // POST /quotes  (price + persist)
 		r.Get("/{quote_id}", h.Get) // GET  /quotes/{quote_id}
 	})
@@ -33,38 +35,65 @@ func (h *QuoteHandler) Mount(r chi.Router) {
 func (h *QuoteHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var in core.QuoteInput
 	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		problem.Write(w, http.StatusBadRequest, "Invalid JSON", "Body could not be decoded.")
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
 		return
 	}
 
 	quote, err := h.Svc.Price(r.Context(), in)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to price quote")
+		writeError(w, r, h.Log, err, "Failed to price quote")
 		return
 	}
+	logging.Add(r.Context(), "quote_id", quote.ID)
 
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(quote); err != nil {
-		h.Log.Error("failed to encode quote", "err", err)
+	if err := writeResource(w, r, http.StatusCreated, quote); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode quote", "err", err)
 	}
 }
 
 // Get retrieves a quote by its ULID.
-// 200: JSON; 400: missing ID; 404: not found; 500: internal error.
+// 200: JSON; 304: unchanged since If-None-Match ETag; 400: missing ID; 404: not found; 500: internal error.
 func (h *QuoteHandler) Get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "quote_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Quote ID", "Path parameter quote_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Quote ID", "Path parameter quote_id is required.")
 		return
 	}
 
 	quote, err := h.Repo.Get(r.Context(), id)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to get quote")
+		writeError(w, r, h.Log, err, "Failed to get quote")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(quote); err != nil {
-		h.Log.Error("failed to encode quote", "quote_id", id, "err", err)
+	if err := writeResource(w, r, http.StatusOK, quote); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode quote", "quote_id", id, "err", err)
+	}
+}
+
+// List returns quotes, newest first, one page at a time.
+// Query: status, product, from/to (creation time), limit, cursor.
+// 200: JSON page; 304: unchanged since If-None-Match ETag; 400: bad filter or cursor; 403: not staff; 500: internal error.
+func (h *QuoteHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, created, ok := listParams(w, r, q)
+	if !ok {
+		return
+	}
+
+	filter := core.QuoteFilter{
+		Status:      core.QuoteStatus(q.Get("status")),
+		ProductSlug: q.Get("product"),
+		Created:     created,
+	}
+
+	quotes, err := h.Svc.List(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to list quotes")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, quotes); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode quotes", "err", err)
 	}
 }
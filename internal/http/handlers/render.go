@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+)
+
+// Renderer turns a response value into the JSON shape of one API version.
+// Values it has no special shape for are returned unchanged.
+type Renderer func(v any) any
+
+type rendererKey struct{}
+
+// UseRenderer makes writeResource, and the ETags it issues, use render for
+// every response of the routes it wraps.
+func UseRenderer(render Renderer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), rendererKey{}, render)))
+		})
+	}
+}
+
+// render shapes v for the request's API version.
+func render(r *http.Request, v any) any {
+	if render, ok := r.Context().Value(rendererKey{}).(Renderer); ok {
+		return render(v)
+	}
+	return v
+}
+
+type versionKey struct{}
+
+// UseVersion records name as the API version of the routes it wraps, for
+// the few handlers whose v1 behaviour differs by more than shape.
+func UseVersion(name string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), versionKey{}, name)))
+		})
+	}
+}
+
+// apiVersion is the API version the request came in on, e.g. "v1", or
+// empty outside a versioned router.
+func apiVersion(r *http.Request) string {
+	v, _ := r.Context().Value(versionKey{}).(string)
+	return v
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type ScheduledRunHandler struct {
+	Svc core.MaintenanceService
+	Log *slog.Logger
+}
+
+func NewScheduledRunHandler(svc core.MaintenanceService, log *slog.Logger) *ScheduledRunHandler {
+	return &ScheduledRunHandler{Svc: svc, Log: log}
+}
+
+func (h *ScheduledRunHandler) Mount(r chi.Router) {
+	r.Get("/admin/scheduled-runs", h.List)
+}
+
+// List returns runs of scheduled tasks, newest first. Filters: task, status.
+// 200: JSON page; 400: bad filter or cursor; 403: not an admin; 500: internal error.
+func (h *ScheduledRunHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, _, ok := listParams(w, r, q)
+	if !ok {
+		return
+	}
+
+	filter := core.ScheduledRunFilter{
+		Task:   q.Get("task"),
+		Status: core.ScheduledRunStatus(q.Get("status")),
+	}
+
+	runs, err := h.Svc.Runs(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to list scheduled runs")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, runs); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode scheduled runs", "err", err)
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type SearchHandler struct {
+	Svc core.SearchService
+	Log *slog.Logger
+}
+
+func NewSearchHandler(svc core.SearchService, log *slog.Logger) *SearchHandler {
+	return &SearchHandler{Svc: svc, Log: log}
+}
+
+func (h *SearchHandler) Mount(r chi.Router) {
+	r.Get("/search", h.Search)
+}
+
+// Search finds applicants, applications and policies by name, email,
+// policy number prefix or date of birth.
+// Query: q (required), type (applicant, application, policy; comma-separated), limit, cursor.
+// 200: JSON page, best match first; 400: missing or bad query; 403: not staff; 500: internal error.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, _, ok := listParams(w, r, q)
+	if !ok {
+		return
+	}
+
+	var kinds []core.SearchKind
+	for _, v := range q["type"] {
+		for _, k := range strings.Split(v, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				kinds = append(kinds, core.SearchKind(k))
+			}
+		}
+	}
+
+	hits, err := h.Svc.Search(r.Context(), q.Get("q"), kinds, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to search")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, hits); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode search results", "err", err)
+	}
+}
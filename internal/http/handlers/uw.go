@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -14,6 +16,9 @@ import (
 type UWHandler struct {
 	Svc core.UnderwritingService
 	Log *slog.Logger
+
+	// RequireIfMatch rejects state changes without an If-Match header (428)
+	RequireIfMatch bool
 }
 
 func NewUWHandler(svc core.UnderwritingService, log *slog.Logger) *UWHandler {
@@ -23,72 +28,264 @@ func NewUWHandler(svc core.UnderwritingService, log *slog.Logger) *UWHandler {
 func (h *UWHandler) Mount(r chi.Router) {
 	r.Route("/underwriting", func(r chi.Router) {
 		r.Get("/cases/{case_id}", h.GetCase)
-		r.Get("/cases", h.ListReferred)
+		r.Get("/cases", h.ListCases)
+		r.Post("/cases/{case_id}:claim", h.Claim)
+		r.Post("/cases/{case_id}:assign", h.Assign)
 		r.Post("/cases/{case_id}:decide", h.Decide)
+		r.Post("/cases/{case_id}:confirm", h.Confirm)
+		r.Get("/cases/{case_id}/history", h.History)
 	})
 }
 
 // GetCase retrieves an underwriting case by ID.
-// 200: JSON; 400: missing ID; 404: not found; 500: internal error.
+// 200: JSON; 304: unchanged since If-None-Match ETag; 400: missing ID; 403: not an underwriter; 404: not found; 500: internal error.
 func (h *UWHandler) GetCase(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "case_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Case ID", "Path parameter case_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Case ID", "Path parameter case_id is required.")
 		return
 	}
 
 	uwCase, err := h.Svc.GetCase(r.Context(), id)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to get underwriting case")
+		writeError(w, r, h.Log, err, "Failed to get underwriting case")
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, uwCase); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode uw case", "case_id", id, "err", err)
+	}
+}
+
+// uwCaseView adds queue tracking computed at read time.
+type uwCaseView struct {
+	core.UnderwritingCase
+	AgeSeconds  int64 `json:"age_seconds"`
+	SLABreached bool  `json:"sla_breached"`
+}
+
+// ListCases returns the underwriting work queue.
+// Query: assignee (or "-" for unassigned), status (default referred), min_age/max_age (e.g. 24h) or from/to, limit, cursor.
+// 200: JSON page, oldest first (v1: an array, with a Link to the next page); 304: unchanged since If-None-Match ETag; 400: bad filter or cursor; 403: not an underwriter; 500: internal error.
+func (h *UWHandler) ListCases(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	now := time.Now()
+
+	filter := core.UWCaseFilter{
+		Assignee: q.Get("assignee"),
+		Decision: core.UWDecisionReferred,
+	}
+	if status, ok := q["status"]; ok {
+		filter.Decision = core.UWDecision(status[0])
+	}
+	if v := q.Get("min_age"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			problem.Write(w, r, http.StatusBadRequest, "invalid_filter", "Invalid Filter", "min_age must be a duration such as 24h.")
+			return
+		}
+		filter.CreatedBefore = now.Add(-d)
+	}
+	if v := q.Get("max_age"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			problem.Write(w, r, http.StatusBadRequest, "invalid_filter", "Invalid Filter", "max_age must be a duration such as 72h.")
+			return
+		}
+		filter.CreatedAfter = now.Add(-d)
+	}
+
+	page, created, ok := listParams(w, r, q)
+	if !ok {
+		return
+	}
+	if !created.From.IsZero() {
+		filter.CreatedAfter = created.From
+	}
+	if !created.To.IsZero() {
+		filter.CreatedBefore = created.To
+	}
+
+	cases, err := h.Svc.ListCases(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to list underwriting cases")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(uwCase); err != nil {
-		h.Log.Error("failed to encode uw case", "case_id", id, "err", err)
+	views := core.Page[uwCaseView]{
+		Items:      make([]uwCaseView, len(cases.Items)),
+		NextCursor: cases.NextCursor,
+	}
+	for i, c := range cases.Items {
+		views.Items[i] = uwCaseView{
+			UnderwritingCase: c,
+			AgeSeconds:       int64(c.Age(now).Seconds()),
+			SLABreached:      c.SLABreached(now),
+		}
+	}
+
+	var body any = views
+	if apiVersion(r) == "v1" {
+		// v1 sends a bare array; the next page is linked instead
+		setNextLink(w, r, views.NextCursor)
+		body = views.Items
+	}
+	if err := writeResource(w, r, http.StatusOK, body); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode uw cases", "err", err)
 	}
 }
 
-// ListReferred returns cases awaiting manual review.
-// 200: JSON array; 500: internal error.
-func (h *UWHandler) ListReferred(w http.ResponseWriter, r *http.Request) {
-	cases, err := h.Svc.ListReferred(r.Context(), 50)
+// Claim assigns an unclaimed referred case to the calling underwriter.
+// 200: JSON; 403: not an underwriter; 404: not found; 409: held by someone else or decided; 412: ETag mismatch; 428: If-Match required; 500: internal error.
+func (h *UWHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "case_id")
+	if id == "" {
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Case ID", "Path parameter case_id is required.")
+		return
+	}
+
+	load := func(ctx context.Context) (core.UnderwritingCase, error) { return h.Svc.GetCase(ctx, id) }
+	r, ok := ifMatch(w, r, h.Log, h.RequireIfMatch, load, caseVersion)
+	if !ok {
+		return
+	}
+
+	uwCase, err := h.Svc.ClaimCase(r.Context(), id)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, "Failed to list referred cases")
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, uwCase); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode uw case", "case_id", id, "err", err)
+	}
+}
+
+// Assign reassigns a referred case to another underwriter.
+// 200: JSON; 400: missing assignee; 403: not an underwriter; 404: not found; 409: changed concurrently or decided; 412: ETag mismatch; 428: If-Match required; 500: internal error.
+func (h *UWHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "case_id")
+	if id == "" {
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Case ID", "Path parameter case_id is required.")
+		return
+	}
+
+	load := func(ctx context.Context) (core.UnderwritingCase, error) { return h.Svc.GetCase(ctx, id) }
+	r, ok := ifMatch(w, r, h.Log, h.RequireIfMatch, load, caseVersion)
+	if !ok {
 		return
 	}
 
-	// Return empty array instead of null
-	if cases == nil {
-		cases = []core.UnderwritingCase{}
+	var input struct {
+		Assignee string `json:"assignee"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
+		return
 	}
 
-	if err := json.NewEncoder(w).Encode(cases); err != nil {
-		h.Log.Error("failed to encode uw cases", "err", err)
+	uwCase, err := h.Svc.AssignCase(r.Context(), id, input.Assignee)
+	if err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, uwCase); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode uw case", "case_id", id, "err", err)
 	}
 }
 
-// Decide makes a manual underwriting decision.
-// 200: JSON; 400: bad JSON/validation; 404: not found; 409: already decided; 500: internal error.
+// Decide makes a manual underwriting decision, claiming the case if it is unassigned.
+// Approvals above the underwriter's authority are parked for a second underwriter.
+// 200: JSON; 202: awaiting approval; 400: bad JSON/validation; 403: not an underwriter; 404: not found;
+// 409: already decided or held by someone else; 412: ETag mismatch; 428: If-Match required; 500: internal error.
 func (h *UWHandler) Decide(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "case_id")
 	if id == "" {
-		problem.Write(w, http.StatusBadRequest, "Missing Case ID", "Path parameter case_id is required.")
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Case ID", "Path parameter case_id is required.")
+		return
+	}
+
+	load := func(ctx context.Context) (core.UnderwritingCase, error) { return h.Svc.GetCase(ctx, id) }
+	r, ok := ifMatch(w, r, h.Log, h.RequireIfMatch, load, caseVersion)
+	if !ok {
 		return
 	}
 
 	var input core.UWDecisionInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		problem.Write(w, http.StatusBadRequest, "Invalid JSON", "Body could not be decoded.")
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
 		return
 	}
 
 	uwCase, err := h.Svc.MakeDecision(r.Context(), id, input)
 	if err != nil {
-		writeError(r.Context(), h.Log, w, err, err.Error())
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	status := http.StatusOK
+	if uwCase.Decision == core.UWDecisionPendingApproval {
+		status = http.StatusAccepted
+	}
+	if err := writeResource(w, r, status, uwCase); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode uw case", "case_id", id, "err", err)
+	}
+}
+
+// Confirm confirms or rejects a decision awaiting four-eyes approval.
+// 200: JSON; 400: bad JSON/validation; 403: not an underwriter, same underwriter or no authority;
+// 404: not found; 409: not awaiting approval; 412: ETag mismatch; 428: If-Match required; 500: internal error.
+func (h *UWHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "case_id")
+	if id == "" {
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Case ID", "Path parameter case_id is required.")
+		return
+	}
+
+	load := func(ctx context.Context) (core.UnderwritingCase, error) { return h.Svc.GetCase(ctx, id) }
+	r, ok := ifMatch(w, r, h.Log, h.RequireIfMatch, load, caseVersion)
+	if !ok {
+		return
+	}
+
+	var input core.UWConfirmInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_json", "Invalid JSON", "Body could not be decoded.")
+		return
+	}
+
+	uwCase, err := h.Svc.ConfirmDecision(r.Context(), id, input)
+	if err != nil {
+		writeError(w, r, h.Log, err, err.Error())
+		return
+	}
+
+	if err := writeResource(w, r, http.StatusOK, uwCase); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode uw case", "case_id", id, "err", err)
+	}
+}
+
+// History returns the audit trail of an underwriting case, oldest first.
+// 200: JSON page; 400: missing ID or bad cursor; 403: not an underwriter; 404: not found; 500: internal error.
+func (h *UWHandler) History(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "case_id")
+	if id == "" {
+		problem.Write(w, r, http.StatusBadRequest, "missing_parameter", "Missing Case ID", "Path parameter case_id is required.")
+		return
+	}
+	page, _, ok := listParams(w, r, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	history, err := h.Svc.History(r.Context(), id, page)
+	if err != nil {
+		writeError(w, r, h.Log, err, "Failed to get underwriting case history")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(uwCase); err != nil {
-		h.Log.Error("failed to encode uw case", "case_id", id, "err", err)
+	if err := writeResource(w, r, http.StatusOK, history); err != nil {
+		h.Log.ErrorContext(r.Context(), "failed to encode history", "case_id", id, "err", err)
 	}
 }
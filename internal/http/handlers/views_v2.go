@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// v2 sends money as exact decimal strings with a currency instead of JSON
+// numbers, and groups coverage and premium into nested objects. Everything
+// else has the same shape as v1.
+
+// currencyV2 is the currency of every amount; products are priced in dollars.
+const currencyV2 = "USD"
+
+type moneyV2 struct {
+	Amount   string `json:"amount"` // Decimal, two places, e.g. "42.50"
+	Currency string `json:"currency"`
+}
+
+func centsV2(cents int64) moneyV2 {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	frac := strconv.FormatInt(cents%100, 10)
+	if len(frac) == 1 {
+		frac = "0" + frac
+	}
+	return moneyV2{Amount: sign + strconv.FormatInt(cents/100, 10) + "." + frac, Currency: currencyV2}
+}
+
+type coverageV2 struct {
+	Amount    moneyV2 `json:"amount"`
+	TermYears int     `json:"term_years"`
+}
+
+type premiumV2 struct {
+	Monthly moneyV2 `json:"monthly"`
+	Annual  moneyV2 `json:"annual"`
+	Term    moneyV2 `json:"term_total"` // Annual premium times the term
+}
+
+func coverageAndPremiumV2(coverage int64, termYears int, monthly float64) (coverageV2, premiumV2) {
+	cents := int64(math.Round(monthly * 100))
+	return coverageV2{Amount: centsV2(coverage * 100), TermYears: termYears},
+		premiumV2{
+			Monthly: centsV2(cents),
+			Annual:  centsV2(cents * 12),
+			Term:    centsV2(cents * 12 * int64(termYears)),
+		}
+}
+
+type quoteV2 struct {
+	ID          string           `json:"id"`
+	ProductID   string           `json:"product_id"`
+	ProductSlug string           `json:"product_slug"`
+	Coverage    coverageV2       `json:"coverage"`
+	Premium     premiumV2        `json:"premium"`
+	Status      core.QuoteStatus `json:"status"`
+	CreatedAt   time.Time        `json:"created_at"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+}
+
+func quoteViewV2(q core.Quote) quoteV2 {
+	coverage, premium := coverageAndPremiumV2(q.CoverageAmount, q.TermYears, q.MonthlyPremium)
+	return quoteV2{
+		ID:          q.ID,
+		ProductID:   q.ProductID,
+		ProductSlug: q.ProductSlug,
+		Coverage:    coverage,
+		Premium:     premium,
+		Status:      q.Status,
+		CreatedAt:   q.CreatedAt,
+		ExpiresAt:   q.ExpiresAt,
+	}
+}
+
+type offerV2 struct {
+	ID            string           `json:"id"`
+	ApplicationID string           `json:"application_id"`
+	ProductSlug   string           `json:"product_slug"`
+	Coverage      coverageV2       `json:"coverage"`
+	Premium       premiumV2        `json:"premium"`
+	Status        core.OfferStatus `json:"status"`
+	CreatedAt     time.Time        `json:"created_at"`
+	ExpiresAt     time.Time        `json:"expires_at"`
+	AcceptedAt    *time.Time       `json:"accepted_at,omitempty"`
+	DeclinedAt    *time.Time       `json:"declined_at,omitempty"`
+}
+
+func offerViewV2(o core.Offer) offerV2 {
+	coverage, premium := coverageAndPremiumV2(o.CoverageAmount, o.TermYears, o.MonthlyPremium)
+	return offerV2{
+		ID:            o.ID,
+		ApplicationID: o.ApplicationID,
+		ProductSlug:   o.ProductSlug,
+		Coverage:      coverage,
+		Premium:       premium,
+		Status:        o.Status,
+		CreatedAt:     o.CreatedAt,
+		ExpiresAt:     o.ExpiresAt,
+		AcceptedAt:    o.AcceptedAt,
+		DeclinedAt:    o.DeclinedAt,
+	}
+}
+
+type policyV2 struct {
+	ID            string            `json:"id"`
+	Number        string            `json:"number"`
+	ApplicationID string            `json:"application_id"`
+	OfferID       string            `json:"offer_id"`
+	ProductSlug   string            `json:"product_slug"`
+	Coverage      coverageV2        `json:"coverage"`
+	Premium       premiumV2         `json:"premium"`
+	Insured       core.Applicant    `json:"insured"`
+	Status        core.PolicyStatus `json:"status"`
+	EffectiveDate time.Time         `json:"effective_date"`
+	ExpiryDate    time.Time         `json:"expiry_date"`
+	IssuedAt      time.Time         `json:"issued_at"`
+}
+
+func policyViewV2(p core.Policy) policyV2 {
+	coverage, premium := coverageAndPremiumV2(p.CoverageAmount, p.TermYears, p.MonthlyPremium)
+	return policyV2{
+		ID:            p.ID,
+		Number:        p.Number,
+		ApplicationID: p.ApplicationID,
+		OfferID:       p.OfferID,
+		ProductSlug:   p.ProductSlug,
+		Coverage:      coverage,
+		Premium:       premium,
+		Insured:       p.Insured,
+		Status:        p.Status,
+		EffectiveDate: p.EffectiveDate,
+		ExpiryDate:    p.ExpiryDate,
+		IssuedAt:      p.IssuedAt,
+	}
+}
+
+func pageViewV2[T, V any](p core.Page[T], view func(T) V) core.Page[V] {
+	items := make([]V, len(p.Items))
+	for i, item := range p.Items {
+		items[i] = view(item)
+	}
+	return core.Page[V]{Items: items, NextCursor: p.NextCursor}
+}
+
+// RenderV2 shapes responses for /api/v2.
+func RenderV2(v any) any {
+	switch v := v.(type) {
+	case core.Quote:
+		return quoteViewV2(v)
+	case core.Page[core.Quote]:
+		return pageViewV2(v, quoteViewV2)
+	case core.Offer:
+		return offerViewV2(v)
+	case core.Page[core.Offer]:
+		return pageViewV2(v, offerViewV2)
+	case core.Policy:
+		return policyViewV2(v)
+	case core.Page[core.Policy]:
+		return pageViewV2(v, policyViewV2)
+	}
+	return v
+}
@@ -1,9 +1,15 @@
+// Package health serves the liveness, startup and readiness probes, and a
+// JSON report of the replica's dependencies and workers.
 package health
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -13,31 +19,222 @@ type Pinger interface {
 	Ping(ctx context.Context) error
 }
 
-// New builds a health check HTTP handler with liveness and readiness endpoints.
-func New(log *slog.Logger, p Pinger, opTimeout time.Duration) http.Handler {
+// Ping checks that p is reachable.
+func Ping(p Pinger) func(ctx context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) {
+		return nil, p.Ping(ctx)
+	}
+}
+
+// Status is the state of a component or of the whole replica.
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded" // A non-critical check is failing; still ready
+	StatusDown     Status = "down"
+	StatusStarting Status = "starting"
+	StatusDraining Status = "draining"
+)
+
+// Check is one component of the report.
+type Check struct {
+	Name string
+	// Critical checks take the replica out of service while they fail;
+	// others only degrade the report.
+	Critical bool
+	// Every reuses the last result for this long, for checks too costly to
+	// run on every probe. Zero runs the check each time.
+	Every time.Duration
+	// Run returns details to report, such as a worker's backlog, and an
+	// error if the component is unhealthy.
+	Run func(ctx context.Context) (any, error)
+}
+
+// Component is the result of a check.
+type Component struct {
+	Status    Status    `json:"status"`
+	Critical  bool      `json:"critical"`
+	Details   any       `json:"details,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report is the readiness of the replica.
+type Report struct {
+	Status     Status               `json:"status"`
+	Pending    []string             `json:"pending,omitempty"` // Startup tasks not yet done
+	Components map[string]Component `json:"components,omitempty"`
+}
+
+// Health tracks the replica's startup and shutdown and runs its checks.
+type Health struct {
+	log      *slog.Logger
+	timeout  time.Duration
+	checks   []*check
+	mu       sync.Mutex
+	pending  []string
+	draining atomic.Bool
+}
+
+type check struct {
+	Check
+	mu   sync.Mutex
+	last Component
+}
+
+// New creates a Health whose checks each get timeout to run.
+func New(log *slog.Logger, timeout time.Duration) *Health {
+	return &Health{log: log, timeout: timeout}
+}
+
+// Add registers checks. Add them before serving.
+func (h *Health) Add(checks ...Check) {
+	for _, c := range checks {
+		h.checks = append(h.checks, &check{Check: c})
+	}
+}
+
+// Starting registers startup work that must finish before the replica
+// takes traffic, e.g. building the search index. Call the returned
+// function when it has, whether or not it succeeded.
+func (h *Health) Starting(name string) func() {
+	h.mu.Lock()
+	h.pending = append(h.pending, name)
+	h.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if i := slices.Index(h.pending, name); i >= 0 {
+				h.pending = slices.Delete(h.pending, i, i+1)
+			}
+		})
+	}
+}
+
+// Drain takes the replica out of service: readiness fails from now on, so
+// load balancers stop sending it requests while it shuts down.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+// Report runs the checks, in parallel, and sums them up. The replica is
+// down if any critical check fails, and degraded if any other one does.
+func (h *Health) Report(ctx context.Context) Report {
+	report := Report{Status: StatusUp, Components: make(map[string]Component, len(h.checks))}
+
+	results := make([]Component, len(h.checks))
+	var wg sync.WaitGroup
+	for i, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, h.timeout)
+		}()
+	}
+	wg.Wait()
+
+	for i, c := range h.checks {
+		result := results[i]
+		report.Components[c.Name] = result
+		switch {
+		case result.Status == StatusUp:
+		case c.Critical:
+			report.Status = StatusDown
+		case report.Status == StatusUp:
+			report.Status = StatusDegraded
+		}
+	}
+
+	h.mu.Lock()
+	report.Pending = slices.Clone(h.pending)
+	h.mu.Unlock()
+	switch {
+	case h.draining.Load():
+		report.Status = StatusDraining
+	case len(report.Pending) > 0:
+		report.Status = StatusStarting
+	}
+	return report
+}
+
+func (c *check) run(ctx context.Context, timeout time.Duration) Component {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Every > 0 && time.Since(c.last.CheckedAt) < c.Every {
+		return c.last
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	details, err := c.Run(ctx)
+	c.last = Component{Status: StatusUp, Critical: c.Critical, Details: details, CheckedAt: time.Now().UTC()}
+	if err != nil {
+		c.last.Status = StatusDown
+		c.last.Error = err.Error()
+	}
+	return c.last
+}
+
+// Handler serves the probes:
+//
+//	/health    liveness: the process is up
+//	/startupz  startup: work registered with Starting is done
+//	/readyz    readiness: started, not draining and every critical check
+//	           passes; the body is the full Report
+func (h *Health) Handler() http.Handler {
 	r := chi.NewRouter()
 
-	// Liveness: process is up
 	r.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
 
-	// Readiness: dependencies are reachable
-	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {
-		ctx, cancel := context.WithTimeout(r.Context(), opTimeout)
-		defer cancel()
+	r.Get("/startupz", func(w http.ResponseWriter, _ *http.Request) {
+		h.mu.Lock()
+		report := Report{Status: StatusUp, Pending: slices.Clone(h.pending)}
+		h.mu.Unlock()
+		if len(report.Pending) > 0 {
+			report.Status = StatusStarting
+		}
+		writeReport(w, report)
+	})
 
-		if err := p.Ping(ctx); err != nil {
-			if log != nil {
-				log.Warn("readiness failed", "err", err)
-			}
-			http.Error(w, "not ready", http.StatusServiceUnavailable)
-			return
+	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		report := h.Report(r.Context())
+		if report.Status != StatusUp && report.Status != StatusDegraded && h.log != nil {
+			h.log.WarnContext(r.Context(), "readiness failed", "status", report.Status, "failing", failing(report))
 		}
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ready"))
+		writeReport(w, report)
 	})
 
 	return r
 }
+
+// writeReport writes report with 200 if the replica can take traffic and
+// 503 if not.
+func writeReport(w http.ResponseWriter, report Report) {
+	status := http.StatusOK
+	if report.Status != StatusUp && report.Status != StatusDegraded {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// failing lists the failing components of report, for logging.
+func failing(report Report) []string {
+	var names []string
+	for name, c := range report.Components {
+		if c.Status != StatusUp {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
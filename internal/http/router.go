@@ -2,6 +2,7 @@ package transporthttp
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
@@ -12,12 +13,16 @@ import (
 // Deps bundles feature handlers that implement handlers.Mountable.
 type Deps struct {
 	Mounts []handlers.Mountable
+	Render handlers.Renderer // Optional: reshapes responses for this API version
 }
 
 func NewRouter(d Deps) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.SetJSONContentType)
+	if d.Render != nil {
+		r.Use(handlers.UseRenderer(d.Render))
+	}
 
 	// Mount each feature's routes into this router.
 	for _, m := range d.Mounts {
@@ -26,3 +31,28 @@ func NewRouter(d Deps) http.Handler {
 
 	return r
 }
+
+// Version is one API version: its own handler set, usually built on the
+// same services as the others, served under /api/<Name>.
+type Version struct {
+	Name       string // e.g. "v1"
+	Deps       Deps
+	Deprecated time.Time // When set, responses carry Deprecation
+	Sunset     time.Time // When set with Deprecated, responses carry Sunset
+	Successor  string    // Version to move to, sent as a successor-version Link
+}
+
+// MountVersions mounts each API version at /api/<name>.
+func MountVersions(r chi.Router, versions ...Version) {
+	for _, v := range versions {
+		h := handlers.UseVersion(v.Name)(NewRouter(v.Deps))
+		if !v.Deprecated.IsZero() {
+			successor := ""
+			if v.Successor != "" {
+				successor = "/api/" + v.Successor
+			}
+			h = middleware.Deprecation(v.Deprecated, v.Sunset, successor)(h)
+		}
+		r.Mount("/api/"+v.Name, h)
+	}
+}
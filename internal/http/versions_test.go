@@ -0,0 +1,172 @@
+package transporthttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/http/handlers"
+)
+
+// Run with -update to rewrite the golden files after an intended change,
+// then review the diff: a changed v1 file breaks integrators.
+var update = flag.Bool("update", false, "rewrite testdata/golden")
+
+var (
+	created  = time.Date(2026, 3, 14, 9, 26, 53, 0, time.UTC)
+	accepted = created.Add(26 * time.Hour)
+
+	goldenQuote = core.Quote{
+		ID:             "01JQ0000000000000000000001",
+		ProductID:      "01JP0000000000000000000001",
+		ProductSlug:    "term-life-20",
+		CoverageAmount: 250000,
+		TermYears:      20,
+		MonthlyPremium: 42.5,
+		Status:         core.QuoteStatusPriced,
+		CreatedAt:      created,
+		ExpiresAt:      created.Add(30 * 24 * time.Hour),
+	}
+	goldenOffer = core.Offer{
+		ID:             "01JO0000000000000000000001",
+		ApplicationID:  "01JA0000000000000000000001",
+		ProductSlug:    "term-life-20",
+		CoverageAmount: 250000,
+		TermYears:      20,
+		MonthlyPremium: 42.5,
+		Status:         core.OfferStatusAccepted,
+		CreatedAt:      created,
+		ExpiresAt:      created.Add(30 * 24 * time.Hour),
+		AcceptedAt:     &accepted,
+	}
+	goldenPolicy = core.Policy{
+		ID:             "01JY0000000000000000000001",
+		Number:         "POL-2026-000001",
+		ApplicationID:  "01JA0000000000000000000001",
+		OfferID:        "01JO0000000000000000000001",
+		ProductSlug:    "term-life-20",
+		CoverageAmount: 250000,
+		TermYears:      20,
+		MonthlyPremium: 42.5,
+		Insured: core.Applicant{
+			FirstName:   "Ada",
+			LastName:    "Lovelace",
+			Email:       "ada@example.com",
+			DateOfBirth: "1985-06-15",
+			Age:         40,
+			State:       "CA",
+		},
+		Status:        core.PolicyStatusActive,
+		EffectiveDate: accepted,
+		ExpiryDate:    accepted.AddDate(20, 0, 0),
+		IssuedAt:      accepted,
+	}
+)
+
+// The services return the fixtures above; the rest of their methods are
+// never called.
+type goldenQuotes struct{ core.QuoteService }
+
+func (goldenQuotes) List(context.Context, core.QuoteFilter, core.PageRequest) (core.Page[core.Quote], error) {
+	return core.Page[core.Quote]{Items: []core.Quote{goldenQuote}, NextCursor: "next"}, nil
+}
+
+type goldenQuoteRepo struct{ core.QuoteRepo }
+
+func (goldenQuoteRepo) Get(context.Context, string) (core.Quote, error) { return goldenQuote, nil }
+
+type goldenOffers struct{ core.OfferService }
+
+func (goldenOffers) Get(context.Context, string) (core.Offer, error) { return goldenOffer, nil }
+
+func (goldenOffers) List(context.Context, core.OfferFilter, core.PageRequest) (core.Page[core.Offer], error) {
+	return core.Page[core.Offer]{Items: []core.Offer{goldenOffer}, NextCursor: "next"}, nil
+}
+
+type goldenPolicies struct{ core.PolicyService }
+
+func (goldenPolicies) GetByNumber(context.Context, string) (core.Policy, error) {
+	return goldenPolicy, nil
+}
+
+// List pages by cursor "", "2", "3", so v1's offset listing walks them all.
+func (goldenPolicies) List(_ context.Context, _ core.PolicyFilter, page core.PageRequest) (core.Page[core.Policy], error) {
+	next := map[string]string{"": "2", "2": "3"}[page.Cursor]
+	return core.Page[core.Policy]{Items: []core.Policy{goldenPolicy}, NextCursor: next}, nil
+}
+
+func goldenRouter() http.Handler {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mounts := []handlers.Mountable{
+		handlers.NewQuoteHandler(goldenQuotes{}, goldenQuoteRepo{}, log),
+		handlers.NewOfferHandler(goldenOffers{}, log),
+		handlers.NewPolicyHandler(goldenPolicies{}, log),
+	}
+	r := chi.NewRouter()
+	MountVersions(r,
+		Version{Name: "v1", Deps: Deps{Mounts: mounts}},
+		Version{Name: "v2", Deps: Deps{Mounts: mounts, Render: handlers.RenderV2}},
+	)
+	return r
+}
+
+// TestResponseBodies pins the JSON each API version sends for quotes,
+// offers and policies, field for field and in order.
+func TestResponseBodies(t *testing.T) {
+	router := goldenRouter()
+	paths := map[string]string{
+		"quote":    "/quotes/01JQ0000000000000000000001",
+		"quotes":   "/quotes",
+		"offer":    "/offers/01JO0000000000000000000001",
+		"offers":   "/offers",
+		"policy":   "/policies/POL-2026-000001",
+		"policies": "/policies?limit=1",
+	}
+
+	for _, version := range []string{"v1", "v2"} {
+		for name, path := range paths {
+			t.Run(version+"/"+name, func(t *testing.T) {
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/"+version+path, nil))
+				if rec.Code != http.StatusOK {
+					t.Fatalf("GET %s: status %d: %s", path, rec.Code, rec.Body)
+				}
+
+				var body bytes.Buffer
+				if err := json.Indent(&body, rec.Body.Bytes(), "", "  "); err != nil {
+					t.Fatalf("GET %s: body is not JSON: %v", path, err)
+				}
+				golden := filepath.Join("testdata", "golden", version, name+".json")
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(golden, body.Bytes(), 0o644); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("read golden file (run with -update to create it): %v", err)
+				}
+				if !bytes.Equal(body.Bytes(), want) {
+					t.Errorf("GET /api/%s%s body changed.\ngot:\n%s\nwant (%s):\n%s", version, path, body.Bytes(), golden, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// EscalationWorker flags referred cases that have breached their SLA.
+type EscalationWorker struct {
+	BaseWorker
+	uw core.UnderwritingService
+}
+
+// NewEscalationWorker creates a new SLA escalation worker.
+func NewEscalationWorker(
+	uwSvc core.UnderwritingService,
+	interval time.Duration,
+	log *slog.Logger,
+) *EscalationWorker {
+	return &EscalationWorker{
+		BaseWorker: NewBaseWorker("uw_escalation", interval, log),
+		uw:         uwSvc,
+	}
+}
+
+// Start begins the worker polling loop.
+func (w *EscalationWorker) Start(ctx context.Context) {
+	w.Poll(ctx, w.escalateBreached)
+}
+
+// Name returns the worker name.
+func (w *EscalationWorker) Name() string {
+	return w.name
+}
+
+// escalateBreached escalates overdue cases (limit 50 per poll).
+func (w *EscalationWorker) escalateBreached(ctx context.Context) error {
+	cases, err := w.uw.EscalateBreached(ctx, 50)
+	if err != nil {
+		return err
+	}
+
+	for _, uwCase := range cases {
+		w.log.Warn("underwriting SLA breached",
+			"case_id", uwCase.ID,
+			"app_id", uwCase.ApplicationID,
+			"assigned_to", uwCase.AssignedTo,
+			"sla_due_at", uwCase.SLADueAt,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// staleAfter is how many intervals a running worker may go without a
+// successful poll before it counts as unhealthy.
+const staleAfter = 3
+
+// HealthCheck reports w's last successful poll and, if it runs jobs of
+// kind, how many are due. It fails once a running worker has gone three
+// intervals without a successful poll. A worker that isn't running, e.g. a
+// singleton another replica leads, is healthy. Pass an empty kind for
+// workers that don't run jobs.
+func HealthCheck(w interface{ Status() PollStatus }, jobs core.JobRepo, kind core.JobKind) func(ctx context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) {
+		status := w.Status()
+		details := map[string]any{"running": status.Running}
+		if !status.LastSuccess.IsZero() {
+			details["last_success"] = status.LastSuccess
+		}
+		if status.Failures > 0 {
+			details["failures"] = status.Failures
+			details["last_error"] = status.LastError
+		}
+
+		if kind != "" {
+			backlog, err := jobs.Backlog(ctx, kind)
+			if err != nil {
+				return details, fmt.Errorf("count backlog: %w", err)
+			}
+			details["backlog"] = backlog
+		}
+
+		if !status.Running {
+			return details, nil
+		}
+		since := status.LastSuccess
+		if since.IsZero() {
+			since = status.StartedAt
+		}
+		if stale := staleAfter * status.Interval; time.Since(since) > stale {
+			if status.LastSuccess.IsZero() {
+				return details, fmt.Errorf("no successful poll since starting %s ago", time.Since(since).Round(time.Second))
+			}
+			return details, fmt.Errorf("no successful poll for %s", time.Since(since).Round(time.Second))
+		}
+		return details, nil
+	}
+}
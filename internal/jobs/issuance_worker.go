@@ -8,30 +8,37 @@ import (
 	"github.com/MrKriegler/go-insurance/internal/core"
 )
 
-// IssuanceWorker processes accepted offers and issues policies.
+// IssuanceWorker runs issue_policy jobs, which accepting an offer queues.
+// Jobs are leased, so replicas don't issue a policy twice.
 type IssuanceWorker struct {
 	BaseWorker
 	offers   core.OfferRepo
 	policies core.PolicyService
+	queue    *Queue
+	sweep    sweeper
 }
 
 // NewIssuanceWorker creates a new issuance worker.
 func NewIssuanceWorker(
 	offers core.OfferRepo,
 	policySvc core.PolicyService,
+	queue *Queue,
 	interval time.Duration,
 	log *slog.Logger,
 ) *IssuanceWorker {
+	base := NewBaseWorker("issuance", interval, log)
+	base.wake = queue.Wakeups(core.JobIssuePolicy)
 	return &IssuanceWorker{
-		BaseWorker: NewBaseWorker("issuance", interval, log),
+		BaseWorker: base,
 		offers:     offers,
 		policies:   policySvc,
+		queue:      queue,
 	}
 }
 
 // Start begins the worker polling loop.
 func (w *IssuanceWorker) Start(ctx context.Context) {
-	w.Poll(ctx, w.processAccepted)
+	w.Poll(ctx, w.processJobs)
 }
 
 // Name returns the worker name.
@@ -39,39 +46,42 @@ func (w *IssuanceWorker) Name() string {
 	return w.name
 }
 
-// processAccepted finds and processes accepted offers.
-func (w *IssuanceWorker) processAccepted(ctx context.Context) error {
-	// Find offers in "accepted" status (limit 10 per poll)
-	offers, err := w.offers.FindAccepted(ctx, 10)
+// processJobs queues any accepted offers that were missed, then runs due
+// jobs.
+func (w *IssuanceWorker) processJobs(ctx context.Context) error {
+	err := w.sweep.run(func() error {
+		offers, err := w.offers.FindAccepted(ctx, sweepLimit)
+		if err != nil {
+			return err
+		}
+		ids := make([]string, len(offers))
+		for i, offer := range offers {
+			ids[i] = offer.ID
+		}
+		return w.queue.Enqueue(ctx, core.JobIssuePolicy, ids...)
+	})
 	if err != nil {
-		return err
-	}
-
-	if len(offers) == 0 {
-		return nil
+		w.log.Warn("failed to sweep accepted offers", "err", err)
 	}
 
-	w.log.Info("found accepted offers", "count", len(offers))
-
-	for _, offer := range offers {
-		w.log.Info("issuing policy", "offer_id", offer.ID)
+	// Run due jobs
+	return w.queue.Run(ctx, core.JobIssuePolicy, func(ctx context.Context, offerID string) error {
+		w.log.InfoContext(ctx, "issuing policy", "offer_id", offerID)
 
-		// Issue policy for each
-		policy, err := w.policies.IssueFromOffer(ctx, offer.ID)
+		policy, err := w.policies.IssueFromOffer(ctx, offerID)
 		if err != nil {
-			w.log.Error("failed to issue policy",
-				"offer_id", offer.ID,
+			w.log.ErrorContext(ctx, "failed to issue policy",
+				"offer_id", offerID,
 				"err", err,
 			)
-			continue
+			return err
 		}
 
-		w.log.Info("policy issued",
-			"offer_id", offer.ID,
+		w.log.InfoContext(ctx, "policy issued",
+			"offer_id", offerID,
 			"policy_id", policy.ID,
 			"policy_number", policy.Number,
 		)
-	}
-
-	return nil
+		return nil
+	})
 }
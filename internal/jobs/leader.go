@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Elector runs a singleton worker in one replica at a time: whichever holds
+// the worker's named lease.
+type Elector struct {
+	leases core.LeaseRepo
+	owner  string
+	ttl    time.Duration
+	log    *slog.Logger
+}
+
+// NewElector creates an elector that campaigns as owner. A leader that
+// stops renewing is replaced once ttl passes.
+func NewElector(leases core.LeaseRepo, owner string, ttl time.Duration, log *slog.Logger) *Elector {
+	return &Elector{leases: leases, owner: owner, ttl: ttl, log: log}
+}
+
+// Run campaigns for the lease "leader:"+w.Name() every ttl/3 and runs w
+// while holding it. If the lease can't be renewed before it expires, w is
+// stopped and the campaign goes on. On return the lease is released, so
+// another replica takes over without waiting for it to expire.
+func (e *Elector) Run(ctx context.Context, w Worker) {
+	name := "leader:" + w.Name()
+	log := e.log.With("worker", w.Name(), "owner", e.owner)
+
+	var (
+		stop      func() // Non-nil while leading
+		heldUntil time.Time
+	)
+	lead := func() func() {
+		leaderCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			w.Start(leaderCtx)
+		}()
+		return func() {
+			cancel()
+			<-done
+		}
+	}
+	resign := func() {
+		if stop != nil {
+			stop()
+			stop = nil
+		}
+	}
+	defer func() {
+		resign()
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if err := e.leases.Release(releaseCtx, name, e.owner); err != nil {
+			log.Warn("failed to release leader lease", "err", err)
+		}
+	}()
+
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+	for {
+		attempted := time.Now()
+		held, err := e.leases.Acquire(ctx, name, e.owner, e.ttl)
+		switch {
+		case err != nil:
+			log.Warn("leader lease unavailable", "err", err)
+			if stop != nil && time.Now().After(heldUntil) {
+				log.Warn("leader lease expired, stopping worker")
+				resign()
+			}
+		case held:
+			heldUntil = attempted.Add(e.ttl)
+			if stop == nil {
+				log.Info("elected leader")
+				stop = lead()
+			}
+		case stop != nil:
+			log.Warn("leader lease taken over, stopping worker")
+			resign()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
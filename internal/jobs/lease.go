@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Claim is how a worker leases items, so that with several replicas each
+// item is processed by one of them at a time.
+type Claim struct {
+	Owner string        // This replica, see DefaultOwner
+	TTL   time.Duration // How long a claim outlives a replica that stopped renewing it
+}
+
+// DefaultOwner identifies this process in leases: host name and process ID.
+func DefaultOwner() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// holdLease runs fn, renewing the lease every ttl/3 until fn returns. If
+// the lease is lost to another owner, fn's context is cancelled. Failed
+// renewals are otherwise retried; the lease stays valid until ttl passes.
+func holdLease(ctx context.Context, ttl time.Duration, renew func(context.Context) error, fn func(context.Context) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := renew(ctx); errors.Is(err, core.ErrLeaseLost) {
+					cancel(err)
+					return
+				}
+			}
+		}
+	}()
+
+	err := fn(ctx)
+	if cause := context.Cause(ctx); errors.Is(cause, core.ErrLeaseLost) {
+		return cause
+	}
+	return err
+}
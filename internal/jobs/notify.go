@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Notifier wakes the worker of a job kind when jobs of that kind are queued,
+// so it doesn't wait for its next poll. Wakeups are coalesced: a worker that
+// is busy when notified runs once more after it finishes.
+type Notifier struct {
+	mu    sync.Mutex
+	wakes map[core.JobKind]chan struct{}
+}
+
+// NewNotifier creates a notifier.
+func NewNotifier() *Notifier {
+	return &Notifier{wakes: make(map[core.JobKind]chan struct{})}
+}
+
+// Notify wakes the worker of kind. It never blocks.
+func (n *Notifier) Notify(kind core.JobKind) {
+	select {
+	case n.wakeups(kind) <- struct{}{}:
+	default: // Already pending
+	}
+}
+
+// Wakeups returns the channel the worker of kind receives wakeups on. Each
+// kind has one worker per process.
+func (n *Notifier) Wakeups(kind core.JobKind) <-chan struct{} {
+	return n.wakeups(kind)
+}
+
+func (n *Notifier) wakeups(kind core.JobKind) chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ch, ok := n.wakes[kind]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		n.wakes[kind] = ch
+	}
+	return ch
+}
+
+// Notifying wraps repo so that queueing a job, or an admin queueing a dead
+// one again, notifies its worker in this process. Other replicas learn of
+// it from the store's change feed, if one is running, or their next poll.
+func Notifying(repo core.JobRepo, n *Notifier) core.JobRepo {
+	return &notifyingRepo{JobRepo: repo, notifier: n}
+}
+
+type notifyingRepo struct {
+	core.JobRepo
+	notifier *Notifier
+}
+
+func (r *notifyingRepo) Enqueue(ctx context.Context, job core.Job) error {
+	if err := r.JobRepo.Enqueue(ctx, job); err != nil {
+		return err
+	}
+	r.notifier.Notify(job.Kind)
+	return nil
+}
+
+func (r *notifyingRepo) Update(ctx context.Context, job core.Job, from core.JobStatus) error {
+	if err := r.JobRepo.Update(ctx, job, from); err != nil {
+		return err
+	}
+	if job.Status == core.JobStatusQueued {
+		r.notifier.Notify(job.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/logging"
+	"github.com/MrKriegler/go-insurance/internal/platform/metrics"
+	"github.com/MrKriegler/go-insurance/internal/platform/tracing"
+)
+
+// RetryPolicy decides what happens to a job whose attempt failed.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts before the job is dead
+	Backoff     time.Duration // Delay after the first failure, doubled after each further one
+	MaxBackoff  time.Duration // Upper bound on the delay
+	Retention   time.Duration // How long succeeded jobs are kept
+}
+
+// delay returns how long to wait after the given number of failed attempts.
+func (p RetryPolicy) delay(attempts int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempts && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxBackoff)
+}
+
+// permanent reports whether err won't go away by retrying, e.g. because the
+// subject is gone or has moved on to another status. An application that is
+// not submitted yet may be by the next attempt, so that one is retried.
+func permanent(err error) bool {
+	if errors.Is(err, core.ErrAppNotSubmitted) {
+		return false
+	}
+	return errors.Is(err, core.ErrNotFound) ||
+		errors.Is(err, core.ErrValidation) ||
+		errors.Is(err, core.ErrInvalidState) ||
+		errors.Is(err, core.ErrForbidden)
+}
+
+// Pool bounds how many jobs a worker runs, and for how long.
+type Pool struct {
+	BatchSize   int           // Most jobs claimed per poll
+	Concurrency int           // Most jobs run at once
+	ItemTimeout time.Duration // Longest one attempt may run
+	Drain       time.Duration // How long running jobs may finish once shutdown starts
+}
+
+// Queue runs persisted jobs. Each job is leased to this replica while it
+// runs; failed attempts are retried with backoff until the job is dead.
+type Queue struct {
+	jobs     core.JobRepo
+	notifier *Notifier
+	claim    Claim
+	retry    RetryPolicy
+	pool     Pool
+	throttle *throttle
+	log      *slog.Logger
+}
+
+// NewQueue creates a queue over jobs. Workers are woken through notifier;
+// jobs should be wrapped with Notifying on the same notifier.
+func NewQueue(jobs core.JobRepo, notifier *Notifier, claim Claim, retry RetryPolicy, pool Pool, log *slog.Logger) *Queue {
+	return &Queue{
+		jobs:     jobs,
+		notifier: notifier,
+		claim:    claim,
+		retry:    retry,
+		pool:     pool,
+		throttle: newThrottle(pool.BatchSize),
+		log:      log,
+	}
+}
+
+// Wakeups returns the channel the worker of kind is woken on.
+func (q *Queue) Wakeups(kind core.JobKind) <-chan struct{} {
+	return q.notifier.Wakeups(kind)
+}
+
+// Enqueue queues a job of kind for each subject that doesn't have one yet.
+func (q *Queue) Enqueue(ctx context.Context, kind core.JobKind, subjectIDs ...string) error {
+	now := time.Now().UTC()
+	for _, id := range subjectIDs {
+		if err := core.EnqueueJob(ctx, q.jobs, kind, id, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run claims a batch of due jobs of kind and runs handle on each job's
+// subject, up to Pool.Concurrency at a time, recording the outcomes. If it
+// claimed a full batch it wakes the worker again, as more jobs are likely
+// due.
+//
+// Once ctx is cancelled, jobs not yet started are handed back and running
+// ones get until Pool.Drain to finish. Run returns when all have.
+func (q *Queue) Run(ctx context.Context, kind core.JobKind, handle func(ctx context.Context, subjectID string) error) error {
+	limit := q.throttle.limit()
+	started := time.Now()
+	claimed, err := q.jobs.Claim(ctx, kind, q.claim.Owner, q.claim.TTL, limit)
+	if next, slowed := q.throttle.observe(time.Since(started), err); slowed {
+		q.log.Warn("job store slow, claiming smaller batches", "kind", kind, "batch_size", next)
+	}
+	if err != nil {
+		return err
+	}
+	if len(claimed) == limit {
+		defer q.notifier.Notify(kind)
+	}
+
+	work, stop := drainContext(ctx, q.pool.Drain)
+	defer stop()
+
+	slots := make(chan struct{}, q.pool.Concurrency)
+	var wg sync.WaitGroup
+	for _, job := range claimed {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.runJob(ctx, work, slots, job, handle)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// errNotStarted is returned for a claimed job that shutdown stopped from
+// starting.
+var errNotStarted = errors.New("job not started")
+
+// runJob runs one claimed job once a slot is free. Its lease is renewed
+// from the start, so it doesn't expire while the job waits for a slot.
+func (q *Queue) runJob(ctx, work context.Context, slots chan struct{}, job core.Job, handle func(ctx context.Context, subjectID string) error) {
+	work = logging.With(work, "job_id", job.ID, "job_kind", job.Kind)
+	renew := func(ctx context.Context) error { return q.jobs.Renew(ctx, job.ID, q.claim.Owner, q.claim.TTL) }
+	err := holdLease(work, q.claim.TTL, renew, func(leaseCtx context.Context) error {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return errNotStarted
+		case <-leaseCtx.Done():
+			return leaseCtx.Err()
+		}
+		defer func() { <-slots }()
+		if ctx.Err() != nil {
+			return errNotStarted
+		}
+
+		itemCtx, cancel := context.WithTimeout(leaseCtx, q.pool.ItemTimeout)
+		defer cancel()
+		return tracing.Job(itemCtx, job, func(ctx context.Context) error {
+			return handle(ctx, job.SubjectID)
+		})
+	})
+
+	switch {
+	case errors.Is(err, core.ErrLeaseLost):
+		q.log.WarnContext(work, "job lease lost", "job_id", job.ID)
+	case errors.Is(err, errNotStarted) || work.Err() != nil:
+		// Shutting down: hand the job back without counting an attempt
+		q.release(work, job)
+	default:
+		q.finish(work, job, err)
+	}
+}
+
+// drainContext returns a context that outlives ctx by drain, so work can
+// finish after shutdown starts.
+func drainContext(ctx context.Context, drain time.Duration) (context.Context, context.CancelFunc) {
+	work, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(drain)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-work.Done():
+		}
+	})
+	return work, func() {
+		stop()
+		cancel()
+	}
+}
+
+// storeTimeout bounds recording a job's outcome, which happens even while
+// shutting down.
+const storeTimeout = 5 * time.Second
+
+// release gives up this replica's lease on job, so another replica can run
+// it without waiting for the lease to expire.
+func (q *Queue) release(ctx context.Context, job core.Job) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+	defer cancel()
+	if err := q.jobs.Finish(ctx, job, q.claim.Owner); err != nil && !errors.Is(err, core.ErrLeaseLost) {
+		q.log.WarnContext(ctx, "failed to release job", "job_id", job.ID, "err", err)
+		return
+	}
+	q.log.InfoContext(ctx, "job released", "job_id", job.ID)
+}
+
+// finish records the outcome of an attempt at job.
+func (q *Queue) finish(ctx context.Context, job core.Job, err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	job.Attempts++
+	job.UpdatedAt = now
+
+	outcome := "retry"
+	switch {
+	case err == nil:
+		outcome = "succeeded"
+		expires := now.Add(q.retry.Retention)
+		job.Status = core.JobStatusSucceeded
+		job.LastError = ""
+		job.ExpiresAt = &expires
+	case permanent(err) || job.Attempts >= q.retry.MaxAttempts:
+		outcome = "dead"
+		job.Status = core.JobStatusDead
+		job.LastError = err.Error()
+		q.log.ErrorContext(ctx, "job dead", "job_id", job.ID, "attempts", job.Attempts, "err", err)
+	default:
+		job.RunAt = now.Add(q.retry.delay(job.Attempts))
+		job.LastError = err.Error()
+		q.log.WarnContext(ctx, "job failed, will retry",
+			"job_id", job.ID,
+			"attempts", job.Attempts,
+			"run_at", job.RunAt,
+			"err", err,
+		)
+	}
+
+	if err := q.jobs.Finish(ctx, job, q.claim.Owner); err != nil {
+		q.log.WarnContext(ctx, "failed to record job outcome", "job_id", job.ID, "err", err)
+		return
+	}
+	metrics.JobFinished(job.Kind, outcome)
+}
+
+// slowClaim is how long a claim may take before the store counts as slow.
+const slowClaim = time.Second
+
+// throttle sizes claim batches. It halves the batch while claims are slow
+// or failing, and grows it back by one per healthy claim, so a struggling
+// store isn't handed a full batch of work at once.
+type throttle struct {
+	mu   sync.Mutex
+	max  int
+	size int
+}
+
+func newThrottle(max int) *throttle {
+	return &throttle{max: max, size: max}
+}
+
+func (t *throttle) limit() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.size
+}
+
+// observe adjusts the batch size after a claim, returning the new size and
+// whether it shrank.
+func (t *throttle) observe(latency time.Duration, err error) (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch {
+	case err != nil || latency > slowClaim:
+		if t.size > 1 {
+			t.size /= 2
+			return t.size, true
+		}
+	case t.size < t.max:
+		t.size++
+	}
+	return t.size, false
+}
+
+const (
+	// sweepInterval is how often workers look for subjects that should have
+	// a job but don't, e.g. when enqueueing failed after the subject was saved.
+	sweepInterval = 10 * time.Minute
+	sweepLimit    = 50
+)
+
+// sweeper runs a worker's sweep on its first poll and then every
+// sweepInterval.
+type sweeper struct {
+	last time.Time
+}
+
+func (s *sweeper) run(sweep func() error) error {
+	if !s.last.IsZero() && time.Since(s.last) < sweepInterval {
+		return nil
+	}
+	s.last = time.Now()
+	return sweep()
+}
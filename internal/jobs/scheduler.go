@@ -0,0 +1,136 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/ids"
+)
+
+// Task is work the scheduler runs on a cron schedule. Run returns how many
+// records it changed.
+type Task struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) (int64, error)
+}
+
+// Scheduler runs tasks on their schedules and records each run. It is a
+// singleton worker: run it through an Elector when there are several
+// replicas. Runs missed while no replica was scheduling are skipped, not
+// caught up.
+type Scheduler struct {
+	tasks     []Task
+	runs      core.ScheduledRunRepo
+	owner     string
+	retention time.Duration
+	log       *slog.Logger
+}
+
+// NewScheduler creates a scheduler that records runs as owner and keeps
+// the records for retention.
+func NewScheduler(runs core.ScheduledRunRepo, owner string, retention time.Duration, log *slog.Logger) *Scheduler {
+	return &Scheduler{
+		runs:      runs,
+		owner:     owner,
+		retention: retention,
+		log:       log.With("worker", "scheduler"),
+	}
+}
+
+// Add schedules run as name on the cron expression spec (see ParseSchedule).
+func (s *Scheduler) Add(name, spec string, run func(ctx context.Context) (int64, error)) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return err
+	}
+	s.tasks = append(s.tasks, Task{Name: name, Schedule: schedule, Run: run})
+	return nil
+}
+
+// Len returns the number of scheduled tasks.
+func (s *Scheduler) Len() int {
+	return len(s.tasks)
+}
+
+// Name returns the worker name.
+func (s *Scheduler) Name() string {
+	return "scheduler"
+}
+
+// Start runs tasks as they come due until ctx is cancelled. Tasks run one
+// at a time, as core.SystemPrincipal.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx = core.WithPrincipal(ctx, core.SystemPrincipal)
+	s.log.Info("worker started", "tasks", len(s.tasks))
+
+	next := make([]time.Time, len(s.tasks))
+	now := time.Now()
+	for i, task := range s.tasks {
+		next[i] = task.Schedule.Next(now)
+	}
+
+	for {
+		var wake time.Time
+		for _, t := range next {
+			if wake.IsZero() || t.Before(wake) {
+				wake = t
+			}
+		}
+		if wake.IsZero() {
+			s.log.Info("worker stopping")
+			return
+		}
+
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.log.Info("worker stopping")
+			return
+		case <-timer.C:
+		}
+
+		for i, task := range s.tasks {
+			if next[i].After(time.Now()) {
+				continue
+			}
+			s.run(ctx, task)
+			next[i] = task.Schedule.Next(time.Now())
+		}
+	}
+}
+
+// run runs task once and records the outcome.
+func (s *Scheduler) run(ctx context.Context, task Task) {
+	started := time.Now().UTC()
+	affected, err := task.Run(ctx)
+	finished := time.Now().UTC()
+
+	run := core.ScheduledRun{
+		ID:         ids.New(),
+		Task:       task.Name,
+		Status:     core.ScheduledRunSucceeded,
+		Affected:   affected,
+		Owner:      s.owner,
+		StartedAt:  started,
+		FinishedAt: finished,
+		ExpiresAt:  finished.Add(s.retention),
+	}
+	if err != nil {
+		run.Status = core.ScheduledRunFailed
+		run.Error = err.Error()
+		s.log.Error("scheduled task failed", "task", task.Name, "affected", affected, "err", err)
+	} else {
+		s.log.Info("scheduled task finished", "task", task.Name, "affected", affected, "duration", finished.Sub(started))
+	}
+
+	// Record the run even if it was cut short by shutdown
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.runs.Create(recordCtx, run); err != nil {
+		s.log.Warn("failed to record scheduled run", "task", task.Name, "err", err)
+	}
+}
@@ -8,30 +8,37 @@ import (
 	"github.com/MrKriegler/go-insurance/internal/core"
 )
 
-// UnderwritingWorker processes submitted applications through underwriting.
+// UnderwritingWorker runs underwrite_application jobs, which submitting an
+// application queues. Jobs are leased, so replicas don't run one twice.
 type UnderwritingWorker struct {
 	BaseWorker
-	apps core.ApplicationRepo
-	uw   core.UnderwritingService
+	apps  core.ApplicationRepo
+	uw    core.UnderwritingService
+	queue *Queue
+	sweep sweeper
 }
 
 // NewUnderwritingWorker creates a new underwriting worker.
 func NewUnderwritingWorker(
 	apps core.ApplicationRepo,
 	uwSvc core.UnderwritingService,
+	queue *Queue,
 	interval time.Duration,
 	log *slog.Logger,
 ) *UnderwritingWorker {
+	base := NewBaseWorker("underwriting", interval, log)
+	base.wake = queue.Wakeups(core.JobUnderwriteApplication)
 	return &UnderwritingWorker{
-		BaseWorker: NewBaseWorker("underwriting", interval, log),
+		BaseWorker: base,
 		apps:       apps,
 		uw:         uwSvc,
+		queue:      queue,
 	}
 }
 
 // Start begins the worker polling loop.
 func (w *UnderwritingWorker) Start(ctx context.Context) {
-	w.Poll(ctx, w.processSubmitted)
+	w.Poll(ctx, w.processJobs)
 }
 
 // Name returns the worker name.
@@ -39,40 +46,43 @@ func (w *UnderwritingWorker) Name() string {
 	return w.name
 }
 
-// processSubmitted finds and processes submitted applications.
-func (w *UnderwritingWorker) processSubmitted(ctx context.Context) error {
-	// Find applications in "submitted" status (limit 10 per poll)
-	apps, err := w.apps.FindByStatus(ctx, core.ApplicationStatusSubmitted, 10)
+// processJobs queues any submitted applications that were missed, then runs
+// due jobs.
+func (w *UnderwritingWorker) processJobs(ctx context.Context) error {
+	err := w.sweep.run(func() error {
+		apps, err := w.apps.FindByStatus(ctx, core.ApplicationStatusSubmitted, sweepLimit)
+		if err != nil {
+			return err
+		}
+		ids := make([]string, len(apps))
+		for i, app := range apps {
+			ids[i] = app.ID
+		}
+		return w.queue.Enqueue(ctx, core.JobUnderwriteApplication, ids...)
+	})
 	if err != nil {
-		return err
-	}
-
-	if len(apps) == 0 {
-		return nil
+		w.log.Warn("failed to sweep submitted applications", "err", err)
 	}
 
-	w.log.Info("found submitted applications", "count", len(apps))
-
-	for _, app := range apps {
-		w.log.Info("processing application", "app_id", app.ID)
+	// Run due jobs
+	return w.queue.Run(ctx, core.JobUnderwriteApplication, func(ctx context.Context, appID string) error {
+		w.log.InfoContext(ctx, "processing application", "app_id", appID)
 
-		// Run underwriting for each
-		uwCase, err := w.uw.ProcessApplication(ctx, app.ID)
+		uwCase, err := w.uw.ProcessApplication(ctx, appID)
 		if err != nil {
-			w.log.Error("failed to process application",
-				"app_id", app.ID,
+			w.log.ErrorContext(ctx, "failed to process application",
+				"app_id", appID,
 				"err", err,
 			)
-			continue
+			return err
 		}
 
-		w.log.Info("underwriting complete",
-			"app_id", app.ID,
+		w.log.InfoContext(ctx, "underwriting complete",
+			"app_id", appID,
 			"case_id", uwCase.ID,
 			"decision", uwCase.Decision,
 			"method", uwCase.Method,
 		)
-	}
-
-	return nil
+		return nil
+	})
 }
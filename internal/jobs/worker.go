@@ -3,7 +3,11 @@ package jobs
 import (
 	"context"
 	"log/slog"
+	"sync"
 	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/metrics"
 )
 
 // Worker defines a background job that polls for work.
@@ -16,7 +20,24 @@ type Worker interface {
 type BaseWorker struct {
 	name     string
 	interval time.Duration
+	wake     <-chan struct{} // Runs work early when signalled; nil polls only
 	log      *slog.Logger
+	status   *pollStatus
+}
+
+// PollStatus is how a worker's polling has gone, for health reports.
+type PollStatus struct {
+	Running     bool // False until started, and while another replica leads a singleton
+	Interval    time.Duration
+	StartedAt   time.Time
+	LastSuccess time.Time // Zero until a poll succeeds
+	LastError   string
+	Failures    int // Polls failed in a row
+}
+
+type pollStatus struct {
+	mu sync.Mutex
+	PollStatus
 }
 
 // NewBaseWorker creates a new base worker.
@@ -25,30 +46,80 @@ func NewBaseWorker(name string, interval time.Duration, log *slog.Logger) BaseWo
 		name:     name,
 		interval: interval,
 		log:      log.With("worker", name),
+		status:   &pollStatus{PollStatus: PollStatus{Interval: interval}},
 	}
 }
 
-// Poll runs the work function at regular intervals until context is cancelled.
+// Status returns how the worker's polling has gone.
+func (w *BaseWorker) Status() PollStatus {
+	w.status.mu.Lock()
+	defer w.status.mu.Unlock()
+	return w.status.PollStatus
+}
+
+// record notes the outcome of a poll.
+func (w *BaseWorker) record(err error, failures int) {
+	w.status.mu.Lock()
+	defer w.status.mu.Unlock()
+	w.status.Failures = failures
+	if err != nil {
+		w.status.LastError = err.Error()
+		return
+	}
+	w.status.LastSuccess = time.Now().UTC()
+	w.status.LastError = ""
+}
+
+func (w *BaseWorker) setRunning(running bool) {
+	w.status.mu.Lock()
+	defer w.status.mu.Unlock()
+	w.status.Running = running
+	if running {
+		w.status.StartedAt = time.Now().UTC()
+	}
+}
+
+// Poll runs the work function at regular intervals, and whenever the worker
+// is woken, until context is cancelled. Work runs as core.SystemPrincipal.
+// After a failure it pauses before running again, doubling the pause with
+// each further failure up to the interval, so wakeups don't hammer a store
+// that is down.
 func (w *BaseWorker) Poll(ctx context.Context, work func(context.Context) error) {
+	ctx = core.WithPrincipal(ctx, core.SystemPrincipal)
 	ticker := time.NewTicker(w.interval)
 	defer ticker.Stop()
 
 	w.log.Info("worker started", "interval", w.interval)
+	w.setRunning(true)
+	defer w.setRunning(false)
 
-	// Run immediately on start
-	if err := work(ctx); err != nil {
-		w.log.Error("worker error", "err", err)
-	}
-
+	failures := 0
 	for {
+		start := time.Now()
+		err := work(ctx)
+		metrics.ObserveWorkerPoll(w.name, time.Since(start), err)
+		if err != nil {
+			failures++
+			w.log.Error("worker error", "err", err, "failures", failures)
+		} else {
+			failures = 0
+		}
+		w.record(err, failures)
+
+		if failures > 0 {
+			pause := min(time.Second<<min(failures-1, 10), w.interval)
+			select {
+			case <-ctx.Done():
+			case <-time.After(pause):
+			}
+		}
+
 		select {
 		case <-ctx.Done():
 			w.log.Info("worker stopping")
 			return
 		case <-ticker.C:
-			if err := work(ctx); err != nil {
-				w.log.Error("worker error", "err", err)
-			}
+		case <-w.wake:
 		}
 	}
 }
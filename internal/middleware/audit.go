@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/pkg/problem"
+)
+
+// AuditInfo hands core the request ID and the reason the caller gave in
+// X-Change-Reason, for the audit entries of the changes the request makes.
+// It must run after chi's RequestID middleware.
+func AuditInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reason := strings.TrimSpace(r.Header.Get("X-Change-Reason"))
+		if utf8.RuneCountInString(reason) > core.MaxAuditReasonLength {
+			problem.Write(w, r, http.StatusBadRequest, "invalid_change_reason", "Invalid X-Change-Reason",
+				"X-Change-Reason must be at most "+strconv.Itoa(core.MaxAuditReasonLength)+" characters")
+			return
+		}
+
+		ctx := core.WithAuditInfo(r.Context(), core.AuditInfo{
+			RequestID: chimw.GetReqID(r.Context()),
+			Reason:    reason,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -1,45 +1,126 @@
 package middleware
 
 import (
-	"crypto/subtle"
+	"context"
+	"errors"
 	"net/http"
+	"regexp"
 	"strings"
 
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/logging"
 	"github.com/MrKriegler/go-insurance/pkg/problem"
 )
 
-// SimpleAPIKey provides basic API key authentication for demo purposes.
-// In production, use JWT/OAuth2 with proper token validation.
-func SimpleAPIKey(apiKey string) func(http.Handler) http.Handler {
-	apiKeyBytes := []byte(apiKey)
+// KeyAuthenticator resolves an API key to its principal.
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (core.Principal, error)
+}
+
+// TokenVerifier validates a bearer token and returns its principal.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (core.Principal, error)
+}
 
+// Authenticate resolves the caller from an API key (X-API-Key, or a
+// non-JWT bearer token) or a JWT bearer token, enforces API key scopes and
+// attaches the principal to the request context. Either authenticator may be nil.
+func Authenticate(keys KeyAuthenticator, tokens TokenVerifier) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip auth for health checks and swagger
-			if strings.HasPrefix(r.URL.Path, "/health") ||
-				strings.HasPrefix(r.URL.Path, "/readyz") ||
-				strings.HasPrefix(r.URL.Path, "/swagger") {
+			if isPublicPath(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			// Check X-API-Key header
-			key := r.Header.Get("X-API-Key")
-			if key == "" {
-				// Also check Authorization: Bearer <key>
-				auth := r.Header.Get("Authorization")
-				if strings.HasPrefix(auth, "Bearer ") {
-					key = strings.TrimPrefix(auth, "Bearer ")
+			p, err := ResolvePrincipal(r.Context(), keys, tokens, r.Header.Get("X-API-Key"), r.Header.Get("Authorization"))
+			if errors.Is(err, ErrNoCredentials) {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				problem.Write(w, r, http.StatusUnauthorized, core.CodeUnauthorized, "Unauthorized", "Missing API key or bearer token")
+				return
+			}
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				code := core.CodeOf(err)
+				if code == "" {
+					code = "invalid_token"
 				}
+				problem.Write(w, r, http.StatusUnauthorized, code, "Unauthorized", err.Error())
+				return
 			}
 
-			// Constant-time comparison to prevent timing attacks
-			if subtle.ConstantTimeCompare([]byte(key), apiKeyBytes) != 1 {
-				problem.Write(w, http.StatusUnauthorized, "Unauthorized", "Invalid or missing API key")
+			// API keys are limited to the areas they were scoped to
+			if scope := ScopeFor(r.Method, r.URL.Path); scope != "" && !p.AllowsScope(scope) {
+				problem.Write(w, r, http.StatusForbidden, "insufficient_scope", "Forbidden", "API key lacks scope "+scope)
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			logging.AddPrincipal(r.Context(), p)
+			next.ServeHTTP(w, r.WithContext(core.WithPrincipal(r.Context(), p)))
 		})
 	}
 }
+
+// ErrNoCredentials is returned by ResolvePrincipal when the caller sent
+// neither an API key nor a bearer token.
+var ErrNoCredentials = errors.New("missing API key or bearer token")
+
+// ResolvePrincipal resolves the caller from an API key or the value of an
+// Authorization header, the same way for every transport. A bearer token
+// that is not a JWT, or any bearer token without a verifier, is taken as
+// an API key. Either authenticator may be nil.
+func ResolvePrincipal(ctx context.Context, keys KeyAuthenticator, tokens TokenVerifier, apiKey, authorization string) (core.Principal, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		token = ""
+	}
+	if apiKey == "" && token != "" && (tokens == nil || !looksLikeJWT(token)) {
+		apiKey, token = token, ""
+	}
+
+	switch {
+	case apiKey != "" && keys != nil:
+		return keys.Authenticate(ctx, apiKey)
+	case token != "" && tokens != nil:
+		return tokens.Verify(ctx, token)
+	default:
+		return core.Principal{}, ErrNoCredentials
+	}
+}
+
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// ScopeFor maps a request to the scope it needs, e.g. "GET /api/v1/policies"
+// needs "policies:read" and "POST /api/v1/quotes" needs "quotes:write".
+func ScopeFor(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for len(segments) > 0 && (segments[0] == "api" || versionSegment.MatchString(segments[0])) {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 || segments[0] == "" {
+		return ""
+	}
+	resource, _, _ := strings.Cut(segments[0], ":")
+	if resource == "graphql" {
+		return "" // One query reads several resources; the schema checks each one's scope
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return resource + ":read"
+	default:
+		return resource + ":write"
+	}
+}
+
+func isPublicPath(path string) bool {
+	return strings.HasPrefix(path, "/health") ||
+		strings.HasPrefix(path, "/readyz") ||
+		strings.HasPrefix(path, "/startupz") ||
+		strings.HasPrefix(path, "/swagger")
+}
+
+func looksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
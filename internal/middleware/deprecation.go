@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Deprecation marks every response of the routes it wraps as deprecated
+// (RFC 9745), with the date after which they may stop working (Sunset,
+// RFC 8594) and the version to move to, when known.
+func Deprecation(since, sunset time.Time, successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "@"+strconv.FormatInt(since.Unix(), 10))
+			if !sunset.IsZero() {
+				w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+			}
+			if successor != "" {
+				w.Header().Add("Link", "<"+successor+">; rel=\"successor-version\"")
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/pkg/problem"
+)
+
+// maxIdempotencyKeyLength bounds the header so it can't bloat the store.
+const maxIdempotencyKeyLength = 255
+
+// replayedHeaders are the response headers stored and sent again on replay.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency makes mutating requests that carry an Idempotency-Key header
+// safe to retry. The first request's response is stored; repeats with the
+// same body get it replayed, repeats with a different body get 422, and
+// repeats while the first is still running get 409. Responses with a 5xx
+// status are not stored, so the client can retry them, and neither are
+// responses marked Cache-Control: no-store (e.g. ones carrying secrets).
+type Idempotency struct {
+	repo  core.IdempotencyRepo
+	ttl   time.Duration
+	log   *slog.Logger
+	clock func() time.Time
+}
+
+// NewIdempotency creates the middleware. Keys expire after ttl.
+func NewIdempotency(repo core.IdempotencyRepo, ttl time.Duration, log *slog.Logger) *Idempotency {
+	return &Idempotency{repo: repo, ttl: ttl, log: log, clock: time.Now}
+}
+
+// Middleware returns the idempotency middleware handler. Keys are scoped to
+// the caller (see clientKey), so it must run after authentication.
+func (m *Idempotency) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Idempotency-Key")
+		if header == "" || !isMutating(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(header) > maxIdempotencyKeyLength {
+			problem.Write(w, r, http.StatusBadRequest, "invalid_idempotency_key", "Invalid Idempotency-Key",
+				"Idempotency-Key must be at most "+strconv.Itoa(maxIdempotencyKeyLength)+" characters")
+			return
+		}
+
+		// 1) Fingerprint the request; the body is buffered so the handler can still read it
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			problem.Write(w, r, http.StatusBadRequest, "invalid_body", "Invalid Body", "Body could not be read.")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		now := m.clock()
+		rec := core.IdempotencyRecord{
+			Key:         clientKey(r.Context(), r.RemoteAddr) + "|" + header,
+			Fingerprint: fingerprint(r, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(m.ttl),
+		}
+
+		// 2) Claim the key, or answer from the earlier request
+		if err := m.repo.Reserve(r.Context(), rec); err != nil {
+			if errors.Is(err, core.ErrIdempotencyKeyExists) {
+				m.answerRepeat(w, r, rec)
+				return
+			}
+			m.log.ErrorContext(r.Context(), "idempotency reserve failed", "err", err)
+			problem.Write(w, r, http.StatusServiceUnavailable, "idempotency_unavailable", "Service Unavailable",
+				"Idempotency-Key could not be recorded; please retry.")
+			return
+		}
+
+		// 3) Run the request, capturing its response. The store is updated even if
+		// the client went away, and the reservation released if the handler panics.
+		ctx := context.WithoutCancel(r.Context())
+		rw := &recordingWriter{ResponseWriter: w}
+		completed := false
+		defer func() {
+			if !completed {
+				_ = m.repo.Release(ctx, rec.Key)
+			}
+		}()
+
+		next.ServeHTTP(rw, r)
+
+		status := rw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if status >= http.StatusInternalServerError ||
+			strings.Contains(w.Header().Get("Cache-Control"), "no-store") {
+			return
+		}
+
+		// 4) Store the response for replays
+		rec.Status = status
+		rec.Body = rw.body.Bytes()
+		rec.Header = make(map[string]string, len(replayedHeaders))
+		for _, h := range replayedHeaders {
+			if v := w.Header().Get(h); v != "" {
+				rec.Header[h] = v
+			}
+		}
+		if err := m.repo.Complete(ctx, rec); err != nil {
+			m.log.ErrorContext(r.Context(), "idempotency complete failed", "err", err)
+			return
+		}
+		completed = true
+	})
+}
+
+func (m *Idempotency) answerRepeat(w http.ResponseWriter, r *http.Request, rec core.IdempotencyRecord) {
+	prev, err := m.repo.Get(r.Context(), rec.Key)
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		// Released or expired since Reserve; the first request did not complete
+		problem.Write(w, r, http.StatusConflict, "idempotency_key_in_use", "Conflict",
+			"A request with this Idempotency-Key is being processed; please retry.")
+	case err != nil:
+		m.log.ErrorContext(r.Context(), "idempotency lookup failed", "err", err)
+		problem.Write(w, r, http.StatusServiceUnavailable, "idempotency_unavailable", "Service Unavailable",
+			"Idempotency-Key could not be checked; please retry.")
+	case prev.Fingerprint != rec.Fingerprint:
+		problem.Write(w, r, http.StatusUnprocessableEntity, "idempotency_key_reused", "Idempotency-Key Reused",
+			"Idempotency-Key was already used for a different request.")
+	case !prev.Completed():
+		problem.Write(w, r, http.StatusConflict, "idempotency_key_in_use", "Conflict",
+			"A request with this Idempotency-Key is being processed; please retry.")
+	default:
+		for h, v := range prev.Header {
+			w.Header().Set(h, v)
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(prev.Status)
+		_, _ = w.Write(prev.Body)
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter passes the response through while keeping a copy.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rw *recordingWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
@@ -2,132 +2,225 @@ package middleware
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/ratelimit"
 	"github.com/MrKriegler/go-insurance/pkg/problem"
 )
 
-// RateLimiter provides simple in-memory rate limiting for demo purposes.
-// In production, use Redis-based distributed rate limiting.
-type RateLimiter struct {
-	requests map[string][]time.Time
-	mu       sync.RWMutex
-	limit    int           // max requests
-	window   time.Duration // time window
-	stopCh   chan struct{}
-}
-
-// NewRateLimiter creates a rate limiter with the given limit per window.
-func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
-	rl := &RateLimiter{
-		requests: make(map[string][]time.Time),
-		limit:    limit,
-		window:   window,
-		stopCh:   make(chan struct{}),
+// RateLimitRule overrides the default limit for matching requests. A rule
+// matches either a route (optional method plus path prefix) or a scope.
+type RateLimitRule struct {
+	Name   string // The rule as configured; also separates its buckets
+	Method string // Empty matches any method
+	Path   string // Path prefix, e.g. "/api/v1/quotes"
+	Scope  string // e.g. "quotes:write" or "quotes:*"
+	Limit  ratelimit.Limit
+}
+
+// ParseRateLimitRule parses "<match>=<limit>" where match is a route such
+// as "POST /api/v1/quotes" or "/api/v1/quotes", or a scope such as
+// "quotes:write", and limit is in ratelimit.ParseLimit format.
+func ParseRateLimitRule(spec string) (RateLimitRule, error) {
+	match, limitSpec, ok := strings.Cut(spec, "=")
+	if !ok {
+		return RateLimitRule{}, fmt.Errorf("rate limit rule %q: want <route|scope>=<limit>", spec)
+	}
+	limit, err := ratelimit.ParseLimit(limitSpec)
+	if err != nil {
+		return RateLimitRule{}, fmt.Errorf("rate limit rule %q: %w", spec, err)
 	}
-	// Cleanup old entries every minute
-	go rl.cleanup()
-	return rl
-}
-
-// Stop gracefully stops the rate limiter's cleanup goroutine.
-func (rl *RateLimiter) Stop() {
-	close(rl.stopCh)
-}
-
-// StartWithContext starts cleanup with context cancellation support.
-func (rl *RateLimiter) StartWithContext(ctx context.Context) {
-	go func() {
-		<-ctx.Done()
-		rl.Stop()
-	}()
-}
-
-func (rl *RateLimiter) cleanup() {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-rl.stopCh:
-			return
-		case <-ticker.C:
-			rl.mu.Lock()
-			now := time.Now()
-			for ip, times := range rl.requests {
-				var valid []time.Time
-				for _, t := range times {
-					if now.Sub(t) < rl.window {
-						valid = append(valid, t)
-					}
-				}
-				if len(valid) == 0 {
-					delete(rl.requests, ip)
-				} else {
-					rl.requests[ip] = valid
-				}
+
+	rule := RateLimitRule{Name: strings.TrimSpace(match), Limit: limit}
+	switch fields := strings.Fields(match); {
+	case len(fields) == 2 && strings.HasPrefix(fields[1], "/"):
+		rule.Method, rule.Path = strings.ToUpper(fields[0]), fields[1]
+	case len(fields) == 1 && strings.HasPrefix(fields[0], "/"):
+		rule.Path = fields[0]
+	case len(fields) == 1 && strings.Contains(fields[0], ":"):
+		rule.Scope = fields[0]
+	default:
+		return RateLimitRule{}, fmt.Errorf("rate limit rule %q: match must be a route or a scope", spec)
+	}
+	return rule, nil
+}
+
+func (rule RateLimitRule) matches(r *http.Request) bool {
+	if rule.Scope != "" {
+		return rule.matchesScope(ScopeFor(r.Method, r.URL.Path))
+	}
+	return (rule.Method == "" || rule.Method == r.Method) && strings.HasPrefix(r.URL.Path, rule.Path)
+}
+
+// matchesScope reports whether a scope rule covers scope, e.g. "quotes:*"
+// covers "quotes:read". Route rules match no scope.
+func (rule RateLimitRule) matchesScope(scope string) bool {
+	resource, _, _ := strings.Cut(scope, ":")
+	return rule.Scope != "" && scope != "" && (rule.Scope == scope || rule.Scope == resource+":*")
+}
+
+// RateLimiter applies token-bucket limits per client. Each client has one
+// bucket for the default limit and one per matching rule; the first rule
+// that matches a request replaces the default for it.
+type RateLimiter struct {
+	store ratelimit.Store
+	def   ratelimit.Limit
+	rules []RateLimitRule
+	log   *slog.Logger
+}
+
+// NewRateLimiter creates a rate limiter backed by store.
+func NewRateLimiter(store ratelimit.Store, def ratelimit.Limit, rules []RateLimitRule, log *slog.Logger) *RateLimiter {
+	return &RateLimiter{store: store, def: def, rules: rules, log: log}
+}
+
+// Middleware returns the rate limiting middleware handler. It sets the
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy
+// headers on every response, and Retry-After when the limit is exceeded.
+// Authenticated callers are limited per API key or subject, so partners
+// behind a shared NAT don't starve each other; anonymous ones per IP.
+// NOTE: This should be used AFTER chi's RealIP middleware which safely
+// sets RemoteAddr from X-Forwarded-For when behind trusted proxies, and
+// after authentication.
+func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, bucket := rl.def, clientKey(r.Context(), r.RemoteAddr)
+		for _, rule := range rl.rules {
+			if rule.matches(r) {
+				limit, bucket = rule.Limit, bucket+"|"+rule.Name
+				break
 			}
-			rl.mu.Unlock()
 		}
-	}
+		if rl.allow(w, r, bucket, limit) {
+			next.ServeHTTP(w, r)
+		}
+	})
 }
 
-func (rl *RateLimiter) isAllowed(ip string) bool {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
+// IPMiddleware limits every request per client IP before it is
+// authenticated, so requests with guessed API keys or tokens are counted
+// too. Its limit should sit well above the per-client one. It only sets
+// headers when it rejects a request; the per-client limiter after
+// authentication reports its own bucket otherwise.
+// NOTE: This should be used AFTER chi's RealIP middleware and BEFORE
+// authentication.
+func (rl *RateLimiter) IPMiddleware(limit ratelimit.Limit) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, ok := rl.TakePreAuth(r.Context(), r.RemoteAddr, limit)
+			if ok && !res.Allowed {
+				writeRateLimited(w, r, res, limit)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-	now := time.Now()
-	windowStart := now.Add(-rl.window)
+// TakePreAuth counts a request from remoteAddr, not yet authenticated,
+// against the per-IP bucket IPMiddleware uses, for transports other than
+// HTTP. ok is as for Take.
+func (rl *RateLimiter) TakePreAuth(ctx context.Context, remoteAddr string, limit ratelimit.Limit) (res ratelimit.Result, ok bool) {
+	return rl.Take(ctx, "preauth|ip:"+clientIP(remoteAddr), limit)
+}
 
-	// Filter to only requests within window
-	var valid []time.Time
-	for _, t := range rl.requests[ip] {
-		if t.After(windowStart) {
-			valid = append(valid, t)
+// TakeCall counts a call that needs scope against the bucket of the caller
+// in ctx, for transports other than HTTP. The first scope rule covering
+// scope replaces the default limit, as for requests; route rules never
+// match. ok is as for Take.
+func (rl *RateLimiter) TakeCall(ctx context.Context, remoteAddr, scope string) (res ratelimit.Result, limit ratelimit.Limit, ok bool) {
+	limit, bucket := rl.def, clientKey(ctx, remoteAddr)
+	for _, rule := range rl.rules {
+		if rule.matchesScope(scope) {
+			limit, bucket = rule.Limit, bucket+"|"+rule.Name
+			break
 		}
 	}
+	res, ok = rl.Take(ctx, bucket, limit)
+	return res, limit, ok
+}
 
-	if len(valid) >= rl.limit {
-		rl.requests[ip] = valid
-		return false
+// Take counts a request against bucket. ok is false when the store is
+// unavailable, in which case the request should be allowed: an unavailable
+// store must not take the API down.
+func (rl *RateLimiter) Take(ctx context.Context, bucket string, limit ratelimit.Limit) (res ratelimit.Result, ok bool) {
+	res, err := rl.store.Take(ctx, bucket, limit)
+	if err != nil {
+		rl.log.WarnContext(ctx, "rate limit store unavailable", "err", err)
+		return ratelimit.Result{}, false
 	}
+	return res, true
+}
 
-	rl.requests[ip] = append(valid, now)
+// allow counts r against bucket and sets the RateLimit headers. It writes
+// the 429 itself and returns false when the limit is exceeded.
+func (rl *RateLimiter) allow(w http.ResponseWriter, r *http.Request, bucket string, limit ratelimit.Limit) bool {
+	res, ok := rl.Take(r.Context(), bucket, limit)
+	if !ok {
+		return true
+	}
+	if !res.Allowed {
+		writeRateLimited(w, r, res, limit)
+		return false
+	}
+	setRateLimitHeaders(w.Header(), res, limit)
 	return true
 }
 
-// Middleware returns the rate limiting middleware handler.
-// NOTE: This should be used AFTER chi's RealIP middleware which safely
-// sets RemoteAddr from X-Forwarded-For when behind trusted proxies.
-func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Use RemoteAddr which is set by chi's RealIP middleware when behind proxy.
-		// Do NOT trust X-Real-IP or X-Forwarded-For directly as clients can spoof them.
-		ip := r.RemoteAddr
-
-		// Strip port if present (RemoteAddr is usually "ip:port")
-		if idx := strings.LastIndex(ip, ":"); idx != -1 {
-			// Check if it's IPv6 (contains multiple colons)
-			if strings.Count(ip, ":") > 1 {
-				// IPv6: look for ]:port pattern
-				if bracketIdx := strings.LastIndex(ip, "]:"); bracketIdx != -1 {
-					ip = ip[1:bracketIdx] // Remove [ and ]:port
-				}
-			} else {
-				ip = ip[:idx]
-			}
-		}
+func setRateLimitHeaders(h http.Header, res ratelimit.Result, limit ratelimit.Limit) {
+	h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", ceilSeconds(res.Reset))
+	h.Set("RateLimit-Policy", limit.String())
+}
+
+func writeRateLimited(w http.ResponseWriter, r *http.Request, res ratelimit.Result, limit ratelimit.Limit) {
+	setRateLimitHeaders(w.Header(), res, limit)
+	w.Header().Set("Retry-After", ceilSeconds(res.RetryAfter))
+	problem.Write(w, r, http.StatusTooManyRequests, "rate_limited", "Rate Limit Exceeded",
+		"Too many requests. Please try again later.")
+}
 
-		if !rl.isAllowed(ip) {
-			w.Header().Set("Retry-After", "60")
-			problem.Write(w, http.StatusTooManyRequests, "Rate Limit Exceeded",
-				"Too many requests. Please try again later.")
-			return
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// clientKey identifies the caller for rate limiting.
+func clientKey(ctx context.Context, remoteAddr string) string {
+	if p, ok := core.PrincipalFrom(ctx); ok {
+		if p.KeyID != "" {
+			return "key:" + p.KeyID
 		}
+		return "sub:" + p.Subject
+	}
 
-		next.ServeHTTP(w, r)
-	})
+	return "ip:" + clientIP(remoteAddr)
+}
+
+// clientIP strips the port from addr. Use RemoteAddr, which is set by chi's
+// RealIP middleware when behind proxy. Do NOT trust X-Real-IP or
+// X-Forwarded-For directly as clients can spoof them.
+func clientIP(addr string) string {
+	ip := addr
+
+	// Strip port if present (RemoteAddr is usually "ip:port")
+	if idx := strings.LastIndex(ip, ":"); idx != -1 {
+		// Check if it's IPv6 (contains multiple colons)
+		if strings.Count(ip, ":") > 1 {
+			// IPv6: look for ]:port pattern
+			if bracketIdx := strings.LastIndex(ip, "]:"); bracketIdx != -1 {
+				ip = ip[1:bracketIdx] // Remove [ and ]:port
+			}
+		} else {
+			ip = ip[:idx]
+		}
+	}
+	return ip
 }
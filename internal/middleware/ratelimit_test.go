@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/ratelimit"
+)
+
+type rejectKeys struct{}
+
+func (rejectKeys) Authenticate(context.Context, string) (core.Principal, error) {
+	return core.Principal{}, errors.New("invalid API key")
+}
+
+// Requests that fail authentication still count against their IP, so keys
+// can't be guessed at an unlimited rate.
+func TestIPMiddlewareCountsFailedAuthentication(t *testing.T) {
+	rl := NewRateLimiter(ratelimit.NewMemoryStore(), ratelimit.PerMinute(100), nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	h := rl.IPMiddleware(ratelimit.PerMinute(2))(
+		Authenticate(rejectKeys{}, nil)(
+			rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Error("handler reached with an invalid key")
+			}))))
+
+	for i, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/quotes", nil)
+		req.RemoteAddr = "203.0.113.7:4242"
+		req.Header.Set("X-API-Key", "guess")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != want {
+			t.Fatalf("request %d: status %d, want %d", i+1, rec.Code, want)
+		}
+		if want == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+			t.Errorf("request %d: 429 without Retry-After", i+1)
+		}
+	}
+
+	// Other clients have their own bucket
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/quotes", nil)
+	req.RemoteAddr = "198.51.100.1:4242"
+	req.Header.Set("X-API-Key", "guess")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("other IP: status %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
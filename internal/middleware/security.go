@@ -15,7 +15,7 @@ func LimitRequestBody(maxBytes int64) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.ContentLength > maxBytes {
-				problem.Write(w, http.StatusRequestEntityTooLarge,
+				problem.Write(w, r, http.StatusRequestEntityTooLarge, "request_too_large",
 					"Request Too Large",
 					"Request body exceeds maximum allowed size")
 				return
@@ -69,8 +69,9 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 			}
 
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, Idempotency-Key, If-Match, If-None-Match, X-Change-Reason")
 			w.Header().Set("Access-Control-Max-Age", "86400")
+			w.Header().Set("Access-Control-Expose-Headers", "ETag, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After, Idempotent-Replayed, Deprecation, Sunset, Link")
 
 			// Handle preflight
 			if r.Method == http.MethodOptions {
@@ -0,0 +1,267 @@
+// Package auth verifies bearer tokens and turns their claims into a core.Principal.
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Config selects how tokens are verified. Exactly one of JWKSURL or
+// StaticKey is expected; StaticKey (HS256/384/512) is meant for local
+// development and tests.
+type Config struct {
+	JWKSURL    string
+	StaticKey  string
+	Issuer     string        // Optional: required "iss"
+	Audience   string        // Optional: required entry in "aud"
+	RolesClaim string        // Dotted path to the roles claim, e.g. "realm_access.roles"
+	CacheTTL   time.Duration // How long fetched JWKS keys are trusted
+	Leeway     time.Duration // Clock skew allowed on exp/nbf
+}
+
+// Verifier validates JWTs and extracts the caller's identity.
+type Verifier struct {
+	cfg   Config
+	jwks  *jwksCache
+	clock func() time.Time
+}
+
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if cfg.JWKSURL == "" && cfg.StaticKey == "" {
+		return nil, fmt.Errorf("auth: JWKS URL or static key is required")
+	}
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = 15 * time.Minute
+	}
+
+	v := &Verifier{cfg: cfg, clock: time.Now}
+	if cfg.JWKSURL != "" {
+		v.jwks = newJWKSCache(cfg.JWKSURL, cfg.CacheTTL)
+	}
+	return v, nil
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the token's signature and registered claims.
+// All failures wrap core.ErrUnauthorized.
+func (v *Verifier) Verify(ctx context.Context, token string) (core.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return core.Principal{}, fmt.Errorf("%w: malformed token", core.ErrUnauthorized)
+	}
+
+	// 1) Header
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return core.Principal{}, fmt.Errorf("%w: bad token header", core.ErrUnauthorized)
+	}
+
+	// 2) Signature
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return core.Principal{}, fmt.Errorf("%w: bad token signature", core.ErrUnauthorized)
+	}
+	if err := v.verifySignature(ctx, header, parts[0]+"."+parts[1], sig); err != nil {
+		return core.Principal{}, err
+	}
+
+	// 3) Claims
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return core.Principal{}, fmt.Errorf("%w: bad token claims", core.ErrUnauthorized)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return core.Principal{}, err
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return core.Principal{}, fmt.Errorf("%w: token has no subject", core.ErrUnauthorized)
+	}
+
+	return core.Principal{
+		Subject: sub,
+		Roles:   rolesFrom(lookupClaim(claims, v.cfg.RolesClaim)),
+	}, nil
+}
+
+func (v *Verifier) verifySignature(ctx context.Context, header jwtHeader, signed string, sig []byte) error {
+	hash, ok := hashFor(header.Alg)
+	if !ok {
+		return fmt.Errorf("%w: unsupported alg %q", core.ErrUnauthorized, header.Alg)
+	}
+
+	// Symmetric tokens are only accepted in static-key mode so a public
+	// JWKS key can never be used as an HMAC secret.
+	if strings.HasPrefix(header.Alg, "HS") {
+		if v.cfg.StaticKey == "" {
+			return fmt.Errorf("%w: %s tokens are not accepted", core.ErrUnauthorized, header.Alg)
+		}
+		mac := hmac.New(hash.New, []byte(v.cfg.StaticKey))
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return fmt.Errorf("%w: invalid signature", core.ErrUnauthorized)
+		}
+		return nil
+	}
+
+	if v.jwks == nil {
+		return fmt.Errorf("%w: %s tokens are not accepted", core.ErrUnauthorized, header.Alg)
+	}
+	key, err := v.jwks.key(ctx, header.Kid)
+	if err != nil {
+		return err
+	}
+
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(header.Alg, "RS") {
+			return fmt.Errorf("%w: alg %s does not match key", core.ErrUnauthorized, header.Alg)
+		}
+		if err := rsa.VerifyPKCS1v15(k, hash, digest, sig); err != nil {
+			return fmt.Errorf("%w: invalid signature", core.ErrUnauthorized)
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(header.Alg, "ES") || len(sig) != 2*size {
+			return fmt.Errorf("%w: alg %s does not match key", core.ErrUnauthorized, header.Alg)
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return fmt.Errorf("%w: invalid signature", core.ErrUnauthorized)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported key type", core.ErrUnauthorized)
+	}
+	return nil
+}
+
+func (v *Verifier) checkClaims(claims map[string]any) error {
+	now := v.clock()
+
+	exp, ok := numericClaim(claims, "exp")
+	if !ok {
+		return fmt.Errorf("%w: token has no expiry", core.ErrUnauthorized)
+	}
+	if now.After(exp.Add(v.cfg.Leeway)) {
+		return fmt.Errorf("%w: token expired", core.ErrUnauthorized)
+	}
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Add(v.cfg.Leeway).Before(nbf) {
+		return fmt.Errorf("%w: token not yet valid", core.ErrUnauthorized)
+	}
+
+	if v.cfg.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.cfg.Issuer {
+			return fmt.Errorf("%w: unexpected issuer", core.ErrUnauthorized)
+		}
+	}
+	if v.cfg.Audience != "" && !hasAudience(claims["aud"], v.cfg.Audience) {
+		return fmt.Errorf("%w: unexpected audience", core.ErrUnauthorized)
+	}
+	return nil
+}
+
+func hashFor(alg string) (crypto.Hash, bool) {
+	switch alg {
+	case "HS256", "RS256", "ES256":
+		return crypto.SHA256, true
+	case "HS384", "RS384", "ES384":
+		return crypto.SHA384, true
+	case "HS512", "RS512", "ES512":
+		return crypto.SHA512, true
+	}
+	return 0, false
+}
+
+func decodeSegment(seg string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func numericClaim(claims map[string]any, name string) (time.Time, bool) {
+	n, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(n), 0), true
+}
+
+// hasAudience accepts both the string and array forms of "aud".
+func hasAudience(aud any, want string) bool {
+	switch a := aud.(type) {
+	case string:
+		return a == want
+	case []any:
+		for _, v := range a {
+			if s, ok := v.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupClaim follows a dotted path through nested claim objects.
+func lookupClaim(claims map[string]any, path string) any {
+	var cur any = claims
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[key]
+	}
+	return cur
+}
+
+// rolesFrom accepts an array of strings or a space-separated string.
+func rolesFrom(v any) []core.Role {
+	var names []string
+	switch r := v.(type) {
+	case string:
+		names = strings.Fields(r)
+	case []any:
+		for _, item := range r {
+			if s, ok := item.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+
+	roles := make([]core.Role, 0, len(names))
+	for _, name := range names {
+		role := core.Role(name)
+		// Only workers run as system; never accept it from a token
+		if role == core.RoleSystem {
+			continue
+		}
+		roles = append(roles, role)
+	}
+	return roles
+}
@@ -3,16 +3,32 @@ package config
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port string
-	Env  string
+	Port        string
+	GRPCEnabled bool
+	GRPCPort    string
+	Env         string
+	LogLevel    slog.Level // Starting level; admins can change it at runtime
+
+	// Prometheus metrics, served on their own port so they stay off the public API
+	MetricsEnabled bool
+	MetricsPort    string
+
+	// OpenTelemetry tracing
+	TracingExporter      string // "none", "stdout" or "otlp"
+	TracingOTLPEndpoint  string // host:port of an OTLP gRPC collector
+	TracingOTLPInsecure  bool
+	TracingSamplePercent int // Share of new traces recorded; traces continued from a caller follow its choice
+	TracingServiceName   string
 
 	// Database selection: "dynamodb" or "mongo"
 	DBType string
@@ -31,17 +47,81 @@ type Config struct {
 	HTTPReadTimeoutSec     int
 	HTTPWriteTimeoutSec    int
 	HTTPIdleTimeoutSec     int
+	HealthCheckTimeoutMs   int // Each readiness check's budget
+	ShutdownDelaySec       int // How long a draining replica fails readiness before its servers stop
 	HTTPRequestTimeoutSec  int
 	MongoConnectTimeoutSec int
 	MongoOpTimeoutMs       int
 
 	// Worker settings
-	WorkerIntervalSec int
+	WorkerIntervalSec    int    // Fallback poll; queued jobs wake workers at once
+	WorkerEvents         bool   // Wake workers on jobs queued by other replicas, via the store's change feed
+	WorkerID             string // Owner name in leases; defaults to host name and PID
+	WorkerLeaseSec       int    // How long a claim or leader lease outlives a replica that stopped
+	LeaderElection       bool   // Run singleton jobs (SLA escalation) in one replica only
+	WorkerBatchSize      int    // Most jobs a worker claims per poll
+	WorkerConcurrency    int    // Most jobs a worker runs at once
+	WorkerItemTimeoutSec int    // Longest one job attempt may run
+	WorkerDrainSec       int    // How long running jobs may finish after shutdown starts
+
+	// Job queue: failed jobs are retried with exponential backoff, then dead
+	JobMaxAttempts    int
+	JobBackoffSec     int // Delay after the first failure
+	JobMaxBackoffSec  int
+	JobRetentionHours int // How long succeeded jobs are kept
+
+	// Scheduled maintenance: cron expressions in UTC; empty disables a task
+	ScheduleOfferExpiry      string
+	ScheduleQuoteExpiry      string
+	SchedulePolicyMaturity   string
+	ScheduleRunRetentionDays int // How long run records are kept
+
+	// Underwriting work queue
+	UWSLAHours              int      // Time allowed for a manual decision
+	UWAssignmentRules       []string // "assignee:condition", evaluated in order
+	UWEscalationAssignee    string   // Optional: reassign breached cases here
+	UWEscalationIntervalSec int
+	UWAuthorityLimits       []string // "underwriter:max_coverage:max_score"; empty disables four-eyes
+
+	// Authentication: "apikey" (named API keys only) or "jwt" (bearer tokens and API keys)
+	AuthMode        string
+	JWTJWKSURL      string // Identity provider's signing keys
+	JWTStaticKey    string // Optional: HMAC secret for local development and tests
+	JWTIssuer       string
+	JWTAudience     string
+	JWTRolesClaim   string // Dotted path to the roles claim
+	JWTJWKSCacheSec int
 
 	// Security settings (for demo)
-	APIKey         string   // Simple API key for demo auth
+	APIKey         string   // Bootstrap admin key, stored in the key store on first start
 	AllowedOrigins []string // CORS allowed origins
-	RateLimitRPM   int      // Rate limit requests per minute
+
+	// Rate limiting (token bucket per API key, user or IP)
+	RateLimitRPM   int      // Default refill rate, requests per minute
+	RateLimitBurst int      // Default bucket size; defaults to RateLimitRPM
+	RateLimitRules []string // "<route|scope>=<n>/<s|m|h>[:<burst>]", first match wins
+	RateLimitIPRPM int      // Per IP before authentication, so failed attempts count too
+	RateLimitStore string   // "memory" (per replica) or "redis" (shared)
+	RedisURL       string
+
+	// Idempotency-Key records
+	IdempotencyStore    string // "db" (the configured database) or "memory"
+	IdempotencyTTLHours int
+
+	// Conditional requests: reject state changes that don't send If-Match
+	RequireIfMatch bool
+
+	// Search index
+	SearchIndex          string // "db" (MongoDB text index) or "memory"; DynamoDB always uses memory
+	SearchRebuildMinutes int    // How often the memory index is rebuilt from the database; 0 disables
+
+	// GraphQL query limits
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	// API versions: when /api/v1 was deprecated (zero while current) and when it goes away
+	APIV1Deprecated time.Time
+	APIV1Sunset     time.Time
 }
 
 func Load() (*Config, error) {
@@ -49,9 +129,29 @@ func Load() (*Config, error) {
 	cfg := &Config{}
 
 	cfg.Port = getEnv("PORT", "8080")
+	cfg.GRPCEnabled = getEnvAsBool("GRPC_ENABLED", true)
+	cfg.GRPCPort = getEnv("GRPC_PORT", "9090")
+	cfg.MetricsEnabled = getEnvAsBool("METRICS_ENABLED", true)
+	cfg.MetricsPort = getEnv("METRICS_PORT", "9091")
+	cfg.TracingExporter = getEnv("TRACING_EXPORTER", "none")
+	cfg.TracingOTLPEndpoint = getEnv("TRACING_OTLP_ENDPOINT", "localhost:4317")
+	cfg.TracingOTLPInsecure = getEnvAsBool("TRACING_OTLP_INSECURE", true)
+	cfg.TracingSamplePercent = getEnvAsInt("TRACING_SAMPLE_PERCENT", 100)
+	cfg.TracingServiceName = getEnv("TRACING_SERVICE_NAME", "go-insurance")
 	cfg.Env = getEnv("ENV", "dev")
 	cfg.DBType = getEnv("DB_TYPE", "dynamodb") // Default to DynamoDB
 
+	// Debug logs in development, info and above in production. Production
+	// also gives load balancers time to see a replica draining.
+	defaultLevel, defaultShutdownDelay := "debug", 0
+	if cfg.Env == "prod" || cfg.Env == "production" {
+		defaultLevel, defaultShutdownDelay = "info", 5
+	}
+	cfg.ShutdownDelaySec = getEnvAsInt("SHUTDOWN_DELAY_SEC", defaultShutdownDelay)
+	if err := cfg.LogLevel.UnmarshalText([]byte(getEnv("LOG_LEVEL", defaultLevel))); err != nil {
+		return nil, fmt.Errorf("LOG_LEVEL must be debug, info, warn or error: %w", err)
+	}
+
 	// MongoDB settings (check both MONGODB_URI and MONGO_URI for compatibility)
 	cfg.MongoURI = getEnv("MONGODB_URI", getEnv("MONGO_URI", ""))
 	cfg.MongoDB = getEnv("MONGO_DB", "go_insurance")
@@ -65,28 +165,170 @@ func Load() (*Config, error) {
 	cfg.HTTPReadTimeoutSec = getEnvAsInt("HTTP_READ_TIMEOUT_SEC", 10)
 	cfg.HTTPWriteTimeoutSec = getEnvAsInt("HTTP_WRITE_TIMEOUT_SEC", 10)
 	cfg.HTTPIdleTimeoutSec = getEnvAsInt("HTTP_IDLE_TIMEOUT_SEC", 120)
+	cfg.HealthCheckTimeoutMs = getEnvAsInt("HEALTH_CHECK_TIMEOUT_MS", 2000)
 	cfg.HTTPRequestTimeoutSec = getEnvAsInt("HTTP_REQUEST_TIMEOUT_SEC", 30)
 	cfg.MongoConnectTimeoutSec = getEnvAsInt("MONGO_CONNECT_TIMEOUT_SEC", 5)
 	cfg.MongoOpTimeoutMs = getEnvAsInt("MONGO_OP_TIMEOUT_MS", 500)
-	cfg.WorkerIntervalSec = getEnvAsInt("WORKER_INTERVAL_SEC", 5)
+	cfg.WorkerIntervalSec = getEnvAsInt("WORKER_INTERVAL_SEC", 30)
+	cfg.WorkerEvents = getEnvAsBool("WORKER_EVENTS", true)
+	cfg.WorkerID = getEnv("WORKER_ID", "")
+	cfg.WorkerLeaseSec = getEnvAsInt("WORKER_LEASE_SEC", 60)
+	cfg.LeaderElection = getEnvAsBool("LEADER_ELECTION", true)
+	cfg.WorkerBatchSize = getEnvAsInt("WORKER_BATCH_SIZE", 10)
+	cfg.WorkerConcurrency = getEnvAsInt("WORKER_CONCURRENCY", 4)
+	cfg.WorkerItemTimeoutSec = getEnvAsInt("WORKER_ITEM_TIMEOUT_SEC", 60)
+	cfg.WorkerDrainSec = getEnvAsInt("WORKER_DRAIN_SEC", 20)
+	cfg.JobMaxAttempts = getEnvAsInt("JOB_MAX_ATTEMPTS", 5)
+	cfg.JobBackoffSec = getEnvAsInt("JOB_BACKOFF_SEC", 10)
+	cfg.JobMaxBackoffSec = getEnvAsInt("JOB_MAX_BACKOFF_SEC", 3600)
+	cfg.JobRetentionHours = getEnvAsInt("JOB_RETENTION_HOURS", 168)
+	cfg.ScheduleOfferExpiry = getEnv("SCHEDULE_OFFER_EXPIRY", "*/5 * * * *")
+	cfg.ScheduleQuoteExpiry = getEnv("SCHEDULE_QUOTE_EXPIRY", "*/15 * * * *")
+	cfg.SchedulePolicyMaturity = getEnv("SCHEDULE_POLICY_MATURITY", "0 1 * * *")
+	cfg.ScheduleRunRetentionDays = getEnvAsInt("SCHEDULE_RUN_RETENTION_DAYS", 30)
+
+	// Underwriting work queue
+	cfg.UWSLAHours = getEnvAsInt("UW_SLA_HOURS", 48)
+	cfg.UWAssignmentRules = getEnvAsSlice("UW_ASSIGNMENT_RULES", nil)
+	cfg.UWEscalationAssignee = getEnv("UW_ESCALATION_ASSIGNEE", "")
+	cfg.UWEscalationIntervalSec = getEnvAsInt("UW_ESCALATION_INTERVAL_SEC", 60)
+	cfg.UWAuthorityLimits = getEnvAsSlice("UW_AUTHORITY_LIMITS", nil)
+
+	// Authentication
+	cfg.AuthMode = getEnv("AUTH_MODE", "apikey")
+	cfg.JWTJWKSURL = getEnv("JWT_JWKS_URL", "")
+	cfg.JWTStaticKey = getEnv("JWT_STATIC_KEY", "")
+	cfg.JWTIssuer = getEnv("JWT_ISSUER", "")
+	cfg.JWTAudience = getEnv("JWT_AUDIENCE", "")
+	cfg.JWTRolesClaim = getEnv("JWT_ROLES_CLAIM", "roles")
+	cfg.JWTJWKSCacheSec = getEnvAsInt("JWT_JWKS_CACHE_SEC", 900)
 
 	// Security settings
 	cfg.APIKey = getEnv("API_KEY", "")
 	cfg.AllowedOrigins = getEnvAsSlice("ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:8080"})
+
+	// Rate limiting
 	cfg.RateLimitRPM = getEnvAsInt("RATE_LIMIT_RPM", 100) // 100 requests per minute
+	cfg.RateLimitBurst = getEnvAsInt("RATE_LIMIT_BURST", cfg.RateLimitRPM)
+	cfg.RateLimitRules = getEnvAsSlice("RATE_LIMIT_RULES", nil)
+	cfg.RateLimitIPRPM = getEnvAsInt("RATE_LIMIT_IP_RPM", 1000) // Well above RPM: partners can share a NAT
+	cfg.RateLimitStore = getEnv("RATE_LIMIT_STORE", "memory")
+	cfg.RedisURL = getEnv("REDIS_URL", "")
+
+	// Idempotency
+	cfg.IdempotencyStore = getEnv("IDEMPOTENCY_STORE", "db")
+	cfg.IdempotencyTTLHours = getEnvAsInt("IDEMPOTENCY_TTL_HOURS", 24)
+
+	cfg.RequireIfMatch = getEnvAsBool("REQUIRE_IF_MATCH", false)
+
+	// Search
+	cfg.SearchIndex = getEnv("SEARCH_INDEX", "db")
+	cfg.SearchRebuildMinutes = getEnvAsInt("SEARCH_REBUILD_MINUTES", 15)
+
+	// GraphQL
+	cfg.GraphQLMaxDepth = getEnvAsInt("GRAPHQL_MAX_DEPTH", 8)
+	cfg.GraphQLMaxComplexity = getEnvAsInt("GRAPHQL_MAX_COMPLEXITY", 1000)
 
 	// Validate required fields based on DB type
 	if cfg.DBType == "mongo" && cfg.MongoURI == "" {
 		return nil, fmt.Errorf("MONGO_URI is required when DB_TYPE=mongo")
 	}
 
-	// In production, API_KEY must be explicitly set
-	if cfg.Env == "prod" && cfg.APIKey == "" {
-		return nil, fmt.Errorf("API_KEY is required in production environment")
+	switch cfg.TracingExporter {
+	case "none", "stdout":
+	case "otlp":
+		if cfg.TracingOTLPEndpoint == "" {
+			return nil, fmt.Errorf("TRACING_OTLP_ENDPOINT is required when TRACING_EXPORTER=otlp")
+		}
+	default:
+		return nil, fmt.Errorf("TRACING_EXPORTER must be none, stdout or otlp, got %q", cfg.TracingExporter)
+	}
+	if cfg.TracingSamplePercent < 0 || cfg.TracingSamplePercent > 100 {
+		return nil, fmt.Errorf("TRACING_SAMPLE_PERCENT must be between 0 and 100")
+	}
+
+	switch cfg.AuthMode {
+	case "apikey":
+		// In production, the bootstrap API_KEY must be explicitly set
+		// (there is no other way to create the first admin key)
+		if cfg.Env == "prod" && cfg.APIKey == "" {
+			return nil, fmt.Errorf("API_KEY is required in production environment")
+		}
+	case "jwt":
+		if cfg.JWTJWKSURL == "" && cfg.JWTStaticKey == "" {
+			return nil, fmt.Errorf("JWT_JWKS_URL or JWT_STATIC_KEY is required when AUTH_MODE=jwt")
+		}
+		if cfg.Env == "prod" && cfg.JWTStaticKey != "" {
+			return nil, fmt.Errorf("JWT_STATIC_KEY is for local use only and not allowed in production")
+		}
+	default:
+		return nil, fmt.Errorf("AUTH_MODE must be apikey or jwt, got %q", cfg.AuthMode)
+	}
+
+	switch cfg.RateLimitStore {
+	case "memory":
+	case "redis":
+		if cfg.RedisURL == "" {
+			return nil, fmt.Errorf("REDIS_URL is required when RATE_LIMIT_STORE=redis")
+		}
+	default:
+		return nil, fmt.Errorf("RATE_LIMIT_STORE must be memory or redis, got %q", cfg.RateLimitStore)
+	}
+	if cfg.RateLimitRPM <= 0 || cfg.RateLimitBurst <= 0 || cfg.RateLimitIPRPM <= 0 {
+		return nil, fmt.Errorf("RATE_LIMIT_RPM, RATE_LIMIT_BURST and RATE_LIMIT_IP_RPM must be positive")
+	}
+
+	if cfg.IdempotencyStore != "db" && cfg.IdempotencyStore != "memory" {
+		return nil, fmt.Errorf("IDEMPOTENCY_STORE must be db or memory, got %q", cfg.IdempotencyStore)
+	}
+	if cfg.IdempotencyTTLHours <= 0 {
+		return nil, fmt.Errorf("IDEMPOTENCY_TTL_HOURS must be positive")
+	}
+
+	if cfg.SearchIndex != "db" && cfg.SearchIndex != "memory" {
+		return nil, fmt.Errorf("SEARCH_INDEX must be db or memory, got %q", cfg.SearchIndex)
+	}
+	if cfg.SearchRebuildMinutes < 0 {
+		return nil, fmt.Errorf("SEARCH_REBUILD_MINUTES must not be negative")
+	}
+	if cfg.WorkerLeaseSec < 3 {
+		return nil, fmt.Errorf("WORKER_LEASE_SEC must be at least 3")
+	}
+	if cfg.WorkerBatchSize <= 0 || cfg.WorkerConcurrency <= 0 || cfg.WorkerItemTimeoutSec <= 0 {
+		return nil, fmt.Errorf("WORKER_BATCH_SIZE, WORKER_CONCURRENCY and WORKER_ITEM_TIMEOUT_SEC must be positive")
+	}
+	if cfg.HealthCheckTimeoutMs <= 0 || cfg.ShutdownDelaySec < 0 {
+		return nil, fmt.Errorf("HEALTH_CHECK_TIMEOUT_MS must be positive and SHUTDOWN_DELAY_SEC not negative")
+	}
+	if cfg.WorkerDrainSec < 0 {
+		return nil, fmt.Errorf("WORKER_DRAIN_SEC must not be negative")
+	}
+	if cfg.JobMaxAttempts <= 0 || cfg.JobBackoffSec <= 0 || cfg.JobRetentionHours <= 0 {
+		return nil, fmt.Errorf("JOB_MAX_ATTEMPTS, JOB_BACKOFF_SEC and JOB_RETENTION_HOURS must be positive")
+	}
+	if cfg.JobMaxBackoffSec < cfg.JobBackoffSec {
+		return nil, fmt.Errorf("JOB_MAX_BACKOFF_SEC must be at least JOB_BACKOFF_SEC")
+	}
+	if cfg.ScheduleRunRetentionDays <= 0 {
+		return nil, fmt.Errorf("SCHEDULE_RUN_RETENTION_DAYS must be positive")
+	}
+	if cfg.GraphQLMaxDepth <= 0 || cfg.GraphQLMaxComplexity <= 0 {
+		return nil, fmt.Errorf("GRAPHQL_MAX_DEPTH and GRAPHQL_MAX_COMPLEXITY must be positive")
+	}
+
+	var err error
+	if cfg.APIV1Deprecated, err = parseDate(getEnv("API_V1_DEPRECATED", "")); err != nil {
+		return nil, fmt.Errorf("API_V1_DEPRECATED: %w", err)
+	}
+	if cfg.APIV1Sunset, err = parseDate(getEnv("API_V1_SUNSET", "")); err != nil {
+		return nil, fmt.Errorf("API_V1_SUNSET: %w", err)
+	}
+	if !cfg.APIV1Sunset.IsZero() && cfg.APIV1Deprecated.IsZero() {
+		return nil, fmt.Errorf("API_V1_SUNSET requires API_V1_DEPRECATED")
 	}
 
 	// Default API key for development only
-	if cfg.APIKey == "" {
+	if cfg.APIKey == "" && cfg.Env != "prod" {
 		cfg.APIKey = "demo-api-key-12345"
 	}
 
@@ -117,6 +359,26 @@ func getEnvAsInt(key string, defaultVal int) int {
 	return defaultVal
 }
 
+func getEnvAsBool(key string, defaultVal bool) bool {
+	valStr := os.Getenv(key)
+	if val, err := strconv.ParseBool(valStr); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+// parseDate accepts an RFC3339 time or a YYYY-MM-DD date (midnight UTC);
+// empty is the zero time.
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
 func getEnvAsSlice(key string, defaultVal []string) []string {
 	valStr := os.Getenv(key)
 	if valStr == "" {
@@ -2,13 +2,22 @@ package ids
 
 import (
 	"crypto/rand"
+	"sync"
 	"time"
 
 	"github.com/oklog/ulid/v2"
 )
 
+// One monotonic source for the process, so IDs made in the same millisecond
+// still sort in the order they were made. It is not safe for concurrent use.
+var (
+	mu      sync.Mutex
+	entropy = ulid.Monotonic(rand.Reader, 0)
+)
+
+// New returns a ULID. IDs from this process sort in the order New was called.
 func New() string {
-	t := time.Now()
-	entropy := ulid.Monotonic(rand.Reader, 0)
-	return ulid.MustNew(ulid.Timestamp(t), entropy).String()
+	mu.Lock()
+	defer mu.Unlock()
+	return ulid.MustNew(ulid.Timestamp(time.Now()), entropy).String()
 }
@@ -0,0 +1,15 @@
+package ids
+
+import "testing"
+
+// IDs made in a burst share milliseconds; they must still sort in order.
+func TestNewSortsInOrder(t *testing.T) {
+	prev := New()
+	for range 10000 {
+		id := New()
+		if id <= prev {
+			t.Fatalf("%s made after %s sorts before it", id, prev)
+		}
+		prev = id
+	}
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// fields are attributes added to every record logged with a context.
+// They are shared by the contexts derived from the one that holds them, so
+// fields added deep in a request also show on the access log line written
+// by the middleware that started it.
+type fields struct {
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+type fieldsKey struct{}
+
+// With returns a context whose log records carry args (key-value pairs or
+// slog.Attrs) as well as the fields of ctx. Start one per unit of work: a
+// request or a job attempt.
+func With(ctx context.Context, args ...any) context.Context {
+	f := &fields{}
+	if parent := fieldsFrom(ctx); parent != nil {
+		f.attrs = parent.snapshot()
+	}
+	f.attrs = append(f.attrs, toAttrs(args)...)
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// Add adds args to the fields of ctx's unit of work, e.g. the ID of an
+// entity once it is known. It does nothing if ctx has none (see With).
+func Add(ctx context.Context, args ...any) {
+	if f := fieldsFrom(ctx); f != nil {
+		f.mu.Lock()
+		f.attrs = append(f.attrs, toAttrs(args)...)
+		f.mu.Unlock()
+	}
+}
+
+// AddPrincipal records the authenticated caller in ctx's fields, so the
+// access log shows it although authentication runs after the access logger.
+func AddPrincipal(ctx context.Context, p core.Principal) {
+	for _, a := range principalAttrs(p) {
+		Add(ctx, a)
+	}
+}
+
+func fieldsFrom(ctx context.Context) *fields {
+	f, _ := ctx.Value(fieldsKey{}).(*fields)
+	return f
+}
+
+func (f *fields) snapshot() []slog.Attr {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]slog.Attr(nil), f.attrs...)
+}
+
+// toAttrs converts arguments as slog.Logger.Info takes them.
+func toAttrs(args []any) []slog.Attr {
+	var r slog.Record
+	r.Add(args...)
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return attrs
+}
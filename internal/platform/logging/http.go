@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+)
+
+// HTTP logs one line per request through log, and starts the request's log
+// fields (see With) with its request ID, so every line logged for the
+// request can be found by it. Query strings are left out, as searches carry
+// names and emails. Health checks are logged at debug level. Use it on the
+// outer router after chimw.RequestID.
+func HTTP(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := With(r.Context(), "request_id", chimw.GetReqID(r.Context()))
+			start := time.Now()
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK // Handler wrote nothing
+			}
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case probe(r.URL.Path):
+				level = slog.LevelDebug
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+			}
+			if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+				attrs = append(attrs, slog.String("route", rctx.RoutePattern()))
+			}
+			attrs = append(attrs,
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("remote_ip", r.RemoteAddr),
+			)
+			log.LogAttrs(ctx, level, "request", attrs...)
+		})
+	}
+}
+
+// probe reports whether path is a health probe.
+func probe(path string) bool {
+	return strings.HasPrefix(path, "/health") || strings.HasPrefix(path, "/readyz") || strings.HasPrefix(path, "/startupz")
+}
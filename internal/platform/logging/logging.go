@@ -1,25 +1,95 @@
 package logging
 
 import (
+	"context"
 	"log/slog"
 	"os"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
 )
 
-func New(env string) *slog.Logger {
+// New returns the service logger, logging at level and above. Pass a
+// *slog.LevelVar to change the level while running. Personal data is
+// masked (see redactHandler).
+func New(env string, level slog.Leveler) *slog.Logger {
 	var handler slog.Handler
 
 	switch env {
 	case "prod", "production":
 		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level:     slog.LevelInfo,
+			Level:     level,
 			AddSource: true,
 		})
 	default:
 		handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level:     slog.LevelDebug,
+			Level:     level,
 			AddSource: true,
 		})
 	}
 
-	return slog.New(handler)
+	return slog.New(contextHandler{redactHandler{handler}})
+}
+
+// contextHandler adds what a context knows about the work at hand to
+// records logged with it (log.InfoContext and friends): fields from With and
+// Add, the authenticated caller, the trace ID and the IDs in the request's
+// route, e.g. offer_id. Attributes passed to the call itself take
+// precedence.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	seen := make(map[string]bool, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		seen[a.Key] = true
+		return true
+	})
+	add := func(a slog.Attr) {
+		if !seen[a.Key] {
+			seen[a.Key] = true
+			r.AddAttrs(a)
+		}
+	}
+
+	if f := fieldsFrom(ctx); f != nil {
+		for _, a := range f.snapshot() {
+			add(a)
+		}
+	}
+	if p, ok := core.PrincipalFrom(ctx); ok {
+		for _, a := range principalAttrs(p) {
+			add(a)
+		}
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		add(slog.String("trace_id", sc.TraceID().String()))
+	}
+	if rctx := chi.RouteContext(ctx); rctx != nil {
+		for i, key := range rctx.URLParams.Keys {
+			if key != "*" && i < len(rctx.URLParams.Values) && rctx.URLParams.Values[i] != "" {
+				add(slog.String(key, rctx.URLParams.Values[i]))
+			}
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+func principalAttrs(p core.Principal) []slog.Attr {
+	attrs := []slog.Attr{slog.String("principal", p.Subject)}
+	if p.KeyID != "" {
+		attrs = append(attrs, slog.String("api_key", p.Name))
+	}
+	return attrs
 }
@@ -0,0 +1,142 @@
+package logging
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces personal data in logs.
+const redacted = "[redacted]"
+
+// personalKeys are attribute and field names, lowercased without
+// underscores, that hold an applicant's name or date of birth. Anything
+// ending in "email" is personal too.
+var personalKeys = map[string]bool{
+	"firstname":   true,
+	"lastname":    true,
+	"middlename":  true,
+	"fullname":    true,
+	"dateofbirth": true,
+	"birthdate":   true,
+	"dob":         true,
+}
+
+// emailPattern finds email addresses in messages and errors, such as a
+// duplicate key error quoting the document.
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// redactHandler masks personal data before it is written: attributes named
+// like an email, a person's name or a date of birth, at any depth, and
+// email addresses in messages, strings and errors. Structs logged whole,
+// e.g. a core.Applicant, are logged as groups of their JSON fields so their
+// personal fields are masked too.
+type redactHandler struct {
+	slog.Handler
+}
+
+func (h redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, maskEmails(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(redact(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, out)
+}
+
+func (h redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	masked := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		masked[i] = redact(a)
+	}
+	return redactHandler{h.Handler.WithAttrs(masked)}
+}
+
+func (h redactHandler) WithGroup(name string) slog.Handler {
+	return redactHandler{h.Handler.WithGroup(name)}
+}
+
+func redact(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		masked := make([]slog.Attr, len(group))
+		for i, g := range group {
+			masked[i] = redact(g)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(masked...)}
+	case slog.KindString:
+		if personal(a.Key) && a.Value.String() != "" {
+			return slog.String(a.Key, redacted)
+		}
+		return slog.String(a.Key, maskEmails(a.Value.String()))
+	case slog.KindAny:
+		v := a.Value.Any()
+		if err, ok := v.(error); ok {
+			return slog.String(a.Key, maskEmails(err.Error()))
+		}
+		if group, ok := structAttrs(v); ok {
+			return redact(slog.Attr{Key: a.Key, Value: slog.GroupValue(group...)})
+		}
+	}
+	if personal(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+func personal(key string) bool {
+	key = strings.ToLower(strings.ReplaceAll(key, "_", ""))
+	return personalKeys[key] || strings.HasSuffix(key, "email")
+}
+
+func maskEmails(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, redacted)
+}
+
+// structAttrs returns the exported fields of a struct, or pointer to one,
+// keyed by JSON name. Types that format themselves, like time.Time, are
+// left alone.
+func structAttrs(v any) ([]slog.Attr, bool) {
+	switch v.(type) {
+	case fmt.Stringer, json.Marshaler, encoding.TextMarshaler:
+		return nil, false
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	rt := rv.Type()
+	attrs := make([]slog.Attr, 0, rt.NumField())
+	for i := range rt.NumField() {
+		f := rt.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		attrs = append(attrs, slog.Any(name, rv.Field(i).Interface()))
+	}
+	return attrs, true
+}
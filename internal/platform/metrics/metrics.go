@@ -0,0 +1,34 @@
+// Package metrics records Prometheus metrics for HTTP traffic, store
+// operations, background workers and business outcomes, and serves them.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes the service's own metrics.
+const namespace = "insurance"
+
+// registry holds every metric this package records, plus Go runtime and
+// process metrics.
+var registry = prometheus.NewRegistry()
+
+// factory registers metrics with registry as they are created.
+var factory = promauto.With(registry)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+var (
+	quotesTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "quotes_total",
+		Help:      "Quotes priced, by product.",
+	}, []string{"product"})
+
+	quotePremium = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "quote_monthly_premium",
+		Help:      "Monthly premium of priced quotes, by product.",
+		Buckets:   []float64{10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+	}, []string{"product"})
+
+	uwDecisions = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "underwriting_decisions_total",
+		Help:      "Underwriting decisions reached, by method (auto or manual) and decision.",
+	}, []string{"method", "decision"})
+
+	offersTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "offers_total",
+		Help:      "Offers by outcome: created, accepted, declined or expired.",
+	}, []string{"outcome"})
+
+	policiesIssued = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "policies_issued_total",
+		Help:      "Policies issued, by product.",
+	}, []string{"product"})
+)
+
+// The wrappers below count business outcomes as they are stored, so each is
+// counted once however it came about (API, worker or scheduled task).
+
+// Quotes wraps repo to count priced quotes and their premiums.
+func Quotes(repo core.QuoteRepo) core.QuoteRepo {
+	return &quoteRepo{QuoteRepo: repo}
+}
+
+type quoteRepo struct {
+	core.QuoteRepo
+}
+
+func (r *quoteRepo) Create(ctx context.Context, q core.Quote) error {
+	if err := r.QuoteRepo.Create(ctx, q); err != nil {
+		return err
+	}
+	quotesTotal.WithLabelValues(q.ProductSlug).Inc()
+	quotePremium.WithLabelValues(q.ProductSlug).Observe(q.MonthlyPremium)
+	return nil
+}
+
+// Underwriting wraps repo to count decisions: a new case's decision, and
+// each change of decision after that.
+func Underwriting(repo core.UnderwritingRepo) core.UnderwritingRepo {
+	return &underwritingRepo{UnderwritingRepo: repo}
+}
+
+type underwritingRepo struct {
+	core.UnderwritingRepo
+}
+
+func (r *underwritingRepo) Create(ctx context.Context, uw core.UnderwritingCase) error {
+	if err := r.UnderwritingRepo.Create(ctx, uw); err != nil {
+		return err
+	}
+	uwDecisions.WithLabelValues(string(uw.Method), string(uw.Decision)).Inc()
+	return nil
+}
+
+// Update counts the decision when the write adds a manual decision step.
+// Every such step is made at the time of the write; other writes, such as
+// escalations, leave the decision alone.
+func (r *underwritingRepo) Update(ctx context.Context, uw core.UnderwritingCase) error {
+	if err := r.UnderwritingRepo.Update(ctx, uw); err != nil {
+		return err
+	}
+	if n := len(uw.DecisionChain); n > 0 && uw.DecisionChain[n-1].At.Equal(uw.UpdatedAt) {
+		uwDecisions.WithLabelValues(string(uw.Method), string(uw.Decision)).Inc()
+	}
+	return nil
+}
+
+// Offers wraps repo to count offers made and how they end. The acceptance
+// rate is accepted over created.
+func Offers(repo core.OfferRepo) core.OfferRepo {
+	return &offerRepo{OfferRepo: repo}
+}
+
+type offerRepo struct {
+	core.OfferRepo
+}
+
+func (r *offerRepo) Create(ctx context.Context, offer core.Offer) error {
+	if err := r.OfferRepo.Create(ctx, offer); err != nil {
+		return err
+	}
+	offersTotal.WithLabelValues("created").Inc()
+	return nil
+}
+
+func (r *offerRepo) Update(ctx context.Context, offer core.Offer) error {
+	if err := r.OfferRepo.Update(ctx, offer); err != nil {
+		return err
+	}
+	switch offer.Status {
+	case core.OfferStatusAccepted, core.OfferStatusDeclined, core.OfferStatusExpired:
+		offersTotal.WithLabelValues(string(offer.Status)).Inc()
+	}
+	return nil
+}
+
+func (r *offerRepo) ExpireOffers(ctx context.Context, before time.Time) ([]core.Offer, error) {
+	expired, err := r.OfferRepo.ExpireOffers(ctx, before)
+	offersTotal.WithLabelValues(string(core.OfferStatusExpired)).Add(float64(len(expired)))
+	return expired, err
+}
+
+// Policies wraps repo to count issued policies.
+func Policies(repo core.PolicyRepo) core.PolicyRepo {
+	return &policyRepo{PolicyRepo: repo}
+}
+
+type policyRepo struct {
+	core.PolicyRepo
+}
+
+func (r *policyRepo) Create(ctx context.Context, p core.Policy) error {
+	if err := r.PolicyRepo.Create(ctx, p); err != nil {
+		return err
+	}
+	policiesIssued.WithLabelValues(p.ProductSlug).Inc()
+	return nil
+}
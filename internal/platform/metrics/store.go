@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	storeDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "store_operation_duration_seconds",
+		Help:      "Database operation latency by store, operation and collection or table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"store", "operation", "collection"})
+
+	storeErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "store_operation_errors_total",
+		Help:      "Failed database operations by store, operation and collection or table.",
+	}, []string{"store", "operation", "collection"})
+)
+
+// ObserveStore records one database operation: a Mongo command or a
+// DynamoDB call. The stores report these from their client hooks.
+func ObserveStore(store, operation, collection string, d time.Duration, err error) {
+	storeDuration.WithLabelValues(store, operation, collection).Observe(d.Seconds())
+	if err != nil {
+		storeErrors.WithLabelValues(store, operation, collection).Inc()
+	}
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+var (
+	workerPollDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "worker_poll_duration_seconds",
+		Help:      "Time a background worker's poll took, including the jobs it ran.",
+		Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"worker"})
+
+	workerPollErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "worker_poll_errors_total",
+		Help:      "Background worker polls that failed.",
+	}, []string{"worker"})
+
+	jobsFinished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_finished_total",
+		Help:      "Job attempts by kind and outcome: succeeded, retry or dead.",
+	}, []string{"kind", "outcome"})
+)
+
+// ObserveWorkerPoll records one poll of the named worker.
+func ObserveWorkerPoll(worker string, d time.Duration, err error) {
+	workerPollDuration.WithLabelValues(worker).Observe(d.Seconds())
+	if err != nil {
+		workerPollErrors.WithLabelValues(worker).Inc()
+	}
+}
+
+// JobFinished records the outcome of a job attempt.
+func JobFinished(kind core.JobKind, outcome string) {
+	jobsFinished.WithLabelValues(string(kind), outcome).Inc()
+}
+
+// backlogTimeout bounds counting the backlog during a scrape.
+const backlogTimeout = 5 * time.Second
+
+// RegisterJobBacklog reports, on each scrape, how many jobs of each kind
+// are due and waiting for a worker. The count comes from the store, so
+// every replica reports the same backlog.
+func RegisterJobBacklog(jobs core.JobRepo, log *slog.Logger, kinds ...core.JobKind) {
+	registry.MustRegister(&backlogCollector{jobs: jobs, kinds: kinds, log: log})
+}
+
+var backlogDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "jobs_backlog"),
+	"Queued jobs that are due and not running, by kind.",
+	[]string{"kind"}, nil,
+)
+
+type backlogCollector struct {
+	jobs  core.JobRepo
+	kinds []core.JobKind
+	log   *slog.Logger
+}
+
+func (c *backlogCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- backlogDesc
+}
+
+func (c *backlogCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), backlogTimeout)
+	defer cancel()
+
+	for _, kind := range c.kinds {
+		n, err := c.jobs.Backlog(ctx, kind)
+		if err != nil {
+			// Leave the series out rather than report a wrong count
+			c.log.Warn("failed to count job backlog", "kind", kind, "err", err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(backlogDesc, prometheus.GaugeValue, float64(n), string(kind))
+	}
+}
@@ -0,0 +1,107 @@
+// Package ratelimit implements token-bucket rate limiting over a pluggable
+// bucket store, so limits can be shared between API replicas.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket: Burst requests at once, refilled at Rate per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute returns a limit of n requests per minute with a burst of n.
+func PerMinute(n int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: n}
+}
+
+// Window is the time an empty bucket takes to refill completely.
+func (l Limit) Window() time.Duration {
+	if l.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d;w=%d", l.Burst, int(math.Ceil(l.Window().Seconds())))
+}
+
+// ParseLimit parses "<n>/<unit>[:<burst>]" where unit is s, m or h,
+// e.g. "100/m" or "10/s:50". The burst defaults to n.
+func ParseLimit(spec string) (Limit, error) {
+	rate, burstStr, hasBurst := strings.Cut(strings.TrimSpace(spec), ":")
+	countStr, unit, ok := strings.Cut(rate, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("rate limit %q: want <n>/<s|m|h>[:<burst>]", spec)
+	}
+
+	count, err := strconv.Atoi(countStr)
+	if err != nil || count <= 0 {
+		return Limit{}, fmt.Errorf("rate limit %q: count must be a positive integer", spec)
+	}
+
+	var per time.Duration
+	switch unit {
+	case "s":
+		per = time.Second
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return Limit{}, fmt.Errorf("rate limit %q: unit must be s, m or h", spec)
+	}
+
+	burst := count
+	if hasBurst {
+		if burst, err = strconv.Atoi(burstStr); err != nil || burst <= 0 {
+			return Limit{}, fmt.Errorf("rate limit %q: burst must be a positive integer", spec)
+		}
+	}
+
+	return Limit{Rate: float64(count) / per.Seconds(), Burst: burst}, nil
+}
+
+// Result describes the bucket after a request was counted against it.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // Until the bucket is full again
+	RetryAfter time.Duration // Until the next request is allowed; zero if allowed
+}
+
+// Store holds bucket state. Take removes one token from the bucket for key
+// if one is available and reports the bucket's state afterwards.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// result derives the response from the token count left in a bucket.
+func result(allowed bool, tokens float64, limit Limit) Result {
+	res := Result{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     secondsToDuration((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !allowed {
+		res.RetryAfter = secondsToDuration((1 - tokens) / limit.Rate)
+	}
+	return res
+}
+
+func secondsToDuration(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
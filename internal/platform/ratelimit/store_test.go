@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// The Redis tests run against a local server, e.g.
+//
+//	REDIS_TEST_URL=redis://localhost:6379/15 go test ./internal/platform/ratelimit
+//
+// Keys are prefixed per run, so a shared database is fine.
+func redisStore(t *testing.T) *RedisStore {
+	t.Helper()
+	rawURL := os.Getenv("REDIS_TEST_URL")
+	if rawURL == "" {
+		t.Skip("REDIS_TEST_URL not set")
+	}
+	s, err := NewRedisStore(context.Background(), rawURL)
+	if err != nil {
+		t.Fatalf("NewRedisStore: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestRedisStore(t *testing.T) {
+	testStore(t, redisStore(t))
+}
+
+// TestRedisStoreReloadsScript checks that Take still works after the server
+// forgets the cached script, e.g. when it restarts.
+func TestRedisStoreReloadsScript(t *testing.T) {
+	s := redisStore(t)
+	ctx := context.Background()
+	key := testKey(t)
+	limit := Limit{Rate: 1, Burst: 5}
+
+	if _, err := s.Take(ctx, key, limit); err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+	if _, err := s.do(ctx, "SCRIPT", "FLUSH"); err != nil {
+		t.Fatalf("SCRIPT FLUSH: %v", err)
+	}
+	res, err := s.Take(ctx, key, limit)
+	if err != nil {
+		t.Fatalf("Take after SCRIPT FLUSH: %v", err)
+	}
+	if !res.Allowed || res.Remaining != 3 {
+		t.Errorf("Take after SCRIPT FLUSH = %+v, want allowed with 3 remaining", res)
+	}
+}
+
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+
+	t.Run("drains the burst then refuses", func(t *testing.T) {
+		key := testKey(t)
+		limit := Limit{Rate: 1, Burst: 3}
+
+		for i := 2; i >= 0; i-- {
+			res, err := s.Take(ctx, key, limit)
+			if err != nil {
+				t.Fatalf("Take: %v", err)
+			}
+			if !res.Allowed || res.Remaining != i || res.Limit != 3 {
+				t.Fatalf("Take = %+v, want allowed with %d remaining of 3", res, i)
+			}
+			if res.RetryAfter != 0 {
+				t.Errorf("RetryAfter = %v on an allowed request, want 0", res.RetryAfter)
+			}
+		}
+
+		res, err := s.Take(ctx, key, limit)
+		if err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+		if res.Allowed {
+			t.Fatalf("Take on an empty bucket = %+v, want refused", res)
+		}
+		if res.RetryAfter <= 0 || res.RetryAfter > time.Second {
+			t.Errorf("RetryAfter = %v, want within the 1s refill of one token", res.RetryAfter)
+		}
+		if res.Reset <= 2*time.Second || res.Reset > 3*time.Second {
+			t.Errorf("Reset = %v, want just under the 3s a full refill takes", res.Reset)
+		}
+	})
+
+	t.Run("refills over time", func(t *testing.T) {
+		key := testKey(t)
+		limit := Limit{Rate: 20, Burst: 1}
+
+		if res, err := s.Take(ctx, key, limit); err != nil || !res.Allowed {
+			t.Fatalf("Take = %+v, %v; want allowed", res, err)
+		}
+		if res, err := s.Take(ctx, key, limit); err != nil || res.Allowed {
+			t.Fatalf("Take = %+v, %v; want refused", res, err)
+		}
+		time.Sleep(100 * time.Millisecond) // Two tokens' worth, capped at the burst
+		if res, err := s.Take(ctx, key, limit); err != nil || !res.Allowed {
+			t.Fatalf("Take after refill = %+v, %v; want allowed", res, err)
+		}
+	})
+
+	t.Run("keeps keys apart", func(t *testing.T) {
+		limit := Limit{Rate: 1, Burst: 1}
+		first, second := testKey(t)+"|a", testKey(t)+"|b"
+
+		if res, err := s.Take(ctx, first, limit); err != nil || !res.Allowed {
+			t.Fatalf("Take(%s) = %+v, %v; want allowed", first, res, err)
+		}
+		if res, err := s.Take(ctx, second, limit); err != nil || !res.Allowed {
+			t.Fatalf("Take(%s) = %+v, %v; want allowed", second, res, err)
+		}
+	})
+}
+
+func testKey(t *testing.T) string {
+	return "test|" + t.Name() + "|" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
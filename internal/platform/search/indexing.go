@@ -0,0 +1,156 @@
+// Package search keeps a core.SearchIndex in step with the stores and
+// provides an in-process index for deployments without a search database.
+package search
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Applications wraps repo so every application write is indexed. Index
+// failures are logged rather than returned: the write itself succeeded, and
+// the document is corrected by its next write or the next rebuild.
+func Applications(repo core.ApplicationRepo, index core.SearchIndex, log *slog.Logger) core.ApplicationRepo {
+	return &applicationRepo{ApplicationRepo: repo, index: index, log: log}
+}
+
+type applicationRepo struct {
+	core.ApplicationRepo
+	index core.SearchIndex
+	log   *slog.Logger
+}
+
+func (r *applicationRepo) Create(ctx context.Context, app core.Application) error {
+	if err := r.ApplicationRepo.Create(ctx, app); err != nil {
+		return err
+	}
+	put(ctx, r.index, r.log, core.ApplicationSearchDocs(app))
+	return nil
+}
+
+func (r *applicationRepo) Update(ctx context.Context, app core.Application) error {
+	if err := r.ApplicationRepo.Update(ctx, app); err != nil {
+		return err
+	}
+	put(ctx, r.index, r.log, core.ApplicationSearchDocs(app))
+	return nil
+}
+
+func (r *applicationRepo) UpdateStatus(ctx context.Context, id string, status core.ApplicationStatus, updatedAt time.Time) error {
+	if err := r.ApplicationRepo.UpdateStatus(ctx, id, status, updatedAt); err != nil {
+		return err
+	}
+	app, err := r.ApplicationRepo.Get(ctx, id)
+	if err != nil {
+		r.log.WarnContext(ctx, "search index update skipped", "application_id", id, "err", err)
+		return nil
+	}
+	put(ctx, r.index, r.log, core.ApplicationSearchDocs(app))
+	return nil
+}
+
+// Policies wraps repo so every issued or expired policy is indexed.
+func Policies(repo core.PolicyRepo, index core.SearchIndex, log *slog.Logger) core.PolicyRepo {
+	return &policyRepo{PolicyRepo: repo, index: index, log: log}
+}
+
+type policyRepo struct {
+	core.PolicyRepo
+	index core.SearchIndex
+	log   *slog.Logger
+}
+
+func (r *policyRepo) Create(ctx context.Context, p core.Policy) error {
+	if err := r.PolicyRepo.Create(ctx, p); err != nil {
+		return err
+	}
+	put(ctx, r.index, r.log, core.PolicySearchDocs(p))
+	return nil
+}
+
+func (r *policyRepo) ExpirePolicies(ctx context.Context, before time.Time) ([]core.Policy, error) {
+	expired, err := r.PolicyRepo.ExpirePolicies(ctx, before)
+	for _, p := range expired {
+		put(ctx, r.index, r.log, core.PolicySearchDocs(p))
+	}
+	return expired, err
+}
+
+func put(ctx context.Context, index core.SearchIndex, log *slog.Logger, docs []core.SearchDoc) {
+	for _, doc := range docs {
+		if err := index.Put(ctx, doc); err != nil {
+			log.WarnContext(ctx, "search index update failed", "kind", doc.Kind, "id", doc.ID, "err", err)
+		}
+	}
+}
+
+// Rebuild indexes every application and policy, paging through the repos.
+// Documents are replaced, so it is safe to run while writes continue.
+func Rebuild(ctx context.Context, index core.SearchIndex, apps core.ApplicationRepo, policies core.PolicyRepo) (int, error) {
+	count := 0
+	page := core.PageRequest{Limit: core.MaxPageLimit}
+	for {
+		res, err := apps.List(ctx, core.ApplicationFilter{}, page)
+		if err != nil {
+			return count, err
+		}
+		for _, app := range res.Items {
+			for _, doc := range core.ApplicationSearchDocs(app) {
+				if err := index.Put(ctx, doc); err != nil {
+					return count, err
+				}
+			}
+			count++
+		}
+		if res.NextCursor == "" {
+			break
+		}
+		page.Cursor = res.NextCursor
+	}
+
+	page.Cursor = ""
+	for {
+		res, err := policies.List(ctx, core.PolicyFilter{}, page)
+		if err != nil {
+			return count, err
+		}
+		for _, p := range res.Items {
+			for _, doc := range core.PolicySearchDocs(p) {
+				if err := index.Put(ctx, doc); err != nil {
+					return count, err
+				}
+			}
+			count++
+		}
+		if res.NextCursor == "" {
+			break
+		}
+		page.Cursor = res.NextCursor
+	}
+	return count, nil
+}
+
+// StartRebuilding rebuilds the index every interval until ctx is done.
+func StartRebuilding(ctx context.Context, index core.SearchIndex, apps core.ApplicationRepo, policies core.PolicyRepo, interval time.Duration, log *slog.Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := Rebuild(ctx, index, apps, policies)
+				if err != nil {
+					log.Error("search index rebuild failed", "err", err)
+					continue
+				}
+				log.Debug("search index rebuilt", "documents", n)
+			}
+		}
+	}()
+}
@@ -0,0 +1,172 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// MemoryIndex is an in-process inverted index, for DynamoDB and single
+// instance deployments. Each instance holds its own copy, built from the
+// repos at startup and kept current by its own writes and periodic rebuilds.
+type MemoryIndex struct {
+	mu       sync.Mutex
+	docs     map[string]core.SearchDoc      // By doc key
+	postings map[string]map[string]struct{} // Term -> doc keys
+	docTerms map[string][]string            // Doc key -> its terms, to replace them
+	sorted   []string                       // Every term, sorted for prefix lookups; nil when stale
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		docs:     make(map[string]core.SearchDoc),
+		postings: make(map[string]map[string]struct{}),
+		docTerms: make(map[string][]string),
+	}
+}
+
+func (idx *MemoryIndex) Put(_ context.Context, doc core.SearchDoc) error {
+	key := string(doc.Kind) + "|" + doc.ID
+	terms := docTerms(doc)
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if prev, ok := idx.docs[key]; ok && prev.UpdatedAt.After(doc.UpdatedAt) {
+		return nil
+	}
+	for _, t := range idx.docTerms[key] {
+		delete(idx.postings[t], key)
+		if len(idx.postings[t]) == 0 {
+			delete(idx.postings, t)
+			idx.sorted = nil
+		}
+	}
+	for _, t := range terms {
+		if idx.postings[t] == nil {
+			idx.postings[t] = make(map[string]struct{})
+			idx.sorted = nil
+		}
+		idx.postings[t][key] = struct{}{}
+	}
+	idx.docs[key] = doc
+	idx.docTerms[key] = terms
+	return nil
+}
+
+// Search scores each document by its query terms: 1 for an exact term,
+// 0.5 for a prefix. A document must match every term.
+func (idx *MemoryIndex) Search(_ context.Context, q core.SearchQuery, page core.PageRequest) (core.Page[core.SearchHit], error) {
+	offset, err := core.DecodeSearchCursor(page.Cursor)
+	if err != nil {
+		return core.Page[core.SearchHit]{}, err
+	}
+
+	idx.mu.Lock()
+	if idx.sorted == nil {
+		idx.sorted = make([]string, 0, len(idx.postings))
+		for t := range idx.postings {
+			idx.sorted = append(idx.sorted, t)
+		}
+		sort.Strings(idx.sorted)
+	}
+
+	var scores map[string]float64
+	for _, prefix := range queryPrefixes(q) {
+		matched := make(map[string]float64)
+		for i := sort.SearchStrings(idx.sorted, prefix); i < len(idx.sorted) && strings.HasPrefix(idx.sorted[i], prefix); i++ {
+			score := 0.5
+			if idx.sorted[i] == prefix {
+				score = 1
+			}
+			for key := range idx.postings[idx.sorted[i]] {
+				matched[key] = max(matched[key], score)
+			}
+		}
+
+		if scores == nil {
+			scores = matched
+			continue
+		}
+		for key := range scores {
+			if s, ok := matched[key]; ok {
+				scores[key] += s
+			} else {
+				delete(scores, key)
+			}
+		}
+	}
+
+	hits := make([]core.SearchHit, 0, len(scores))
+	for key, score := range scores {
+		if doc := idx.docs[key]; q.Matches(doc.Kind) {
+			hits = append(hits, core.SearchHit{SearchDoc: doc, Score: score})
+		}
+	}
+	idx.mu.Unlock()
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].UpdatedAt.Equal(hits[j].UpdatedAt) {
+			return hits[i].UpdatedAt.After(hits[j].UpdatedAt)
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	return pageOf(hits, offset, page.Limit)
+}
+
+// pageOf cuts one page out of ranked hits.
+func pageOf(hits []core.SearchHit, offset, limit int) (core.Page[core.SearchHit], error) {
+	result := core.Page[core.SearchHit]{Items: []core.SearchHit{}}
+	if offset >= len(hits) {
+		return result, nil
+	}
+	hits = hits[offset:]
+	if len(hits) > limit {
+		next, err := core.EncodeCursor(core.SearchCursor{Offset: offset + limit})
+		if err != nil {
+			return core.Page[core.SearchHit]{}, err
+		}
+		hits, result.NextCursor = hits[:limit], next
+	}
+	result.Items = hits
+	return result, nil
+}
+
+// docTerms are the terms a document is found by, prefixed with their field.
+func docTerms(d core.SearchDoc) []string {
+	var terms []string
+	for _, name := range strings.Fields(strings.ToLower(d.FirstName + " " + d.LastName)) {
+		terms = append(terms, "name:"+name)
+	}
+	if d.Email != "" {
+		terms = append(terms, "email:"+strings.ToLower(d.Email))
+	}
+	if d.DateOfBirth != "" {
+		terms = append(terms, "dob:"+d.DateOfBirth)
+	}
+	if d.PolicyNumber != "" {
+		terms = append(terms, "policy:"+strings.ToLower(d.PolicyNumber))
+	}
+	return terms
+}
+
+func queryPrefixes(q core.SearchQuery) []string {
+	field := map[core.SearchField]string{
+		core.SearchByName:         "name:",
+		core.SearchByEmail:        "email:",
+		core.SearchByDateOfBirth:  "dob:",
+		core.SearchByPolicyNumber: "policy:",
+	}[q.Field]
+
+	prefixes := make([]string, len(q.Terms))
+	for i, t := range q.Terms {
+		prefixes[i] = field + t
+	}
+	return prefixes
+}
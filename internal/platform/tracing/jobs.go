@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// traceContext reads and writes the traceparent stored on jobs.
+var traceContext = propagation.TraceContext{}
+
+// Jobs wraps repo so queued jobs carry the trace of the request that queued
+// them: a submit or accept, or an admin's retry. Job then links the worker's
+// span back to it.
+func Jobs(repo core.JobRepo) core.JobRepo {
+	return &jobRepo{JobRepo: repo}
+}
+
+type jobRepo struct {
+	core.JobRepo
+}
+
+func (r *jobRepo) Enqueue(ctx context.Context, job core.Job) error {
+	job.TraceParent = traceParent(ctx)
+	return r.JobRepo.Enqueue(ctx, job)
+}
+
+func (r *jobRepo) Update(ctx context.Context, job core.Job, from core.JobStatus) error {
+	if job.Status == core.JobStatusQueued {
+		if tp := traceParent(ctx); tp != "" {
+			job.TraceParent = tp
+		}
+	}
+	return r.JobRepo.Update(ctx, job, from)
+}
+
+// traceParent returns the W3C traceparent of ctx's span, or "" if it has
+// none or isn't sampled.
+func traceParent(ctx context.Context) string {
+	if !trace.SpanContextFromContext(ctx).IsSampled() {
+		return ""
+	}
+	carrier := propagation.MapCarrier{}
+	traceContext.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// Job runs fn for one attempt at job in a span of its own. The span starts
+// a new trace, linked to the one that queued the job, since a job can
+// outlive its request by minutes and be attempted several times.
+func Job(ctx context.Context, job core.Job, fn func(context.Context) error) error {
+	opts := []trace.SpanStartOption{
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("job.id", job.ID),
+			attribute.String("job.kind", string(job.Kind)),
+			attribute.String("job.subject_id", job.SubjectID),
+			attribute.Int("job.attempt", job.Attempts+1),
+		),
+	}
+	if job.TraceParent != "" {
+		queued := traceContext.Extract(context.Background(), propagation.MapCarrier{"traceparent": job.TraceParent})
+		if sc := trace.SpanContextFromContext(queued); sc.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+		}
+	}
+
+	ctx, span := tracer.Start(ctx, "job "+string(job.Kind), opts...)
+	return end(span, fn(ctx))
+}
@@ -0,0 +1,385 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// The wrappers below start a span for every core service method, named
+// after it, e.g. "OfferService.Accept", with the IDs it was called with.
+// Store spans nest under them.
+
+// call runs fn in a span named name.
+func call[T any](ctx context.Context, name string, fn func(context.Context) (T, error), attrs ...attribute.KeyValue) (T, error) {
+	ctx, span := tracer.Start(ctx, name)
+	span.SetAttributes(attrs...)
+	v, err := fn(ctx)
+	return v, end(span, err)
+}
+
+func id(key, v string) attribute.KeyValue {
+	return attribute.String(key, v)
+}
+
+// Quotes wraps svc with spans.
+func Quotes(svc core.QuoteService) core.QuoteService {
+	return &quoteService{svc}
+}
+
+type quoteService struct {
+	svc core.QuoteService
+}
+
+func (s *quoteService) Price(ctx context.Context, in core.QuoteInput) (core.Quote, error) {
+	return call(ctx, "QuoteService.Price", func(ctx context.Context) (core.Quote, error) {
+		return s.svc.Price(ctx, in)
+	}, id("product.slug", in.ProductSlug))
+}
+
+func (s *quoteService) List(ctx context.Context, filter core.QuoteFilter, page core.PageRequest) (core.Page[core.Quote], error) {
+	return call(ctx, "QuoteService.List", func(ctx context.Context) (core.Page[core.Quote], error) {
+		return s.svc.List(ctx, filter, page)
+	})
+}
+
+// Applications wraps svc with spans.
+func Applications(svc core.ApplicationService) core.ApplicationService {
+	return &applicationService{svc}
+}
+
+type applicationService struct {
+	svc core.ApplicationService
+}
+
+func (s *applicationService) Create(ctx context.Context, in core.ApplicationInput) (core.Application, error) {
+	return call(ctx, "ApplicationService.Create", func(ctx context.Context) (core.Application, error) {
+		return s.svc.Create(ctx, in)
+	}, id("quote.id", in.QuoteID))
+}
+
+func (s *applicationService) Get(ctx context.Context, appID string) (core.Application, error) {
+	return call(ctx, "ApplicationService.Get", func(ctx context.Context) (core.Application, error) {
+		return s.svc.Get(ctx, appID)
+	}, id("application.id", appID))
+}
+
+func (s *applicationService) Patch(ctx context.Context, appID string, patch core.ApplicationPatch) (core.Application, error) {
+	return call(ctx, "ApplicationService.Patch", func(ctx context.Context) (core.Application, error) {
+		return s.svc.Patch(ctx, appID, patch)
+	}, id("application.id", appID))
+}
+
+func (s *applicationService) Submit(ctx context.Context, appID string) (core.Application, error) {
+	return call(ctx, "ApplicationService.Submit", func(ctx context.Context) (core.Application, error) {
+		return s.svc.Submit(ctx, appID)
+	}, id("application.id", appID))
+}
+
+func (s *applicationService) List(ctx context.Context, filter core.ApplicationFilter, page core.PageRequest) (core.Page[core.Application], error) {
+	return call(ctx, "ApplicationService.List", func(ctx context.Context) (core.Page[core.Application], error) {
+		return s.svc.List(ctx, filter, page)
+	})
+}
+
+func (s *applicationService) History(ctx context.Context, appID string, page core.PageRequest) (core.Page[core.AuditEntry], error) {
+	return call(ctx, "ApplicationService.History", func(ctx context.Context) (core.Page[core.AuditEntry], error) {
+		return s.svc.History(ctx, appID, page)
+	}, id("application.id", appID))
+}
+
+// Underwriting wraps svc with spans.
+func Underwriting(svc core.UnderwritingService) core.UnderwritingService {
+	return &underwritingService{svc}
+}
+
+type underwritingService struct {
+	svc core.UnderwritingService
+}
+
+func (s *underwritingService) ProcessApplication(ctx context.Context, appID string) (core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.ProcessApplication", func(ctx context.Context) (core.UnderwritingCase, error) {
+		return s.svc.ProcessApplication(ctx, appID)
+	}, id("application.id", appID))
+}
+
+func (s *underwritingService) MakeDecision(ctx context.Context, caseID string, input core.UWDecisionInput) (core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.MakeDecision", func(ctx context.Context) (core.UnderwritingCase, error) {
+		return s.svc.MakeDecision(ctx, caseID, input)
+	}, id("underwriting_case.id", caseID))
+}
+
+func (s *underwritingService) ConfirmDecision(ctx context.Context, caseID string, input core.UWConfirmInput) (core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.ConfirmDecision", func(ctx context.Context) (core.UnderwritingCase, error) {
+		return s.svc.ConfirmDecision(ctx, caseID, input)
+	}, id("underwriting_case.id", caseID))
+}
+
+func (s *underwritingService) GetCase(ctx context.Context, caseID string) (core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.GetCase", func(ctx context.Context) (core.UnderwritingCase, error) {
+		return s.svc.GetCase(ctx, caseID)
+	}, id("underwriting_case.id", caseID))
+}
+
+func (s *underwritingService) GetByApplicationID(ctx context.Context, appID string) (core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.GetByApplicationID", func(ctx context.Context) (core.UnderwritingCase, error) {
+		return s.svc.GetByApplicationID(ctx, appID)
+	}, id("application.id", appID))
+}
+
+func (s *underwritingService) ListReferred(ctx context.Context, limit int) ([]core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.ListReferred", func(ctx context.Context) ([]core.UnderwritingCase, error) {
+		return s.svc.ListReferred(ctx, limit)
+	})
+}
+
+func (s *underwritingService) ListCases(ctx context.Context, filter core.UWCaseFilter, page core.PageRequest) (core.Page[core.UnderwritingCase], error) {
+	return call(ctx, "UnderwritingService.ListCases", func(ctx context.Context) (core.Page[core.UnderwritingCase], error) {
+		return s.svc.ListCases(ctx, filter, page)
+	})
+}
+
+func (s *underwritingService) ClaimCase(ctx context.Context, caseID string) (core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.ClaimCase", func(ctx context.Context) (core.UnderwritingCase, error) {
+		return s.svc.ClaimCase(ctx, caseID)
+	}, id("underwriting_case.id", caseID))
+}
+
+func (s *underwritingService) AssignCase(ctx context.Context, caseID, assignee string) (core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.AssignCase", func(ctx context.Context) (core.UnderwritingCase, error) {
+		return s.svc.AssignCase(ctx, caseID, assignee)
+	}, id("underwriting_case.id", caseID))
+}
+
+func (s *underwritingService) EscalateBreached(ctx context.Context, limit int) ([]core.UnderwritingCase, error) {
+	return call(ctx, "UnderwritingService.EscalateBreached", func(ctx context.Context) ([]core.UnderwritingCase, error) {
+		return s.svc.EscalateBreached(ctx, limit)
+	})
+}
+
+func (s *underwritingService) History(ctx context.Context, caseID string, page core.PageRequest) (core.Page[core.AuditEntry], error) {
+	return call(ctx, "UnderwritingService.History", func(ctx context.Context) (core.Page[core.AuditEntry], error) {
+		return s.svc.History(ctx, caseID, page)
+	}, id("underwriting_case.id", caseID))
+}
+
+// Offers wraps svc with spans.
+func Offers(svc core.OfferService) core.OfferService {
+	return &offerService{svc}
+}
+
+type offerService struct {
+	svc core.OfferService
+}
+
+func (s *offerService) GenerateOffer(ctx context.Context, appID string) (core.Offer, error) {
+	return call(ctx, "OfferService.GenerateOffer", func(ctx context.Context) (core.Offer, error) {
+		return s.svc.GenerateOffer(ctx, appID)
+	}, id("application.id", appID))
+}
+
+func (s *offerService) Get(ctx context.Context, offerID string) (core.Offer, error) {
+	return call(ctx, "OfferService.Get", func(ctx context.Context) (core.Offer, error) {
+		return s.svc.Get(ctx, offerID)
+	}, id("offer.id", offerID))
+}
+
+func (s *offerService) GetByApplicationID(ctx context.Context, appID string) (core.Offer, error) {
+	return call(ctx, "OfferService.GetByApplicationID", func(ctx context.Context) (core.Offer, error) {
+		return s.svc.GetByApplicationID(ctx, appID)
+	}, id("application.id", appID))
+}
+
+func (s *offerService) Accept(ctx context.Context, offerID string) (core.Offer, error) {
+	return call(ctx, "OfferService.Accept", func(ctx context.Context) (core.Offer, error) {
+		return s.svc.Accept(ctx, offerID)
+	}, id("offer.id", offerID))
+}
+
+func (s *offerService) Decline(ctx context.Context, offerID string) (core.Offer, error) {
+	return call(ctx, "OfferService.Decline", func(ctx context.Context) (core.Offer, error) {
+		return s.svc.Decline(ctx, offerID)
+	}, id("offer.id", offerID))
+}
+
+func (s *offerService) List(ctx context.Context, filter core.OfferFilter, page core.PageRequest) (core.Page[core.Offer], error) {
+	return call(ctx, "OfferService.List", func(ctx context.Context) (core.Page[core.Offer], error) {
+		return s.svc.List(ctx, filter, page)
+	})
+}
+
+func (s *offerService) History(ctx context.Context, offerID string, page core.PageRequest) (core.Page[core.AuditEntry], error) {
+	return call(ctx, "OfferService.History", func(ctx context.Context) (core.Page[core.AuditEntry], error) {
+		return s.svc.History(ctx, offerID, page)
+	}, id("offer.id", offerID))
+}
+
+// Policies wraps svc with spans.
+func Policies(svc core.PolicyService) core.PolicyService {
+	return &policyService{svc}
+}
+
+type policyService struct {
+	svc core.PolicyService
+}
+
+func (s *policyService) IssueFromOffer(ctx context.Context, offerID string) (core.Policy, error) {
+	return call(ctx, "PolicyService.IssueFromOffer", func(ctx context.Context) (core.Policy, error) {
+		return s.svc.IssueFromOffer(ctx, offerID)
+	}, id("offer.id", offerID))
+}
+
+func (s *policyService) Get(ctx context.Context, policyID string) (core.Policy, error) {
+	return call(ctx, "PolicyService.Get", func(ctx context.Context) (core.Policy, error) {
+		return s.svc.Get(ctx, policyID)
+	}, id("policy.id", policyID))
+}
+
+func (s *policyService) GetByNumber(ctx context.Context, number string) (core.Policy, error) {
+	return call(ctx, "PolicyService.GetByNumber", func(ctx context.Context) (core.Policy, error) {
+		return s.svc.GetByNumber(ctx, number)
+	}, id("policy.number", number))
+}
+
+func (s *policyService) List(ctx context.Context, filter core.PolicyFilter, page core.PageRequest) (core.Page[core.Policy], error) {
+	return call(ctx, "PolicyService.List", func(ctx context.Context) (core.Page[core.Policy], error) {
+		return s.svc.List(ctx, filter, page)
+	})
+}
+
+func (s *policyService) History(ctx context.Context, policyID string, page core.PageRequest) (core.Page[core.AuditEntry], error) {
+	return call(ctx, "PolicyService.History", func(ctx context.Context) (core.Page[core.AuditEntry], error) {
+		return s.svc.History(ctx, policyID, page)
+	}, id("policy.id", policyID))
+}
+
+// APIKeys wraps svc with spans. Keys themselves are never recorded.
+func APIKeys(svc core.APIKeyService) core.APIKeyService {
+	return &apiKeyService{svc}
+}
+
+type apiKeyService struct {
+	svc core.APIKeyService
+}
+
+func (s *apiKeyService) Create(ctx context.Context, in core.APIKeyInput) (core.IssuedAPIKey, error) {
+	return call(ctx, "APIKeyService.Create", func(ctx context.Context) (core.IssuedAPIKey, error) {
+		return s.svc.Create(ctx, in)
+	})
+}
+
+func (s *apiKeyService) Get(ctx context.Context, keyID string) (core.APIKey, error) {
+	return call(ctx, "APIKeyService.Get", func(ctx context.Context) (core.APIKey, error) {
+		return s.svc.Get(ctx, keyID)
+	}, id("api_key.id", keyID))
+}
+
+func (s *apiKeyService) List(ctx context.Context) ([]core.APIKey, error) {
+	return call(ctx, "APIKeyService.List", func(ctx context.Context) ([]core.APIKey, error) {
+		return s.svc.List(ctx)
+	})
+}
+
+func (s *apiKeyService) Rotate(ctx context.Context, keyID string, grace time.Duration) (core.IssuedAPIKey, error) {
+	return call(ctx, "APIKeyService.Rotate", func(ctx context.Context) (core.IssuedAPIKey, error) {
+		return s.svc.Rotate(ctx, keyID, grace)
+	}, id("api_key.id", keyID))
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, keyID string) (core.APIKey, error) {
+	return call(ctx, "APIKeyService.Revoke", func(ctx context.Context) (core.APIKey, error) {
+		return s.svc.Revoke(ctx, keyID)
+	}, id("api_key.id", keyID))
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (core.Principal, error) {
+	return call(ctx, "APIKeyService.Authenticate", func(ctx context.Context) (core.Principal, error) {
+		return s.svc.Authenticate(ctx, key)
+	})
+}
+
+func (s *apiKeyService) Bootstrap(ctx context.Context, name, key string) error {
+	_, err := call(ctx, "APIKeyService.Bootstrap", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.svc.Bootstrap(ctx, name, key)
+	})
+	return err
+}
+
+// Search wraps svc with spans. Search text is left out, as it often holds
+// names and emails.
+func Search(svc core.SearchService) core.SearchService {
+	return &searchService{svc}
+}
+
+type searchService struct {
+	svc core.SearchService
+}
+
+func (s *searchService) Search(ctx context.Context, text string, kinds []core.SearchKind, page core.PageRequest) (core.Page[core.SearchHit], error) {
+	return call(ctx, "SearchService.Search", func(ctx context.Context) (core.Page[core.SearchHit], error) {
+		return s.svc.Search(ctx, text, kinds, page)
+	})
+}
+
+// JobAdmin wraps the admin job service with spans.
+func JobAdmin(svc core.JobService) core.JobService {
+	return &jobService{svc}
+}
+
+type jobService struct {
+	svc core.JobService
+}
+
+func (s *jobService) List(ctx context.Context, filter core.JobFilter, page core.PageRequest) (core.Page[core.Job], error) {
+	return call(ctx, "JobService.List", func(ctx context.Context) (core.Page[core.Job], error) {
+		return s.svc.List(ctx, filter, page)
+	})
+}
+
+func (s *jobService) Get(ctx context.Context, jobID string) (core.Job, error) {
+	return call(ctx, "JobService.Get", func(ctx context.Context) (core.Job, error) {
+		return s.svc.Get(ctx, jobID)
+	}, id("job.id", jobID))
+}
+
+func (s *jobService) Retry(ctx context.Context, jobID string) (core.Job, error) {
+	return call(ctx, "JobService.Retry", func(ctx context.Context) (core.Job, error) {
+		return s.svc.Retry(ctx, jobID)
+	}, id("job.id", jobID))
+}
+
+func (s *jobService) Discard(ctx context.Context, jobID string) (core.Job, error) {
+	return call(ctx, "JobService.Discard", func(ctx context.Context) (core.Job, error) {
+		return s.svc.Discard(ctx, jobID)
+	}, id("job.id", jobID))
+}
+
+// Maintenance wraps svc with spans.
+func Maintenance(svc core.MaintenanceService) core.MaintenanceService {
+	return &maintenanceService{svc}
+}
+
+type maintenanceService struct {
+	svc core.MaintenanceService
+}
+
+func (s *maintenanceService) ExpireOffers(ctx context.Context) (int64, error) {
+	return call(ctx, "MaintenanceService.ExpireOffers", s.svc.ExpireOffers)
+}
+
+func (s *maintenanceService) ExpireQuotes(ctx context.Context) (int64, error) {
+	return call(ctx, "MaintenanceService.ExpireQuotes", s.svc.ExpireQuotes)
+}
+
+func (s *maintenanceService) MaturePolicies(ctx context.Context) (int64, error) {
+	return call(ctx, "MaintenanceService.MaturePolicies", s.svc.MaturePolicies)
+}
+
+func (s *maintenanceService) Runs(ctx context.Context, filter core.ScheduledRunFilter, page core.PageRequest) (core.Page[core.ScheduledRun], error) {
+	return call(ctx, "MaintenanceService.Runs", func(ctx context.Context) (core.Page[core.ScheduledRun], error) {
+		return s.svc.Runs(ctx, filter, page)
+	})
+}
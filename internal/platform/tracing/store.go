@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StoreSpan starts a client span for a database operation, e.g. "find
+// policies", and returns a function that ends it with the operation's
+// error. The stores call it from their client hooks.
+//
+// Spans are only started inside a trace, i.e. for work done on behalf of a
+// request or job. Polls, sweeps and change feeds would otherwise each start
+// a trace of their own.
+func StoreSpan(ctx context.Context, store, operation, collection string) (context.Context, func(error)) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, func(error) {}
+	}
+
+	name := operation
+	if collection != "" {
+		name += " " + collection
+	}
+	ctx, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(store),
+			semconv.DBOperationName(operation),
+			semconv.DBCollectionName(collection),
+		),
+	)
+	return ctx, func(err error) { end(span, err) }
+}
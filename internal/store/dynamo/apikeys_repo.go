@@ -0,0 +1,250 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type APIKeyItem struct {
+	ID         string   `dynamodbav:"id"`
+	Name       string   `dynamodbav:"name"`
+	Prefix     string   `dynamodbav:"prefix"`
+	Hash       string   `dynamodbav:"hash"`
+	Scopes     []string `dynamodbav:"scopes"`
+	Roles      []string `dynamodbav:"roles"`
+	CreatedBy  string   `dynamodbav:"created_by"`
+	CreatedAt  string   `dynamodbav:"created_at"`
+	ExpiresAt  string   `dynamodbav:"expires_at,omitempty"`
+	LastUsedAt string   `dynamodbav:"last_used_at,omitempty"`
+	RevokedAt  string   `dynamodbav:"revoked_at,omitempty"`
+	RotatedTo  string   `dynamodbav:"rotated_to,omitempty"`
+}
+
+func (i APIKeyItem) ToCore() core.APIKey {
+	createdAt, _ := time.Parse(time.RFC3339, i.CreatedAt)
+	roles := make([]core.Role, len(i.Roles))
+	for n, r := range i.Roles {
+		roles[n] = core.Role(r)
+	}
+	return core.APIKey{
+		ID:         i.ID,
+		Name:       i.Name,
+		Prefix:     i.Prefix,
+		Hash:       i.Hash,
+		Scopes:     i.Scopes,
+		Roles:      roles,
+		CreatedBy:  i.CreatedBy,
+		CreatedAt:  createdAt,
+		ExpiresAt:  parseOptionalTime(i.ExpiresAt),
+		LastUsedAt: parseOptionalTime(i.LastUsedAt),
+		RevokedAt:  parseOptionalTime(i.RevokedAt),
+		RotatedTo:  i.RotatedTo,
+	}
+}
+
+func apiKeyItemFromCore(k core.APIKey) APIKeyItem {
+	roles := make([]string, len(k.Roles))
+	for n, r := range k.Roles {
+		roles[n] = string(r)
+	}
+	return APIKeyItem{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Hash:       k.Hash,
+		Scopes:     k.Scopes,
+		Roles:      roles,
+		CreatedBy:  k.CreatedBy,
+		CreatedAt:  k.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:  formatOptionalTime(k.ExpiresAt),
+		LastUsedAt: formatOptionalTime(k.LastUsedAt),
+		RevokedAt:  formatOptionalTime(k.RevokedAt),
+		RotatedTo:  k.RotatedTo,
+	}
+}
+
+type APIKeyRepo struct {
+	client *dynamodb.Client
+}
+
+func NewAPIKeyRepo(client *dynamodb.Client) *APIKeyRepo {
+	return &APIKeyRepo{client: client}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, key core.APIKey) error {
+	av, err := attributevalue.MarshalMap(apiKeyItemFromCore(key))
+	if err != nil {
+		return fmt.Errorf("api_keys.marshal: %w", err)
+	}
+
+	cond := expression.AttributeNotExists(expression.Name("id"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("api_keys.buildExpr: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(TableAPIKeys),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return core.ErrAPIKeyExists
+		}
+		return fmt.Errorf("api_keys.putItem: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepo) Get(ctx context.Context, id string) (core.APIKey, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(TableAPIKeys),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+	})
+	if err != nil {
+		return core.APIKey{}, fmt.Errorf("api_keys.getItem: %w", err)
+	}
+
+	if out.Item == nil {
+		return core.APIKey{}, core.ErrAPIKeyNotFound
+	}
+
+	var item APIKeyItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return core.APIKey{}, fmt.Errorf("api_keys.unmarshal: %w", err)
+	}
+
+	return item.ToCore(), nil
+}
+
+func (r *APIKeyRepo) GetByHash(ctx context.Context, hash string) (core.APIKey, error) {
+	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(TableAPIKeys),
+		IndexName:              aws.String(GSIAPIKeysHash),
+		KeyConditionExpression: aws.String("#hash = :hash"),
+		ExpressionAttributeNames: map[string]string{
+			"#hash": "hash",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":hash": &types.AttributeValueMemberS{Value: hash},
+		},
+		Limit: aws.Int32(1),
+	})
+	if err != nil {
+		return core.APIKey{}, fmt.Errorf("api_keys.query: %w", err)
+	}
+
+	if len(out.Items) == 0 {
+		return core.APIKey{}, core.ErrAPIKeyNotFound
+	}
+
+	var item APIKeyItem
+	if err := attributevalue.UnmarshalMap(out.Items[0], &item); err != nil {
+		return core.APIKey{}, fmt.Errorf("api_keys.unmarshal: %w", err)
+	}
+
+	return item.ToCore(), nil
+}
+
+func (r *APIKeyRepo) List(ctx context.Context) ([]core.APIKey, error) {
+	var items []APIKeyItem
+	paginator := dynamodb.NewScanPaginator(r.client, &dynamodb.ScanInput{
+		TableName: aws.String(TableAPIKeys),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("api_keys.scan: %w", err)
+		}
+		var page []APIKeyItem
+		if err := attributevalue.UnmarshalListOfMaps(out.Items, &page); err != nil {
+			return nil, fmt.Errorf("api_keys.unmarshal: %w", err)
+		}
+		items = append(items, page...)
+	}
+
+	keys := make([]core.APIKey, len(items))
+	for i, item := range items {
+		keys[i] = item.ToCore()
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+func (r *APIKeyRepo) Update(ctx context.Context, key core.APIKey) error {
+	av, err := attributevalue.MarshalMap(apiKeyItemFromCore(key))
+	if err != nil {
+		return fmt.Errorf("api_keys.marshal: %w", err)
+	}
+
+	cond := expression.AttributeExists(expression.Name("id"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("api_keys.buildExpr: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(TableAPIKeys),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return core.ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("api_keys.putItem: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepo) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	update := expression.Set(expression.Name("last_used_at"), expression.Value(at.Format(time.RFC3339)))
+	cond := expression.AttributeExists(expression.Name("id"))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("api_keys.buildExpr: %w", err)
+	}
+
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(TableAPIKeys),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return core.ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("api_keys.updateItem: %w", err)
+	}
+	return nil
+}
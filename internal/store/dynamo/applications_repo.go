@@ -35,9 +35,11 @@ type ApplicationItem struct {
 	MonthlyPremium float64       `dynamodbav:"monthly_premium"`
 	Applicant      ApplicantItem `dynamodbav:"applicant"`
 	Status         string        `dynamodbav:"status"`
-	CreatedAt      string        `dynamodbav:"created_at"`
+	OwnerID        string        `dynamodbav:"owner_id,omitempty"`
+	CreatedAt      string        `dynamodbav:"created_at"` // RFC3339 in UTC, so ranges compare as strings
 	UpdatedAt      string        `dynamodbav:"updated_at"`
 	SubmittedAt    string        `dynamodbav:"submitted_at,omitempty"`
+	Version        int           `dynamodbav:"version"` // Missing on items written before versions, i.e. 0
 }
 
 func (i ApplicationItem) ToCore() core.Application {
@@ -66,9 +68,11 @@ func (i ApplicationItem) ToCore() core.Application {
 			State:       i.Applicant.State,
 		},
 		Status:      core.ApplicationStatus(i.Status),
+		OwnerID:     i.OwnerID,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 		SubmittedAt: submittedAt,
+		Version:     i.Version,
 	}
 }
 
@@ -91,8 +95,10 @@ func applicationItemFromCore(a core.Application) ApplicationItem {
 			State:       a.Applicant.State,
 		},
 		Status:    string(a.Status),
-		CreatedAt: a.CreatedAt.Format(time.RFC3339),
+		OwnerID:   a.OwnerID,
+		CreatedAt: a.CreatedAt.UTC().Format(time.RFC3339),
 		UpdatedAt: a.UpdatedAt.Format(time.RFC3339),
+		Version:   a.Version,
 	}
 	if a.SubmittedAt != nil {
 		item.SubmittedAt = a.SubmittedAt.Format(time.RFC3339)
@@ -162,14 +168,23 @@ func (r *ApplicationRepo) Get(ctx context.Context, id string) (core.Application,
 	return item.ToCore(), nil
 }
 
+func (r *ApplicationRepo) GetMany(ctx context.Context, ids []string) ([]core.Application, error) {
+	items, err := batchGet[ApplicationItem](ctx, r.client, TableApplications, ids)
+	if err != nil {
+		return nil, fmt.Errorf("applications.batchGet: %w", err)
+	}
+	return items, nil
+}
+
 func (r *ApplicationRepo) Update(ctx context.Context, app core.Application) error {
 	item := applicationItemFromCore(app)
+	item.Version++
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		return fmt.Errorf("applications.marshal: %w", err)
 	}
 
-	cond := expression.AttributeExists(expression.Name("id"))
+	cond := atVersion(app.Version)
 	expr, err := expression.NewBuilder().WithCondition(cond).Build()
 	if err != nil {
 		return fmt.Errorf("applications.buildExpr: %w", err)
@@ -185,7 +200,7 @@ func (r *ApplicationRepo) Update(ctx context.Context, app core.Application) erro
 	if err != nil {
 		var ccf *types.ConditionalCheckFailedException
 		if errors.As(err, &ccf) {
-			return core.ErrApplicationNotFound
+			return versionFailure(ctx, app.ID, r.Get)
 		}
 		return fmt.Errorf("applications.putItem: %w", err)
 	}
@@ -198,7 +213,7 @@ func (r *ApplicationRepo) UpdateStatus(ctx context.Context, id string, status co
 		expression.Name("status"), expression.Value(string(status)),
 	).Set(
 		expression.Name("updated_at"), expression.Value(updatedAt.Format(time.RFC3339)),
-	)
+	).Add(expression.Name("version"), expression.Value(1))
 	cond := expression.AttributeExists(expression.Name("id"))
 	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(cond).Build()
 	if err != nil {
@@ -254,3 +269,17 @@ func (r *ApplicationRepo) FindByStatus(ctx context.Context, status core.Applicat
 	}
 	return apps, nil
 }
+
+func (r *ApplicationRepo) List(ctx context.Context, filter core.ApplicationFilter, page core.PageRequest) (core.Page[core.Application], error) {
+	q := listQuery{table: TableApplications}
+	if filter.Status != "" {
+		key := expression.Key("status").Equal(expression.Value(string(filter.Status)))
+		q.index, q.key = GSIApplicationsStatus, &key
+	}
+	q.whereEqual("product_slug", filter.ProductSlug)
+	q.whereEqual("applicant.email", filter.ApplicantEmail)
+	q.whereEqual("owner_id", filter.OwnerID)
+	q.whereTime("created_at", filter.Created)
+
+	return listPage(ctx, r.client, q, page, ApplicationItem.ToCore)
+}
@@ -0,0 +1,129 @@
+package dynamo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type AuditItem struct {
+	ID         string            `dynamodbav:"id"`
+	Subject    string            `dynamodbav:"subject"` // resource|resource_id, partition of GSIAuditSubject
+	Resource   string            `dynamodbav:"resource"`
+	ResourceID string            `dynamodbav:"resource_id"`
+	Action     string            `dynamodbav:"action"`
+	Actor      string            `dynamodbav:"actor"`
+	APIKeyID   string            `dynamodbav:"api_key_id,omitempty"`
+	Changes    []AuditChangeItem `dynamodbav:"changes"`
+	RequestID  string            `dynamodbav:"request_id,omitempty"`
+	Reason     string            `dynamodbav:"reason,omitempty"`
+	At         string            `dynamodbav:"at"` // RFC3339Nano in UTC
+}
+
+// AuditChangeItem keeps the values as JSON text, exactly as they were.
+type AuditChangeItem struct {
+	Path   string `dynamodbav:"path"`
+	Before string `dynamodbav:"before,omitempty"`
+	After  string `dynamodbav:"after,omitempty"`
+}
+
+func auditSubject(resource core.AuditResource, resourceID string) string {
+	return string(resource) + "|" + resourceID
+}
+
+func (i AuditItem) ToCore() core.AuditEntry {
+	at, _ := time.Parse(time.RFC3339Nano, i.At)
+	changes := make([]core.AuditChange, len(i.Changes))
+	for n, c := range i.Changes {
+		changes[n] = core.AuditChange{Path: c.Path, Before: rawJSON(c.Before), After: rawJSON(c.After)}
+	}
+	return core.AuditEntry{
+		ID:         i.ID,
+		Resource:   core.AuditResource(i.Resource),
+		ResourceID: i.ResourceID,
+		Action:     core.AuditAction(i.Action),
+		Actor:      i.Actor,
+		APIKeyID:   i.APIKeyID,
+		Changes:    changes,
+		RequestID:  i.RequestID,
+		Reason:     i.Reason,
+		At:         at,
+	}
+}
+
+func auditItemFromCore(e core.AuditEntry) AuditItem {
+	changes := make([]AuditChangeItem, len(e.Changes))
+	for n, c := range e.Changes {
+		changes[n] = AuditChangeItem{Path: c.Path, Before: string(c.Before), After: string(c.After)}
+	}
+	return AuditItem{
+		ID:         e.ID,
+		Subject:    auditSubject(e.Resource, e.ResourceID),
+		Resource:   string(e.Resource),
+		ResourceID: e.ResourceID,
+		Action:     string(e.Action),
+		Actor:      e.Actor,
+		APIKeyID:   e.APIKeyID,
+		Changes:    changes,
+		RequestID:  e.RequestID,
+		Reason:     e.Reason,
+		At:         e.At.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// rawJSON turns stored JSON text back into a value; empty means absent.
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+	return json.RawMessage(s)
+}
+
+// AuditRepo only puts and reads; nothing in the app updates or deletes an
+// entry, and a put never replaces one.
+type AuditRepo struct {
+	client *dynamodb.Client
+}
+
+func NewAuditRepo(client *dynamodb.Client) *AuditRepo {
+	return &AuditRepo{client: client}
+}
+
+func (r *AuditRepo) Append(ctx context.Context, entries ...core.AuditEntry) error {
+	cond := expression.AttributeNotExists(expression.Name("id"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("audit_log.buildExpr: %w", err)
+	}
+
+	for _, e := range entries {
+		av, err := attributevalue.MarshalMap(auditItemFromCore(e))
+		if err != nil {
+			return fmt.Errorf("audit_log.marshal: %w", err)
+		}
+		_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName:                aws.String(TableAudit),
+			Item:                     av,
+			ConditionExpression:      expr.Condition(),
+			ExpressionAttributeNames: expr.Names(),
+		})
+		if err != nil {
+			return fmt.Errorf("audit_log.putItem: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *AuditRepo) History(ctx context.Context, resource core.AuditResource, resourceID string, page core.PageRequest) (core.Page[core.AuditEntry], error) {
+	key := expression.Key("subject").Equal(expression.Value(auditSubject(resource, resourceID)))
+	q := listQuery{table: TableAudit, index: GSIAuditSubject, key: &key}
+	return listPage(ctx, r.client, q, page, AuditItem.ToCore)
+}
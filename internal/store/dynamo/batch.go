@@ -0,0 +1,92 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+const (
+	// maxBatchGetKeys is the BatchGetItem limit per request.
+	maxBatchGetKeys = 100
+
+	// maxBatchGetRounds bounds retries of unprocessed keys under throttling.
+	maxBatchGetRounds = 5
+
+	// maxParallelQueries bounds the index queries run for one batch lookup.
+	maxParallelQueries = 8
+)
+
+var errBatchGetIncomplete = errors.New("batch get: keys still unprocessed after retries")
+
+// batchGet reads items by id with BatchGetItem, skipping missing ones and
+// retrying keys DynamoDB leaves unprocessed.
+func batchGet[I interface{ ToCore() T }, T any](ctx context.Context, client *dynamodb.Client, table string, ids []string) ([]T, error) {
+	var items []T
+	for start := 0; start < len(ids); start += maxBatchGetKeys {
+		end := min(start+maxBatchGetKeys, len(ids))
+
+		keys := make([]map[string]types.AttributeValue, 0, end-start)
+		for _, id := range ids[start:end] {
+			keys = append(keys, map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}})
+		}
+		request := map[string]types.KeysAndAttributes{table: {Keys: keys}}
+
+		for round := 0; len(request) > 0; round++ {
+			if round == maxBatchGetRounds {
+				return nil, errBatchGetIncomplete
+			}
+			out, err := client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{RequestItems: request})
+			if err != nil {
+				return nil, err
+			}
+			for _, av := range out.Responses[table] {
+				var item I
+				if err := attributevalue.UnmarshalMap(av, &item); err != nil {
+					return nil, err
+				}
+				items = append(items, item.ToCore())
+			}
+			request = out.UnprocessedKeys
+		}
+	}
+	return items, nil
+}
+
+// getEach looks up each key with get, a few at a time, skipping keys that
+// are not found. DynamoDB can't batch index queries, so lookups by
+// application ID fan out instead.
+func getEach[T any](ctx context.Context, keys []string, get func(context.Context, string) (T, error)) ([]T, error) {
+	var (
+		mu    sync.Mutex
+		items []T
+	)
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxParallelQueries)
+	for _, key := range keys {
+		g.Go(func() error {
+			item, err := get(ctx, key)
+			if errors.Is(err, core.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			items = append(items, item)
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
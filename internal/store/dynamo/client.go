@@ -10,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
 )
 
 const (
@@ -18,9 +19,10 @@ const (
 	maxBackoff     = 30 * time.Second
 )
 
-// Client wraps the DynamoDB client.
+// Client wraps the DynamoDB and DynamoDB Streams clients.
 type Client struct {
-	DB *dynamodb.Client
+	DB      *dynamodb.Client
+	Streams *dynamodbstreams.Client
 }
 
 // Config holds DynamoDB configuration.
@@ -42,7 +44,7 @@ func NewClient(ctx context.Context, cfg Config) (*Client, error) {
 	if cfg.Endpoint != "" {
 		customResolver := aws.EndpointResolverWithOptionsFunc(
 			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-				if service == dynamodb.ServiceID {
+				if service == dynamodb.ServiceID || service == dynamodbstreams.ServiceID {
 					return aws.Endpoint{
 						URL:           cfg.Endpoint,
 						SigningRegion: cfg.Region,
@@ -71,14 +73,16 @@ func NewClient(ctx context.Context, cfg Config) (*Client, error) {
 		return nil, fmt.Errorf("load aws config: %w", err)
 	}
 
-	client := dynamodb.NewFromConfig(awsCfg)
+	client := dynamodb.NewFromConfig(awsCfg, func(o *dynamodb.Options) {
+		o.APIOptions = append(o.APIOptions, instrument)
+	})
 
 	// Verify connectivity with retry
 	if err := pingWithRetry(ctx, client); err != nil {
 		return nil, err
 	}
 
-	return &Client{DB: client}, nil
+	return &Client{DB: client, Streams: dynamodbstreams.NewFromConfig(awsCfg)}, nil
 }
 
 // pingWithRetry attempts to ping DynamoDB with exponential backoff.
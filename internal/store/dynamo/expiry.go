@@ -0,0 +1,74 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// expireItems reads q page by page and sets the status of each item that is
+// due to expired. Each update is conditioned on the status the item was read
+// with, so one that moved on in the meantime (e.g. an offer just accepted)
+// is left alone. Versioned items (version is nil for others) get their
+// version bumped. It returns the items it expired, as they now are.
+func expireItems[I any](ctx context.Context, client *dynamodb.Client, q listQuery, expired string,
+	id func(I) string, status func(*I) *string, version func(*I) *int, due func(I) bool) ([]I, error) {
+
+	var changed []I
+	page := core.PageRequest{Limit: core.MaxPageLimit}
+	for {
+		res, err := listPage(ctx, client, q, page, func(i I) I { return i })
+		if err != nil {
+			return changed, err
+		}
+
+		for _, item := range res.Items {
+			if !due(item) {
+				continue
+			}
+			from := *status(&item)
+			update := expression.Set(expression.Name("status"), expression.Value(expired))
+			if version != nil {
+				update = update.Add(expression.Name("version"), expression.Value(1))
+			}
+			cond := expression.Name("status").Equal(expression.Value(from))
+			expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(cond).Build()
+			if err != nil {
+				return changed, fmt.Errorf("%s.buildExpr: %w", q.table, err)
+			}
+
+			_, err = client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:                 aws.String(q.table),
+				Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id(item)}},
+				UpdateExpression:          expr.Update(),
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			})
+			if err != nil {
+				var ccf *types.ConditionalCheckFailedException
+				if errors.As(err, &ccf) {
+					continue
+				}
+				return changed, fmt.Errorf("%s.updateItem: %w", q.table, err)
+			}
+			*status(&item) = expired
+			if version != nil {
+				*version(&item)++
+			}
+			changed = append(changed, item)
+		}
+
+		if res.NextCursor == "" {
+			return changed, nil
+		}
+		page.Cursor = res.NextCursor
+	}
+}
@@ -0,0 +1,158 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type IdempotencyItem struct {
+	ID          string            `dynamodbav:"id"` // Client identity plus Idempotency-Key
+	Fingerprint string            `dynamodbav:"fingerprint"`
+	Status      int               `dynamodbav:"status"`
+	Header      map[string]string `dynamodbav:"header,omitempty"`
+	Body        []byte            `dynamodbav:"body,omitempty"`
+	CreatedAt   string            `dynamodbav:"created_at"`
+	ExpiresAt   int64             `dynamodbav:"expires_at"` // Unix seconds; the table's TTL attribute
+}
+
+func (i IdempotencyItem) ToCore() core.IdempotencyRecord {
+	createdAt, _ := time.Parse(time.RFC3339, i.CreatedAt)
+	return core.IdempotencyRecord{
+		Key:         i.ID,
+		Fingerprint: i.Fingerprint,
+		Status:      i.Status,
+		Header:      i.Header,
+		Body:        i.Body,
+		CreatedAt:   createdAt,
+		ExpiresAt:   time.Unix(i.ExpiresAt, 0).UTC(),
+	}
+}
+
+func idempotencyItemFromCore(r core.IdempotencyRecord) IdempotencyItem {
+	return IdempotencyItem{
+		ID:          r.Key,
+		Fingerprint: r.Fingerprint,
+		Status:      r.Status,
+		Header:      r.Header,
+		Body:        r.Body,
+		CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+		ExpiresAt:   r.ExpiresAt.Unix(),
+	}
+}
+
+type IdempotencyRepo struct {
+	client *dynamodb.Client
+}
+
+func NewIdempotencyRepo(client *dynamodb.Client) *IdempotencyRepo {
+	return &IdempotencyRepo{client: client}
+}
+
+// Reserve also overwrites expired records, since DynamoDB TTL deletes lazily.
+func (r *IdempotencyRepo) Reserve(ctx context.Context, rec core.IdempotencyRecord) error {
+	av, err := attributevalue.MarshalMap(idempotencyItemFromCore(rec))
+	if err != nil {
+		return fmt.Errorf("idempotency_keys.marshal: %w", err)
+	}
+
+	cond := expression.AttributeNotExists(expression.Name("id")).
+		Or(expression.Name("expires_at").LessThanEqual(expression.Value(rec.CreatedAt.Unix())))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("idempotency_keys.buildExpr: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(TableIdempotency),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return core.ErrIdempotencyKeyExists
+		}
+		return fmt.Errorf("idempotency_keys.putItem: %w", err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepo) Get(ctx context.Context, key string) (core.IdempotencyRecord, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(TableIdempotency),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: key},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return core.IdempotencyRecord{}, fmt.Errorf("idempotency_keys.getItem: %w", err)
+	}
+
+	if out.Item == nil {
+		return core.IdempotencyRecord{}, core.ErrIdempotencyKeyNotFound
+	}
+
+	var item IdempotencyItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return core.IdempotencyRecord{}, fmt.Errorf("idempotency_keys.unmarshal: %w", err)
+	}
+
+	return item.ToCore(), nil
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, rec core.IdempotencyRecord) error {
+	av, err := attributevalue.MarshalMap(idempotencyItemFromCore(rec))
+	if err != nil {
+		return fmt.Errorf("idempotency_keys.marshal: %w", err)
+	}
+
+	cond := expression.AttributeExists(expression.Name("id"))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("idempotency_keys.buildExpr: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(TableIdempotency),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return core.ErrIdempotencyKeyNotFound
+		}
+		return fmt.Errorf("idempotency_keys.putItem: %w", err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, key string) error {
+	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(TableIdempotency),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: key},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("idempotency_keys.deleteItem: %w", err)
+	}
+	return nil
+}
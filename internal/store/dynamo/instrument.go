@@ -0,0 +1,45 @@
+package dynamo
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/MrKriegler/go-insurance/internal/platform/metrics"
+	"github.com/MrKriegler/go-insurance/internal/platform/tracing"
+)
+
+// instrument records every call the client makes, retries included, by
+// operation and table, as a metric and, inside a trace, as a span. Add it
+// to the client's APIOptions.
+func instrument(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Instrument",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			op, table := middleware.GetOperationName(ctx), tableName(in.Parameters)
+			ctx, end := tracing.StoreSpan(ctx, "dynamodb", op, table)
+			start := time.Now()
+			out, md, err := next.HandleInitialize(ctx, in)
+			metrics.ObserveStore("dynamodb", op, table, time.Since(start), err)
+			end(err)
+			return out, md, err
+		}), middleware.After)
+}
+
+// tableName returns the TableName of a DynamoDB input, or "" for inputs
+// that span tables, such as BatchWriteItem.
+func tableName(params any) string {
+	v := reflect.ValueOf(params)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.Elem().FieldByName("TableName")
+	if !f.IsValid() {
+		return ""
+	}
+	if name, ok := f.Interface().(*string); ok && name != nil {
+		return *name
+	}
+	return ""
+}
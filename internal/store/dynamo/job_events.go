@@ -0,0 +1,261 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+const (
+	// streamPollInterval is how often each shard of the jobs stream is read.
+	streamPollInterval = time.Second
+	// streamDiscoverInterval is how often the stream is checked for new
+	// shards, besides whenever a shard closes.
+	streamDiscoverInterval = time.Minute
+)
+
+// WatchQueuedJobs calls notify with the kind of each job that becomes ready
+// to run, on any replica, until ctx is cancelled: newly enqueued jobs and
+// dead jobs an admin queued again. Jobs queued for a retry after a failed
+// attempt aren't reported; polling picks them up when they come due.
+//
+// It reads the jobs table's stream from the time it starts. If the table
+// has no stream, or the stream may not be read, it logs a warning and
+// returns, leaving workers to poll.
+func WatchQueuedJobs(ctx context.Context, client *Client, log *slog.Logger, notify func(core.JobKind)) {
+	out, err := client.DB.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(TableJobs)})
+	if err != nil {
+		log.Warn("failed to describe jobs table, workers will poll", "err", err)
+		return
+	}
+	if out.Table.LatestStreamArn == nil {
+		log.Warn("jobs table has no stream, workers will poll")
+		return
+	}
+
+	w := &streamWatcher{
+		streams: client.Streams,
+		arn:     out.Table.LatestStreamArn,
+		shards:  make(map[string]*streamShard),
+		notify:  notify,
+		log:     log,
+	}
+	w.run(ctx)
+}
+
+// streamShard is the read position in one shard of the stream.
+type streamShard struct {
+	start    streamtypes.ShardIteratorType // Where to read from when there's no lastSeq
+	lastSeq  *string                       // Last record read
+	iterator *string                       // Nil when one must be fetched
+	done     bool                          // Closed and read to the end
+}
+
+type streamWatcher struct {
+	streams *dynamodbstreams.Client
+	arn     *string
+	shards  map[string]*streamShard
+	notify  func(core.JobKind)
+	log     *slog.Logger
+}
+
+func (w *streamWatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+
+	var discovered, retryAt time.Time
+	var backoff time.Duration // Doubles on each failed discovery in a row
+	initial, rediscover := true, true
+	for {
+		due := rediscover || time.Since(discovered) >= streamDiscoverInterval
+		if due && !time.Now().Before(retryAt) {
+			if err := w.discover(ctx, initial); err != nil {
+				if accessDenied(err) {
+					w.log.Warn("not allowed to read jobs stream, workers will poll", "err", err)
+					return
+				}
+				backoff = min(max(2*backoff, streamPollInterval), streamDiscoverInterval)
+				retryAt = time.Now().Add(backoff)
+				if ctx.Err() == nil {
+					w.log.Warn("failed to list job stream shards", "err", err, "retry_in", backoff)
+				}
+			} else {
+				if initial {
+					w.log.Info("watching job queue", "shards", len(w.shards))
+				}
+				discovered, initial, rediscover, backoff = time.Now(), false, false, 0
+			}
+		}
+
+		if !initial {
+			closed, err := w.poll(ctx)
+			if err != nil {
+				w.log.Warn("not allowed to read jobs stream, workers will poll", "err", err)
+				return
+			}
+			if closed {
+				rediscover = true
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// accessDenied reports whether err is the stream refusing the caller, which
+// retrying won't fix.
+func accessDenied(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "AccessDeniedException"
+}
+
+// discover brings the shard list up to date. Shards open when watching
+// starts are read from their latest record; shards that appear later, when
+// one splits, are read from the start.
+func (w *streamWatcher) discover(ctx context.Context, initial bool) error {
+	seen := make(map[string]bool, len(w.shards))
+	var startShard *string
+	for {
+		out, err := w.streams.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             w.arn,
+			ExclusiveStartShardId: startShard,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, s := range out.StreamDescription.Shards {
+			id := aws.ToString(s.ShardId)
+			seen[id] = true
+			if _, ok := w.shards[id]; ok {
+				continue
+			}
+			closed := s.SequenceNumberRange != nil && s.SequenceNumberRange.EndingSequenceNumber != nil
+			switch {
+			case initial && closed:
+				w.shards[id] = &streamShard{done: true}
+			case initial:
+				w.shards[id] = &streamShard{start: streamtypes.ShardIteratorTypeLatest}
+			default:
+				w.shards[id] = &streamShard{start: streamtypes.ShardIteratorTypeTrimHorizon}
+			}
+		}
+
+		if out.StreamDescription.LastEvaluatedShardId == nil {
+			break
+		}
+		startShard = out.StreamDescription.LastEvaluatedShardId
+	}
+
+	// Forget shards the stream has trimmed
+	for id := range w.shards {
+		if !seen[id] {
+			delete(w.shards, id)
+		}
+	}
+	return nil
+}
+
+// poll reads each open shard once. It reports whether a shard closed, so
+// its children should be looked for, and returns an error only when the
+// stream may not be read.
+func (w *streamWatcher) poll(ctx context.Context) (closed bool, err error) {
+	for id, shard := range w.shards {
+		if shard.done {
+			continue
+		}
+		if shard.iterator == nil {
+			if err := w.seek(ctx, id, shard); err != nil {
+				if accessDenied(err) {
+					return closed, err
+				}
+				w.log.Warn("failed to open job stream shard", "shard_id", id, "err", err)
+				continue
+			}
+		}
+
+		out, err := w.streams.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{ShardIterator: shard.iterator})
+		if err != nil {
+			shard.iterator = nil
+			var expired *streamtypes.ExpiredIteratorException
+			var trimmed *streamtypes.TrimmedDataAccessException
+			switch {
+			case accessDenied(err):
+				return closed, err
+			case errors.As(err, &expired):
+				// Fetched again on the next poll
+			case errors.As(err, &trimmed):
+				shard.lastSeq, shard.start = nil, streamtypes.ShardIteratorTypeTrimHorizon
+			case ctx.Err() == nil:
+				w.log.Warn("failed to read job stream", "shard_id", id, "err", err)
+			}
+			continue
+		}
+
+		for _, rec := range out.Records {
+			w.handle(rec)
+			if rec.Dynamodb != nil && rec.Dynamodb.SequenceNumber != nil {
+				shard.lastSeq = rec.Dynamodb.SequenceNumber
+			}
+		}
+		shard.iterator = out.NextShardIterator
+		if shard.iterator == nil {
+			shard.done, closed = true, true
+		}
+	}
+	return closed, nil
+}
+
+// seek fetches an iterator for shard, after the last record read if any.
+func (w *streamWatcher) seek(ctx context.Context, id string, shard *streamShard) error {
+	in := &dynamodbstreams.GetShardIteratorInput{
+		StreamArn:         w.arn,
+		ShardId:           aws.String(id),
+		ShardIteratorType: shard.start,
+	}
+	if shard.lastSeq != nil {
+		in.ShardIteratorType = streamtypes.ShardIteratorTypeAfterSequenceNumber
+		in.SequenceNumber = shard.lastSeq
+	}
+	out, err := w.streams.GetShardIterator(ctx, in)
+	if err != nil {
+		return err
+	}
+	shard.iterator = out.ShardIterator
+	return nil
+}
+
+// handle notifies for a record that leaves a job queued with no attempts
+// made: an enqueue or an admin's retry.
+func (w *streamWatcher) handle(rec streamtypes.Record) {
+	if rec.EventName == streamtypes.OperationTypeRemove || rec.Dynamodb == nil || rec.Dynamodb.NewImage == nil {
+		return
+	}
+	image, err := attributevalue.FromDynamoDBStreamsMap(rec.Dynamodb.NewImage)
+	if err != nil {
+		w.log.Warn("failed to convert job change", "err", err)
+		return
+	}
+	var item JobItem
+	if err := attributevalue.UnmarshalMap(image, &item); err != nil {
+		w.log.Warn("failed to decode job change", "err", err)
+		return
+	}
+	if core.JobStatus(item.Status) == core.JobStatusQueued && item.Attempts == 0 {
+		w.notify(core.JobKind(item.Kind))
+	}
+}
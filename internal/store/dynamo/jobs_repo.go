@@ -0,0 +1,308 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type JobItem struct {
+	ID             string `dynamodbav:"id"` // Kind.subject
+	Kind           string `dynamodbav:"kind"`
+	SubjectID      string `dynamodbav:"subject_id"`
+	Status         string `dynamodbav:"status"`
+	Attempts       int    `dynamodbav:"attempts"`
+	RunAt          string `dynamodbav:"run_at"` // RFC3339 in UTC, so it sorts in time order
+	LastError      string `dynamodbav:"last_error,omitempty"`
+	LeaseOwner     string `dynamodbav:"lease_owner,omitempty"`
+	LeaseExpiresAt int64  `dynamodbav:"lease_expires_at,omitempty"` // Unix milliseconds
+	CreatedAt      string `dynamodbav:"created_at"`
+	UpdatedAt      string `dynamodbav:"updated_at"`
+	ExpiresAt      int64  `dynamodbav:"expires_at,omitempty"` // Unix seconds; the table's TTL attribute
+	TraceParent    string `dynamodbav:"trace_parent,omitempty"`
+}
+
+func (i JobItem) ToCore() core.Job {
+	runAt, _ := time.Parse(time.RFC3339, i.RunAt)
+	createdAt, _ := time.Parse(time.RFC3339, i.CreatedAt)
+	updatedAt, _ := time.Parse(time.RFC3339, i.UpdatedAt)
+	job := core.Job{
+		ID:          i.ID,
+		Kind:        core.JobKind(i.Kind),
+		SubjectID:   i.SubjectID,
+		Status:      core.JobStatus(i.Status),
+		Attempts:    i.Attempts,
+		RunAt:       runAt,
+		LastError:   i.LastError,
+		LeaseOwner:  i.LeaseOwner,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		TraceParent: i.TraceParent,
+	}
+	if i.LeaseExpiresAt != 0 {
+		t := time.UnixMilli(i.LeaseExpiresAt).UTC()
+		job.LeaseExpiresAt = &t
+	}
+	if i.ExpiresAt != 0 {
+		t := time.Unix(i.ExpiresAt, 0).UTC()
+		job.ExpiresAt = &t
+	}
+	return job
+}
+
+func jobItemFromCore(j core.Job) JobItem {
+	item := JobItem{
+		ID:          j.ID,
+		Kind:        string(j.Kind),
+		SubjectID:   j.SubjectID,
+		Status:      string(j.Status),
+		Attempts:    j.Attempts,
+		RunAt:       j.RunAt.UTC().Format(time.RFC3339),
+		LastError:   j.LastError,
+		LeaseOwner:  j.LeaseOwner,
+		CreatedAt:   j.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   j.UpdatedAt.Format(time.RFC3339),
+		TraceParent: j.TraceParent,
+	}
+	if j.LeaseExpiresAt != nil {
+		item.LeaseExpiresAt = j.LeaseExpiresAt.UnixMilli()
+	}
+	if j.ExpiresAt != nil {
+		item.ExpiresAt = j.ExpiresAt.Unix()
+	}
+	return item
+}
+
+type JobRepo struct {
+	client *dynamodb.Client
+}
+
+func NewJobRepo(client *dynamodb.Client) *JobRepo {
+	return &JobRepo{client: client}
+}
+
+func (r *JobRepo) Enqueue(ctx context.Context, job core.Job) error {
+	if err := r.put(ctx, job, expression.AttributeNotExists(expression.Name("id"))); err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return core.ErrJobExists
+		}
+		return fmt.Errorf("jobs.putItem: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepo) Get(ctx context.Context, id string) (core.Job, error) {
+	out, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(TableJobs),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return core.Job{}, fmt.Errorf("jobs.getItem: %w", err)
+	}
+
+	if out.Item == nil {
+		return core.Job{}, core.ErrJobNotFound
+	}
+
+	var item JobItem
+	if err := attributevalue.UnmarshalMap(out.Item, &item); err != nil {
+		return core.Job{}, fmt.Errorf("jobs.unmarshal: %w", err)
+	}
+
+	return item.ToCore(), nil
+}
+
+// Claim queries the status index for due jobs, which only nominates
+// candidates since the index is eventually consistent. Each claim is a
+// conditional update on the table, so concurrent claimers never get the
+// same job.
+func (r *JobRepo) Claim(ctx context.Context, kind core.JobKind, owner string, ttl time.Duration, limit int) ([]core.Job, error) {
+	now := time.Now()
+	queued := expression.Value(string(core.JobStatusQueued))
+	due := expression.Value(now.UTC().Format(time.RFC3339))
+	ofKind := expression.Name("kind").Equal(expression.Value(string(kind)))
+
+	query, err := expression.NewBuilder().
+		WithKeyCondition(expression.Key("status").Equal(queued).And(expression.Key("run_at").LessThanEqual(due))).
+		WithFilter(ofKind.And(unleased(now))).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("jobs.buildExpr: %w", err)
+	}
+	update := expression.Set(expression.Name(attrLeaseOwner), expression.Value(owner)).
+		Set(expression.Name(attrLeaseExpiresAt), expression.Value(now.Add(ttl).UnixMilli()))
+	cond := expression.Name("status").Equal(queued).
+		And(expression.Name("run_at").LessThanEqual(due)).
+		And(ofKind).
+		And(unleased(now))
+	claimExpr, err := expression.NewBuilder().WithUpdate(update).WithCondition(cond).Build()
+	if err != nil {
+		return nil, fmt.Errorf("jobs.buildExpr: %w", err)
+	}
+
+	var (
+		claimed []core.Job
+		start   map[string]types.AttributeValue
+	)
+	for len(claimed) < limit {
+		out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(TableJobs),
+			IndexName:                 aws.String(GSIJobsStatusRunAt),
+			KeyConditionExpression:    query.KeyCondition(),
+			FilterExpression:          query.Filter(),
+			ExpressionAttributeNames:  query.Names(),
+			ExpressionAttributeValues: query.Values(),
+			ExclusiveStartKey:         start,
+		})
+		if err != nil {
+			return claimed, fmt.Errorf("jobs.query: %w", err)
+		}
+
+		for _, av := range out.Items {
+			if len(claimed) == limit {
+				break
+			}
+			res, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:                 aws.String(TableJobs),
+				Key:                       map[string]types.AttributeValue{"id": av["id"]},
+				UpdateExpression:          claimExpr.Update(),
+				ConditionExpression:       claimExpr.Condition(),
+				ExpressionAttributeNames:  claimExpr.Names(),
+				ExpressionAttributeValues: claimExpr.Values(),
+				ReturnValues:              types.ReturnValueAllNew,
+			})
+			if err != nil {
+				var ccf *types.ConditionalCheckFailedException
+				if errors.As(err, &ccf) {
+					continue // Claimed by someone else, or no longer queued
+				}
+				return claimed, fmt.Errorf("jobs.updateItem: %w", err)
+			}
+			var item JobItem
+			if err := attributevalue.UnmarshalMap(res.Attributes, &item); err != nil {
+				return claimed, fmt.Errorf("jobs.unmarshal: %w", err)
+			}
+			claimed = append(claimed, item.ToCore())
+		}
+
+		if out.LastEvaluatedKey == nil {
+			break
+		}
+		start = out.LastEvaluatedKey
+	}
+	return claimed, nil
+}
+
+func (r *JobRepo) Backlog(ctx context.Context, kind core.JobKind) (int64, error) {
+	now := time.Now()
+	expr, err := expression.NewBuilder().
+		WithKeyCondition(expression.Key("status").Equal(expression.Value(string(core.JobStatusQueued))).
+			And(expression.Key("run_at").LessThanEqual(expression.Value(now.UTC().Format(time.RFC3339))))).
+		WithFilter(expression.Name("kind").Equal(expression.Value(string(kind))).And(unleased(now))).
+		Build()
+	if err != nil {
+		return 0, fmt.Errorf("jobs.buildExpr: %w", err)
+	}
+
+	var (
+		count int64
+		start map[string]types.AttributeValue
+	)
+	for {
+		out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(TableJobs),
+			IndexName:                 aws.String(GSIJobsStatusRunAt),
+			KeyConditionExpression:    expr.KeyCondition(),
+			FilterExpression:          expr.Filter(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			Select:                    types.SelectCount,
+			ExclusiveStartKey:         start,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("jobs.count: %w", err)
+		}
+		count += int64(out.Count)
+
+		if out.LastEvaluatedKey == nil {
+			return count, nil
+		}
+		start = out.LastEvaluatedKey
+	}
+}
+
+func (r *JobRepo) Renew(ctx context.Context, id, owner string, ttl time.Duration) error {
+	err := renewClaim(ctx, r.client, TableJobs, id, owner, ttl)
+	if err != nil && !errors.Is(err, core.ErrLeaseLost) {
+		return fmt.Errorf("jobs.renew: %w", err)
+	}
+	return err
+}
+
+func (r *JobRepo) Finish(ctx context.Context, job core.Job, owner string) error {
+	job.LeaseOwner, job.LeaseExpiresAt = "", nil
+	if err := r.put(ctx, job, expression.Name(attrLeaseOwner).Equal(expression.Value(owner))); err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return core.ErrLeaseLost
+		}
+		return fmt.Errorf("jobs.putItem: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepo) Update(ctx context.Context, job core.Job, from core.JobStatus) error {
+	if err := r.put(ctx, job, expression.Name("status").Equal(expression.Value(string(from)))); err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return core.ErrConflict
+		}
+		return fmt.Errorf("jobs.putItem: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepo) List(ctx context.Context, filter core.JobFilter, page core.PageRequest) (core.Page[core.Job], error) {
+	q := listQuery{table: TableJobs}
+	if filter.Status != "" {
+		key := expression.Key("status").Equal(expression.Value(string(filter.Status)))
+		q.index, q.key = GSIJobsStatusRunAt, &key
+	}
+	q.whereEqual("kind", string(filter.Kind))
+
+	return listPage(ctx, r.client, q, page, JobItem.ToCore)
+}
+
+// put writes job if cond holds.
+func (r *JobRepo) put(ctx context.Context, job core.Job, cond expression.ConditionBuilder) error {
+	av, err := attributevalue.MarshalMap(jobItemFromCore(job))
+	if err != nil {
+		return err
+	}
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(TableJobs),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	return err
+}
@@ -0,0 +1,124 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Claimed items carry their lease in these attributes. The expiry is in
+// Unix milliseconds so conditions can compare it.
+const (
+	attrLeaseOwner     = "lease_owner"
+	attrLeaseExpiresAt = "lease_expires_at"
+)
+
+// unleased matches items without a live lease at now.
+func unleased(now time.Time) expression.ConditionBuilder {
+	return expression.AttributeNotExists(expression.Name(attrLeaseExpiresAt)).
+		Or(expression.Name(attrLeaseExpiresAt).LessThan(expression.Value(now.UnixMilli())))
+}
+
+// renewClaim extends owner's lease, unless another owner has taken it over.
+func renewClaim(ctx context.Context, client *dynamodb.Client, table, id, owner string, ttl time.Duration) error {
+	update := expression.Set(expression.Name(attrLeaseExpiresAt), expression.Value(time.Now().Add(ttl).UnixMilli()))
+	cond := expression.Name(attrLeaseOwner).Equal(expression.Value(owner))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(cond).Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(table),
+		Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return core.ErrLeaseLost
+	}
+	return err
+}
+
+// LeaseItem is a named lease, e.g. the leader lease of a singleton job.
+type LeaseItem struct {
+	Name      string `dynamodbav:"name"`
+	Owner     string `dynamodbav:"owner"`
+	ExpiresAt int64  `dynamodbav:"expires_at"` // Unix milliseconds
+}
+
+type LeaseRepo struct {
+	client *dynamodb.Client
+}
+
+func NewLeaseRepo(client *dynamodb.Client) *LeaseRepo {
+	return &LeaseRepo{client: client}
+}
+
+func (r *LeaseRepo) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	av, err := attributevalue.MarshalMap(LeaseItem{Name: name, Owner: owner, ExpiresAt: now.Add(ttl).UnixMilli()})
+	if err != nil {
+		return false, fmt.Errorf("leases.marshal: %w", err)
+	}
+
+	cond := expression.AttributeNotExists(expression.Name("name")).
+		Or(expression.Name("owner").Equal(expression.Value(owner))).
+		Or(expression.Name("expires_at").LessThan(expression.Value(now.UnixMilli())))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return false, fmt.Errorf("leases.buildExpr: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(TableLeases),
+		Item:                      av,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return false, nil
+		}
+		return false, fmt.Errorf("leases.putItem: %w", err)
+	}
+	return true, nil
+}
+
+func (r *LeaseRepo) Release(ctx context.Context, name, owner string) error {
+	cond := expression.Name("owner").Equal(expression.Value(owner))
+	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	if err != nil {
+		return fmt.Errorf("leases.buildExpr: %w", err)
+	}
+
+	_, err = r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:                 aws.String(TableLeases),
+		Key:                       map[string]types.AttributeValue{"name": &types.AttributeValueMemberS{Value: name}},
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return nil
+		}
+		return fmt.Errorf("leases.deleteItem: %w", err)
+	}
+	return nil
+}
@@ -23,10 +23,11 @@ type OfferItem struct {
 	TermYears      int     `dynamodbav:"term_years"`
 	MonthlyPremium float64 `dynamodbav:"monthly_premium"`
 	Status         string  `dynamodbav:"status"`
-	CreatedAt      string  `dynamodbav:"created_at"`
+	CreatedAt      string  `dynamodbav:"created_at"` // RFC3339 in UTC, so ranges compare as strings
 	ExpiresAt      string  `dynamodbav:"expires_at"`
 	AcceptedAt     string  `dynamodbav:"accepted_at,omitempty"`
 	DeclinedAt     string  `dynamodbav:"declined_at,omitempty"`
+	Version        int     `dynamodbav:"version"`
 }
 
 func (i OfferItem) ToCore() core.Offer {
@@ -53,6 +54,7 @@ func (i OfferItem) ToCore() core.Offer {
 		ExpiresAt:      expiresAt,
 		AcceptedAt:     acceptedAt,
 		DeclinedAt:     declinedAt,
+		Version:        i.Version,
 	}
 }
 
@@ -65,8 +67,9 @@ func offerItemFromCore(o core.Offer) OfferItem {
 		TermYears:      o.TermYears,
 		MonthlyPremium: o.MonthlyPremium,
 		Status:         string(o.Status),
-		CreatedAt:      o.CreatedAt.Format(time.RFC3339),
+		CreatedAt:      o.CreatedAt.UTC().Format(time.RFC3339),
 		ExpiresAt:      o.ExpiresAt.Format(time.RFC3339),
+		Version:        o.Version,
 	}
 	if o.AcceptedAt != nil {
 		item.AcceptedAt = o.AcceptedAt.Format(time.RFC3339)
@@ -165,14 +168,19 @@ func (r *OfferRepo) GetByApplicationID(ctx context.Context, appID string) (core.
 	return item.ToCore(), nil
 }
 
+func (r *OfferRepo) FindByApplicationIDs(ctx context.Context, appIDs []string) ([]core.Offer, error) {
+	return getEach(ctx, appIDs, r.GetByApplicationID)
+}
+
 func (r *OfferRepo) Update(ctx context.Context, offer core.Offer) error {
 	item := offerItemFromCore(offer)
+	item.Version++
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		return fmt.Errorf("offers.marshal: %w", err)
 	}
 
-	cond := expression.AttributeExists(expression.Name("id"))
+	cond := atVersion(offer.Version)
 	expr, err := expression.NewBuilder().WithCondition(cond).Build()
 	if err != nil {
 		return fmt.Errorf("offers.buildExpr: %w", err)
@@ -188,7 +196,7 @@ func (r *OfferRepo) Update(ctx context.Context, offer core.Offer) error {
 	if err != nil {
 		var ccf *types.ConditionalCheckFailedException
 		if errors.As(err, &ccf) {
-			return core.ErrOfferNotFound
+			return versionFailure(ctx, offer.ID, r.Get)
 		}
 		return fmt.Errorf("offers.putItem: %w", err)
 	}
@@ -225,52 +233,35 @@ func (r *OfferRepo) FindAccepted(ctx context.Context, limit int) ([]core.Offer,
 	return offers, nil
 }
 
-func (r *OfferRepo) ExpireOffers(ctx context.Context, before time.Time) (int64, error) {
-	// DynamoDB doesn't support bulk updates like MongoDB
-	// We need to scan and update individually (or use batch write)
-	// For simplicity, this is a basic implementation
-	out, err := r.client.Query(ctx, &dynamodb.QueryInput{
-		TableName:              aws.String(TableOffers),
-		IndexName:              aws.String(GSIOffersStatus),
-		KeyConditionExpression: aws.String("#status = :status"),
-		ExpressionAttributeNames: map[string]string{
-			"#status": "status",
-		},
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":status": &types.AttributeValueMemberS{Value: string(core.OfferStatusPending)},
-		},
-	})
-	if err != nil {
-		return 0, fmt.Errorf("offers.query: %w", err)
+func (r *OfferRepo) ExpireOffers(ctx context.Context, before time.Time) ([]core.Offer, error) {
+	key := expression.Key("status").Equal(expression.Value(string(core.OfferStatusPending)))
+	q := listQuery{table: TableOffers, index: GSIOffersStatus, key: &key}
+
+	expired, err := expireItems(ctx, r.client, q, string(core.OfferStatusExpired),
+		func(i OfferItem) string { return i.ID },
+		func(i *OfferItem) *string { return &i.Status },
+		func(i *OfferItem) *int { return &i.Version },
+		func(i OfferItem) bool { return i.ToCore().ExpiresAt.Before(before) })
+	offers := make([]core.Offer, len(expired))
+	for n, item := range expired {
+		offers[n] = item.ToCore()
 	}
+	return offers, err
+}
 
-	var count int64
-	for _, item := range out.Items {
-		var offer OfferItem
-		if err := attributevalue.UnmarshalMap(item, &offer); err != nil {
-			continue
-		}
-
-		expiresAt, _ := time.Parse(time.RFC3339, offer.ExpiresAt)
-		if expiresAt.Before(before) {
-			// Update to expired
-			update := expression.Set(expression.Name("status"), expression.Value(string(core.OfferStatusExpired)))
-			expr, _ := expression.NewBuilder().WithUpdate(update).Build()
-
-			_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
-				TableName: aws.String(TableOffers),
-				Key: map[string]types.AttributeValue{
-					"id": &types.AttributeValueMemberS{Value: offer.ID},
-				},
-				UpdateExpression:          expr.Update(),
-				ExpressionAttributeNames:  expr.Names(),
-				ExpressionAttributeValues: expr.Values(),
-			})
-			if err == nil {
-				count++
-			}
-		}
+func (r *OfferRepo) List(ctx context.Context, filter core.OfferFilter, page core.PageRequest) (core.Page[core.Offer], error) {
+	q := listQuery{table: TableOffers}
+	switch {
+	case filter.ApplicationID != "":
+		key := expression.Key("application_id").Equal(expression.Value(filter.ApplicationID))
+		q.index, q.key = GSIOffersAppID, &key
+		q.whereEqual("status", string(filter.Status))
+	case filter.Status != "":
+		key := expression.Key("status").Equal(expression.Value(string(filter.Status)))
+		q.index, q.key = GSIOffersStatus, &key
 	}
+	q.whereEqual("product_slug", filter.ProductSlug)
+	q.whereTime("created_at", filter.Created)
 
-	return count, nil
+	return listPage(ctx, r.client, q, page, OfferItem.ToCore)
 }
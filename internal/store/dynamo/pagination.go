@@ -0,0 +1,184 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// maxPageReads bounds the Scan/Query calls behind one page, so a selective
+// filter over a large table returns a short page with a cursor instead of
+// reading the whole table in one request.
+const maxPageReads = 10
+
+// pageCursor resumes a Scan or Query at its LastEvaluatedKey. Every key
+// attribute in these tables is a string.
+type pageCursor struct {
+	Key map[string]string `json:"k"`
+}
+
+// listQuery describes a filtered listing: a Query on index when key is set,
+// otherwise a Scan of the table.
+type listQuery struct {
+	table       string
+	index       string
+	key         *expression.KeyConditionBuilder
+	conds       []expression.ConditionBuilder
+	newestFirst bool // Query the index's sort key in descending order
+}
+
+// where adds a filter condition.
+func (q *listQuery) where(c expression.ConditionBuilder) {
+	q.conds = append(q.conds, c)
+}
+
+// whereEqual filters on name = value when value is set.
+func (q *listQuery) whereEqual(name, value string) {
+	if value != "" {
+		q.where(expression.Name(name).Equal(expression.Value(value)))
+	}
+}
+
+// whereTime filters name (an RFC3339 string in UTC, so strings order by
+// time) to the range. Like issuedKey, it ends on the last stored second
+// before To.
+func (q *listQuery) whereTime(name string, r core.TimeRange) {
+	if !r.From.IsZero() {
+		q.where(expression.Name(name).GreaterThanEqual(expression.Value(r.From.UTC().Format(time.RFC3339))))
+	}
+	if !r.To.IsZero() {
+		q.where(expression.Name(name).LessThanEqual(expression.Value(lastSecondBefore(r.To))))
+	}
+}
+
+// lastSecondBefore is the latest time stored to the second, as RFC3339 in
+// UTC, that is before t: t's own second if t is past it, else the one before.
+func lastSecondBefore(t time.Time) string {
+	last := t.Truncate(time.Second)
+	if last.Equal(t) {
+		last = last.Add(-time.Second)
+	}
+	return last.UTC().Format(time.RFC3339)
+}
+
+// listPage reads one page of q. Scans, and queries on indexes without a sort
+// key, return items in storage order rather than creation order. Each read is limited to the items still wanted, so the last
+// LastEvaluatedKey is exactly where the next page starts.
+func listPage[I any, T any](ctx context.Context, client *dynamodb.Client, q listQuery, page core.PageRequest, conv func(I) T) (core.Page[T], error) {
+	startKey, err := decodeStartKey(page.Cursor)
+	if err != nil {
+		return core.Page[T]{}, err
+	}
+
+	builder := expression.NewBuilder()
+	if len(q.conds) > 0 {
+		cond := q.conds[0]
+		for _, c := range q.conds[1:] {
+			cond = cond.And(c)
+		}
+		builder = builder.WithFilter(cond)
+	}
+	if q.key != nil {
+		builder = builder.WithKeyCondition(*q.key)
+	}
+	var expr expression.Expression
+	if len(q.conds) > 0 || q.key != nil {
+		if expr, err = builder.Build(); err != nil {
+			return core.Page[T]{}, fmt.Errorf("%s.buildExpr: %w", q.table, err)
+		}
+	}
+
+	result := core.Page[T]{Items: make([]T, 0, page.Limit)}
+	for reads := 0; reads < maxPageReads; reads++ {
+		limit := aws.Int32(int32(page.Limit - len(result.Items)))
+
+		var raw []map[string]types.AttributeValue
+		if q.key != nil {
+			out, err := client.Query(ctx, &dynamodb.QueryInput{
+				TableName:                 aws.String(q.table),
+				IndexName:                 aws.String(q.index),
+				KeyConditionExpression:    expr.KeyCondition(),
+				FilterExpression:          expr.Filter(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+				ExclusiveStartKey:         startKey,
+				Limit:                     limit,
+				ScanIndexForward:          aws.Bool(!q.newestFirst),
+			})
+			if err != nil {
+				return core.Page[T]{}, fmt.Errorf("%s.query: %w", q.table, err)
+			}
+			raw, startKey = out.Items, out.LastEvaluatedKey
+		} else {
+			out, err := client.Scan(ctx, &dynamodb.ScanInput{
+				TableName:                 aws.String(q.table),
+				FilterExpression:          expr.Filter(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+				ExclusiveStartKey:         startKey,
+				Limit:                     limit,
+			})
+			if err != nil {
+				return core.Page[T]{}, fmt.Errorf("%s.scan: %w", q.table, err)
+			}
+			raw, startKey = out.Items, out.LastEvaluatedKey
+		}
+
+		var items []I
+		if err := attributevalue.UnmarshalListOfMaps(raw, &items); err != nil {
+			return core.Page[T]{}, fmt.Errorf("%s.unmarshal: %w", q.table, err)
+		}
+		for _, item := range items {
+			result.Items = append(result.Items, conv(item))
+		}
+
+		if len(startKey) == 0 || len(result.Items) >= page.Limit {
+			break
+		}
+	}
+
+	if len(startKey) > 0 {
+		if result.NextCursor, err = encodeStartKey(startKey); err != nil {
+			return core.Page[T]{}, err
+		}
+	}
+	return result, nil
+}
+
+func encodeStartKey(key map[string]types.AttributeValue) (string, error) {
+	c := pageCursor{Key: make(map[string]string, len(key))}
+	for name, v := range key {
+		s, ok := v.(*types.AttributeValueMemberS)
+		if !ok {
+			return "", fmt.Errorf("encode cursor: key attribute %q is not a string", name)
+		}
+		c.Key[name] = s.Value
+	}
+	return core.EncodeCursor(c)
+}
+
+func decodeStartKey(cursor string) (map[string]types.AttributeValue, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	var c pageCursor
+	if err := core.DecodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	if len(c.Key) == 0 {
+		return nil, core.ErrInvalidCursor
+	}
+	key := make(map[string]types.AttributeValue, len(c.Key))
+	for name, v := range c.Key {
+		key[name] = &types.AttributeValueMemberS{Value: v}
+	}
+	return key, nil
+}
@@ -0,0 +1,22 @@
+package dynamo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLastSecondBefore(t *testing.T) {
+	noon := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		to   time.Time
+		want string
+	}{
+		{noon, "2026-03-14T11:59:59Z"},
+		{noon.Add(500 * time.Millisecond), "2026-03-14T12:00:00Z"},
+		{noon.In(time.FixedZone("CEST", 2*60*60)), "2026-03-14T11:59:59Z"},
+	} {
+		if got := lastSecondBefore(tc.to); got != tc.want {
+			t.Errorf("lastSecondBefore(%s) = %s, want %s", tc.to.Format(time.RFC3339Nano), got, tc.want)
+		}
+	}
+}
@@ -29,8 +29,14 @@ type PolicyItem struct {
 	EffectiveDate  string        `dynamodbav:"effective_date"`
 	ExpiryDate     string        `dynamodbav:"expiry_date"`
 	IssuedAt       string        `dynamodbav:"issued_at"`
+	ListKey        string        `dynamodbav:"list_key"` // Always policyListKey; partition of the issued_at index
 }
 
+// policyListKey puts every policy in one partition of GSIPoliciesIssued, so
+// the whole book can be read in issue order. Policies are issued at a low
+// rate, well inside a single partition's write limits.
+const policyListKey = "policy"
+
 func (i PolicyItem) ToCore() core.Policy {
 	effectiveDate, _ := time.Parse(time.RFC3339, i.EffectiveDate)
 	expiryDate, _ := time.Parse(time.RFC3339, i.ExpiryDate)
@@ -82,7 +88,8 @@ func policyItemFromCore(p core.Policy) PolicyItem {
 		Status:        string(p.Status),
 		EffectiveDate: p.EffectiveDate.Format(time.RFC3339),
 		ExpiryDate:    p.ExpiryDate.Format(time.RFC3339),
-		IssuedAt:      p.IssuedAt.Format(time.RFC3339),
+		IssuedAt:      p.IssuedAt.UTC().Format(time.RFC3339), // Sort key: one zone so strings order by time
+		ListKey:       policyListKey,
 	}
 }
 
@@ -229,74 +236,65 @@ func (r *PolicyRepo) GetByApplicationID(ctx context.Context, appID string) (core
 	return item.ToCore(), nil
 }
 
-func (r *PolicyRepo) List(ctx context.Context, filter core.PolicyFilter, limit, offset int) ([]core.Policy, int64, error) {
-	// For DynamoDB, we'll use Scan with filters (not ideal for large datasets)
-	// In production, consider using GSIs for common query patterns
-	var filterExpr expression.ConditionBuilder
-	hasFilter := false
-
-	if filter.ApplicationID != "" {
-		filterExpr = expression.Name("application_id").Equal(expression.Value(filter.ApplicationID))
-		hasFilter = true
-	}
-	if filter.Status != "" {
-		statusFilter := expression.Name("status").Equal(expression.Value(string(filter.Status)))
-		if hasFilter {
-			filterExpr = filterExpr.And(statusFilter)
-		} else {
-			filterExpr = statusFilter
-			hasFilter = true
-		}
-	}
-
-	var scanInput *dynamodb.ScanInput
-	if hasFilter {
-		expr, err := expression.NewBuilder().WithFilter(filterExpr).Build()
-		if err != nil {
-			return nil, 0, fmt.Errorf("policies.buildExpr: %w", err)
-		}
-		scanInput = &dynamodb.ScanInput{
-			TableName:                 aws.String(TablePolicies),
-			FilterExpression:          expr.Filter(),
-			ExpressionAttributeNames:  expr.Names(),
-			ExpressionAttributeValues: expr.Values(),
-		}
-	} else {
-		scanInput = &dynamodb.ScanInput{
-			TableName: aws.String(TablePolicies),
-		}
-	}
-
-	out, err := r.client.Scan(ctx, scanInput)
-	if err != nil {
-		return nil, 0, fmt.Errorf("policies.scan: %w", err)
-	}
+func (r *PolicyRepo) FindByApplicationIDs(ctx context.Context, appIDs []string) ([]core.Policy, error) {
+	return getEach(ctx, appIDs, r.GetByApplicationID)
+}
 
-	var items []PolicyItem
-	if err := attributevalue.UnmarshalListOfMaps(out.Items, &items); err != nil {
-		return nil, 0, fmt.Errorf("policies.unmarshal: %w", err)
+// List returns policies newest first. A status filter reads the status
+// index, no filter reads the issued_at index, and an application ID reads its
+// own index; the issue-time range is part of the key condition where possible.
+func (r *PolicyRepo) List(ctx context.Context, filter core.PolicyFilter, page core.PageRequest) (core.Page[core.Policy], error) {
+	q := listQuery{table: TablePolicies, newestFirst: true}
+	switch {
+	case filter.ApplicationID != "":
+		key := expression.Key("application_id").Equal(expression.Value(filter.ApplicationID))
+		q.index, q.key = GSIPoliciesAppID, &key
+		q.whereEqual("status", string(filter.Status))
+		q.whereTime("issued_at", filter.Issued)
+	case filter.Status != "":
+		key := issuedKey(expression.Key("status").Equal(expression.Value(string(filter.Status))), filter.Issued)
+		q.index, q.key = GSIPoliciesStatus, &key
+	default:
+		key := issuedKey(expression.Key("list_key").Equal(expression.Value(policyListKey)), filter.Issued)
+		q.index, q.key = GSIPoliciesIssued, &key
 	}
+	q.whereEqual("product_slug", filter.ProductSlug)
+	q.whereEqual("insured.email", filter.InsuredEmail)
 
-	total := int64(len(items))
-
-	// Apply offset and limit manually (DynamoDB pagination is different)
-	if offset >= len(items) {
-		return []core.Policy{}, total, nil
-	}
+	return listPage(ctx, r.client, q, page, PolicyItem.ToCore)
+}
 
-	end := offset + limit
-	if end > len(items) {
-		end = len(items)
+// issuedKey adds the issue-time range to a key condition on an index sorted
+// by issued_at. Times are stored to the second, so the exclusive upper bound
+// becomes an inclusive one on the last stored second before it.
+func issuedKey(key expression.KeyConditionBuilder, r core.TimeRange) expression.KeyConditionBuilder {
+	sortKey := expression.Key("issued_at")
+	from := expression.Value(r.From.UTC().Format(time.RFC3339))
+	switch {
+	case !r.From.IsZero() && !r.To.IsZero():
+		return key.And(sortKey.Between(from, expression.Value(lastSecondBefore(r.To))))
+	case !r.From.IsZero():
+		return key.And(sortKey.GreaterThanEqual(from))
+	case !r.To.IsZero():
+		return key.And(sortKey.LessThanEqual(expression.Value(lastSecondBefore(r.To))))
 	}
+	return key
+}
 
-	items = items[offset:end]
-
-	policies := make([]core.Policy, len(items))
-	for i, item := range items {
-		policies[i] = item.ToCore()
+func (r *PolicyRepo) ExpirePolicies(ctx context.Context, before time.Time) ([]core.Policy, error) {
+	key := expression.Key("status").Equal(expression.Value(string(core.PolicyStatusActive)))
+	q := listQuery{table: TablePolicies, index: GSIPoliciesStatus, key: &key}
+
+	expired, err := expireItems(ctx, r.client, q, string(core.PolicyStatusExpired),
+		func(i PolicyItem) string { return i.ID },
+		func(i *PolicyItem) *string { return &i.Status },
+		nil,
+		func(i PolicyItem) bool { return i.ToCore().ExpiryDate.Before(before) })
+	policies := make([]core.Policy, len(expired))
+	for n, item := range expired {
+		policies[n] = item.ToCore()
 	}
-
-	return policies, total, nil
+	return policies, err
 }
 
 func (r *PolicyRepo) NextPolicyNumber(ctx context.Context) (string, error) {
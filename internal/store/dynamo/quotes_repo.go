@@ -23,7 +23,7 @@ type QuoteItem struct {
 	TermYears      int     `dynamodbav:"term_years"`
 	MonthlyPremium float64 `dynamodbav:"monthly_premium"`
 	Status         string  `dynamodbav:"status"`
-	CreatedAt      string  `dynamodbav:"created_at"`
+	CreatedAt      string  `dynamodbav:"created_at"` // RFC3339 in UTC, so ranges compare as strings
 	ExpiresAt      string  `dynamodbav:"expires_at"`
 }
 
@@ -52,7 +52,7 @@ func quoteItemFromCore(q core.Quote) QuoteItem {
 		TermYears:      q.TermYears,
 		MonthlyPremium: q.MonthlyPremium,
 		Status:         string(q.Status),
-		CreatedAt:      q.CreatedAt.Format(time.RFC3339),
+		CreatedAt:      q.CreatedAt.UTC().Format(time.RFC3339),
 		ExpiresAt:      q.ExpiresAt.Format(time.RFC3339),
 	}
 }
@@ -118,3 +118,35 @@ func (r *QuoteRepo) Get(ctx context.Context, id string) (core.Quote, error) {
 
 	return item.ToCore(), nil
 }
+
+func (r *QuoteRepo) GetMany(ctx context.Context, ids []string) ([]core.Quote, error) {
+	items, err := batchGet[QuoteItem](ctx, r.client, TableQuotes, ids)
+	if err != nil {
+		return nil, fmt.Errorf("quotes.batchGet: %w", err)
+	}
+	return items, nil
+}
+
+func (r *QuoteRepo) List(ctx context.Context, filter core.QuoteFilter, page core.PageRequest) (core.Page[core.Quote], error) {
+	q := listQuery{table: TableQuotes}
+	q.whereEqual("status", string(filter.Status))
+	q.whereEqual("product_slug", filter.ProductSlug)
+	q.whereTime("created_at", filter.Created)
+
+	return listPage(ctx, r.client, q, page, QuoteItem.ToCore)
+}
+
+func (r *QuoteRepo) ExpireQuotes(ctx context.Context, before time.Time) (int64, error) {
+	q := listQuery{table: TableQuotes}
+	q.where(expression.Name("status").In(
+		expression.Value(string(core.QuoteStatusNew)),
+		expression.Value(string(core.QuoteStatusPriced)),
+	))
+
+	expired, err := expireItems(ctx, r.client, q, string(core.QuoteStatusExpired),
+		func(i QuoteItem) string { return i.ID },
+		func(i *QuoteItem) *string { return &i.Status },
+		nil,
+		func(i QuoteItem) bool { return i.ToCore().ExpiresAt.Before(before) })
+	return int64(len(expired)), err
+}
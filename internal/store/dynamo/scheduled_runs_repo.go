@@ -0,0 +1,102 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type ScheduledRunItem struct {
+	ID         string `dynamodbav:"id"`
+	Task       string `dynamodbav:"task"`
+	Status     string `dynamodbav:"status"`
+	Affected   int64  `dynamodbav:"affected"`
+	Error      string `dynamodbav:"error,omitempty"`
+	Owner      string `dynamodbav:"owner"`
+	StartedAt  string `dynamodbav:"started_at"` // RFC3339 in UTC, the indexes' sort key
+	FinishedAt string `dynamodbav:"finished_at"`
+	ExpiresAt  int64  `dynamodbav:"expires_at"` // Unix seconds; the table's TTL attribute
+	ListKey    string `dynamodbav:"list_key"`   // Always runListKey; partition of the started_at index
+}
+
+// runListKey puts every run in one partition of GSIRunsStarted, like
+// policyListKey. Runs are recorded a few times an hour.
+const runListKey = "run"
+
+func (i ScheduledRunItem) ToCore() core.ScheduledRun {
+	startedAt, _ := time.Parse(time.RFC3339, i.StartedAt)
+	finishedAt, _ := time.Parse(time.RFC3339, i.FinishedAt)
+	return core.ScheduledRun{
+		ID:         i.ID,
+		Task:       i.Task,
+		Status:     core.ScheduledRunStatus(i.Status),
+		Affected:   i.Affected,
+		Error:      i.Error,
+		Owner:      i.Owner,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		ExpiresAt:  time.Unix(i.ExpiresAt, 0).UTC(),
+	}
+}
+
+func scheduledRunItemFromCore(r core.ScheduledRun) ScheduledRunItem {
+	return ScheduledRunItem{
+		ID:         r.ID,
+		Task:       r.Task,
+		Status:     string(r.Status),
+		Affected:   r.Affected,
+		Error:      r.Error,
+		Owner:      r.Owner,
+		StartedAt:  r.StartedAt.UTC().Format(time.RFC3339),
+		FinishedAt: r.FinishedAt.UTC().Format(time.RFC3339),
+		ExpiresAt:  r.ExpiresAt.Unix(),
+		ListKey:    runListKey,
+	}
+}
+
+type ScheduledRunRepo struct {
+	client *dynamodb.Client
+}
+
+func NewScheduledRunRepo(client *dynamodb.Client) *ScheduledRunRepo {
+	return &ScheduledRunRepo{client: client}
+}
+
+func (r *ScheduledRunRepo) Create(ctx context.Context, run core.ScheduledRun) error {
+	av, err := attributevalue.MarshalMap(scheduledRunItemFromCore(run))
+	if err != nil {
+		return fmt.Errorf("scheduled_runs.marshal: %w", err)
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(TableScheduledRuns),
+		Item:      av,
+	})
+	if err != nil {
+		return fmt.Errorf("scheduled_runs.putItem: %w", err)
+	}
+	return nil
+}
+
+// List returns runs newest first, from the task index when filtering by
+// task and from the started_at index otherwise.
+func (r *ScheduledRunRepo) List(ctx context.Context, filter core.ScheduledRunFilter, page core.PageRequest) (core.Page[core.ScheduledRun], error) {
+	q := listQuery{table: TableScheduledRuns, newestFirst: true}
+	if filter.Task != "" {
+		key := expression.Key("task").Equal(expression.Value(filter.Task))
+		q.index, q.key = GSIRunsTask, &key
+	} else {
+		key := expression.Key("list_key").Equal(expression.Value(runListKey))
+		q.index, q.key = GSIRunsStarted, &key
+	}
+	q.whereEqual("status", string(filter.Status))
+
+	return listPage(ctx, r.client, q, page, ScheduledRunItem.ToCore)
+}
@@ -0,0 +1,99 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// tableIndexes lists every table with the GSIs the repos query, as
+// EnsureTables and its migrations leave them.
+var tableIndexes = map[string][]string{
+	TableProducts:      {GSIProductsSlug},
+	TableQuotes:        nil,
+	TableApplications:  {GSIApplicationsStatus, GSIApplicationsQuoteID},
+	TableUWCases:       {GSIUWCasesAppID, GSIUWCasesDecision},
+	TableOffers:        {GSIOffersAppID, GSIOffersStatus},
+	TablePolicies:      {GSIPoliciesNumber, GSIPoliciesAppID, GSIPoliciesOfferID, GSIPoliciesStatus, GSIPoliciesIssued},
+	TableCounters:      nil,
+	TableAPIKeys:       {GSIAPIKeysHash},
+	TableIdempotency:   nil,
+	TableLeases:        nil,
+	TableJobs:          {GSIJobsStatusRunAt},
+	TableScheduledRuns: {GSIRunsTask, GSIRunsStarted},
+	TableAudit:         {GSIAuditSubject},
+}
+
+// CheckTables reports the status of every table, and fails if any table or
+// GSI is missing or not active, for the health report.
+func CheckTables(ctx context.Context, db *dynamodb.Client) (map[string]string, error) {
+	statuses := make(map[string]string, len(tableIndexes))
+	var (
+		problems []string
+		mu       sync.Mutex
+	)
+
+	g, ctx := errgroup.WithContext(ctx)
+	for table, indexes := range tableIndexes {
+		g.Go(func() error {
+			status, problem, err := checkTable(ctx, db, table, indexes)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			statuses[table] = status
+			if problem != "" {
+				problems = append(problems, problem)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return statuses, err
+	}
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return statuses, errors.New(strings.Join(problems, "; "))
+	}
+	return statuses, nil
+}
+
+// checkTable returns the status of table and what is wrong with it, if
+// anything.
+func checkTable(ctx context.Context, db *dynamodb.Client, table string, indexes []string) (string, string, error) {
+	out, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(table)})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return "MISSING", "table " + table + " is missing", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("describe table %s: %w", table, err)
+	}
+
+	status := string(out.Table.TableStatus)
+	if out.Table.TableStatus != types.TableStatusActive && out.Table.TableStatus != types.TableStatusUpdating {
+		return status, "table " + table + " is " + status, nil
+	}
+	gsis := make(map[string]types.IndexStatus, len(out.Table.GlobalSecondaryIndexes))
+	for _, gsi := range out.Table.GlobalSecondaryIndexes {
+		gsis[aws.ToString(gsi.IndexName)] = gsi.IndexStatus
+	}
+	for _, index := range indexes {
+		switch s, ok := gsis[index]; {
+		case !ok:
+			return status, "index " + index + " of " + table + " is missing", nil
+		case s != types.IndexStatusActive:
+			return status, "index " + index + " of " + table + " is " + string(s), nil
+		}
+	}
+	return status, "", nil
+}
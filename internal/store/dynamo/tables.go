@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -13,13 +14,19 @@ import (
 
 // Table names
 const (
-	TableProducts     = "insurance_products"
-	TableQuotes       = "insurance_quotes"
-	TableApplications = "insurance_applications"
-	TableUWCases      = "insurance_underwriting_cases"
-	TableOffers       = "insurance_offers"
-	TablePolicies     = "insurance_policies"
-	TableCounters     = "insurance_counters" // For policy number generation
+	TableProducts      = "insurance_products"
+	TableQuotes        = "insurance_quotes"
+	TableApplications  = "insurance_applications"
+	TableUWCases       = "insurance_underwriting_cases"
+	TableOffers        = "insurance_offers"
+	TablePolicies      = "insurance_policies"
+	TableCounters      = "insurance_counters" // For policy number generation
+	TableAPIKeys       = "insurance_api_keys"
+	TableIdempotency   = "insurance_idempotency_keys"
+	TableLeases        = "insurance_leases" // Leader leases of singleton jobs
+	TableJobs          = "insurance_jobs"
+	TableScheduledRuns = "insurance_scheduled_runs"
+	TableAudit         = "insurance_audit_log"
 )
 
 // GSI names
@@ -33,7 +40,14 @@ const (
 	GSIPoliciesNumber       = "number-index"
 	GSIPoliciesAppID        = "application_id-index"
 	GSIPoliciesOfferID      = "offer_id-index"
+	GSIPoliciesStatus       = "status-issued_at-index"
+	GSIPoliciesIssued       = "issued_at-index"
 	GSIProductsSlug         = "slug-index"
+	GSIAPIKeysHash          = "hash-index"
+	GSIJobsStatusRunAt      = "status-run_at-index"
+	GSIRunsTask             = "task-started_at-index"
+	GSIRunsStarted          = "started_at-index"
+	GSIAuditSubject         = "subject-id-index"
 )
 
 // EnsureTables creates all required tables if they don't exist.
@@ -49,6 +63,12 @@ func EnsureTables(ctx context.Context, client *dynamodb.Client, log *slog.Logger
 		{TableOffers, createOffersTable},
 		{TablePolicies, createPoliciesTable},
 		{TableCounters, createCountersTable},
+		{TableAPIKeys, createAPIKeysTable},
+		{TableIdempotency, createIdempotencyTable},
+		{TableLeases, createLeasesTable},
+		{TableJobs, createJobsTable},
+		{TableScheduledRuns, createScheduledRunsTable},
+		{TableAudit, createAuditTable},
 	}
 
 	for _, t := range tables {
@@ -65,12 +85,292 @@ func EnsureTables(ctx context.Context, client *dynamodb.Client, log *slog.Logger
 		if err := t.create(ctx, client); err != nil {
 			return fmt.Errorf("create table %s: %w", t.name, err)
 		}
+		// Migrations below read the new tables, which must be active first
+		waiter := dynamodb.NewTableExistsWaiter(client)
+		if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(t.name)}, 2*time.Minute); err != nil {
+			return fmt.Errorf("wait for table %s: %w", t.name, err)
+		}
 		log.Info("table created", "table", t.name)
 	}
 
+	return runMigrations(ctx, client, log)
+}
+
+// migration changes existing tables in ways creating them can't, such as
+// adding an index. Each runs once and must be safe to re-run if interrupted;
+// completion is recorded in the counters table.
+type migration struct {
+	id  string
+	run func(context.Context, *dynamodb.Client, *slog.Logger) error
+}
+
+// migrations run in order after the tables exist. Append only.
+var migrations = []migration{
+	{"2026-10-policies-list-indexes", migratePolicyListIndexes},
+	{"2026-10-jobs-stream", migrateJobsStream},
+	{"2026-10-created-at-utc", migrateCreatedAtUTC},
+}
+
+func runMigrations(ctx context.Context, client *dynamodb.Client, log *slog.Logger) error {
+	for _, m := range migrations {
+		key := map[string]types.AttributeValue{
+			"counter_name": &types.AttributeValueMemberS{Value: "migration#" + m.id},
+		}
+		out, err := client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName:      aws.String(TableCounters),
+			Key:            key,
+			ConsistentRead: aws.Bool(true),
+		})
+		if err != nil {
+			return fmt.Errorf("check migration %s: %w", m.id, err)
+		}
+		if out.Item != nil {
+			continue
+		}
+
+		log.Info("running migration", "migration", m.id)
+		if err := m.run(ctx, client, log); err != nil {
+			return fmt.Errorf("migration %s: %w", m.id, err)
+		}
+
+		key["applied_at"] = &types.AttributeValueMemberS{Value: time.Now().UTC().Format(time.RFC3339)}
+		if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String(TableCounters),
+			Item:      key,
+		}); err != nil {
+			return fmt.Errorf("record migration %s: %w", m.id, err)
+		}
+		log.Info("migration applied", "migration", m.id)
+	}
+	return nil
+}
+
+// migratePolicyListIndexes backfills list_key on policies written before it
+// existed, then adds the status and issued_at indexes that List reads.
+func migratePolicyListIndexes(ctx context.Context, client *dynamodb.Client, log *slog.Logger) error {
+	backfilled := 0
+	var startKey map[string]types.AttributeValue
+	for {
+		out, err := client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:            aws.String(TablePolicies),
+			FilterExpression:     aws.String("attribute_not_exists(list_key)"),
+			ProjectionExpression: aws.String("id, issued_at"),
+			ExclusiveStartKey:    startKey,
+		})
+		if err != nil {
+			return fmt.Errorf("scan policies: %w", err)
+		}
+
+		for _, item := range out.Items {
+			// issued_at is re-written in UTC, since the index sorts it as a string
+			update := "SET list_key = :k"
+			values := map[string]types.AttributeValue{
+				":k": &types.AttributeValueMemberS{Value: policyListKey},
+			}
+			if v, ok := item["issued_at"].(*types.AttributeValueMemberS); ok {
+				if t, err := time.Parse(time.RFC3339, v.Value); err == nil {
+					update += ", issued_at = :i"
+					values[":i"] = &types.AttributeValueMemberS{Value: t.UTC().Format(time.RFC3339)}
+				}
+			}
+			_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName:                 aws.String(TablePolicies),
+				Key:                       map[string]types.AttributeValue{"id": item["id"]},
+				UpdateExpression:          aws.String(update),
+				ConditionExpression:       aws.String("attribute_exists(id)"),
+				ExpressionAttributeValues: values,
+			})
+			var ccf *types.ConditionalCheckFailedException
+			if errors.As(err, &ccf) {
+				continue // Deleted since the scan
+			}
+			if err != nil {
+				return fmt.Errorf("backfill policy: %w", err)
+			}
+			backfilled++
+		}
+
+		if len(out.LastEvaluatedKey) == 0 {
+			break
+		}
+		startKey = out.LastEvaluatedKey
+	}
+	log.Info("policies backfilled", "count", backfilled)
+
+	for _, gsi := range policyListIndexes() {
+		if err := addIndex(ctx, client, TablePolicies, gsi, log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateJobsStream enables the stream WatchQueuedJobs reads on a jobs
+// table created without one.
+func migrateJobsStream(ctx context.Context, client *dynamodb.Client, log *slog.Logger) error {
+	out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(TableJobs)})
+	if err != nil {
+		return fmt.Errorf("describe jobs: %w", err)
+	}
+	if spec := out.Table.StreamSpecification; spec != nil && aws.ToBool(spec.StreamEnabled) {
+		return nil
+	}
+
+	log.Info("enabling stream", "table", TableJobs)
+	if _, err := client.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+		TableName:           aws.String(TableJobs),
+		StreamSpecification: jobsStream(),
+	}); err != nil {
+		return fmt.Errorf("enable jobs stream: %w", err)
+	}
+	waiter := dynamodb.NewTableExistsWaiter(client)
+	return waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(TableJobs)}, 2*time.Minute)
+}
+
+// migrateCreatedAtUTC re-writes created_at in UTC on items written with a
+// local offset, since list filters compare it as a string.
+func migrateCreatedAtUTC(ctx context.Context, client *dynamodb.Client, log *slog.Logger) error {
+	for _, table := range []string{TableQuotes, TableApplications, TableUWCases, TableOffers, TableJobs} {
+		rewritten := 0
+		var startKey map[string]types.AttributeValue
+		for {
+			out, err := client.Scan(ctx, &dynamodb.ScanInput{
+				TableName:            aws.String(table),
+				ProjectionExpression: aws.String("id, created_at"),
+				ExclusiveStartKey:    startKey,
+			})
+			if err != nil {
+				return fmt.Errorf("scan %s: %w", table, err)
+			}
+
+			for _, item := range out.Items {
+				v, ok := item["created_at"].(*types.AttributeValueMemberS)
+				if !ok {
+					continue
+				}
+				t, err := time.Parse(time.RFC3339, v.Value)
+				if err != nil || t.UTC().Format(time.RFC3339) == v.Value {
+					continue
+				}
+				_, err = client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+					TableName:           aws.String(table),
+					Key:                 map[string]types.AttributeValue{"id": item["id"]},
+					UpdateExpression:    aws.String("SET created_at = :c"),
+					ConditionExpression: aws.String("attribute_exists(id)"),
+					ExpressionAttributeValues: map[string]types.AttributeValue{
+						":c": &types.AttributeValueMemberS{Value: t.UTC().Format(time.RFC3339)},
+					},
+				})
+				var ccf *types.ConditionalCheckFailedException
+				if errors.As(err, &ccf) {
+					continue // Deleted since the scan
+				}
+				if err != nil {
+					return fmt.Errorf("rewrite %s created_at: %w", table, err)
+				}
+				rewritten++
+			}
+
+			if len(out.LastEvaluatedKey) == 0 {
+				break
+			}
+			startKey = out.LastEvaluatedKey
+		}
+		log.Info("created_at normalized", "table", table, "count", rewritten)
+	}
+	return nil
+}
+
+// jobsStream is the jobs table's stream. Only new images are needed, to see
+// which jobs became queued.
+func jobsStream() *types.StreamSpecification {
+	return &types.StreamSpecification{
+		StreamEnabled:  aws.Bool(true),
+		StreamViewType: types.StreamViewTypeNewImage,
+	}
+}
+
+// policyListIndexes are the policy indexes sorted by issue time.
+func policyListIndexes() []types.GlobalSecondaryIndex {
+	return []types.GlobalSecondaryIndex{
+		{
+			IndexName: aws.String(GSIPoliciesStatus),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("status"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String("issued_at"), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		},
+		{
+			IndexName: aws.String(GSIPoliciesIssued),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("list_key"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String("issued_at"), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		},
+	}
+}
+
+// addIndex creates gsi on an existing table, if missing, and waits until
+// DynamoDB has finished building it. Only one index can be built at a time.
+func addIndex(ctx context.Context, client *dynamodb.Client, table string, gsi types.GlobalSecondaryIndex, log *slog.Logger) error {
+	name := aws.ToString(gsi.IndexName)
+	status, err := indexStatus(ctx, client, table, name)
+	if err != nil {
+		return err
+	}
+
+	if status == "" {
+		var attrs []types.AttributeDefinition
+		for _, k := range gsi.KeySchema {
+			attrs = append(attrs, types.AttributeDefinition{AttributeName: k.AttributeName, AttributeType: types.ScalarAttributeTypeS})
+		}
+		log.Info("creating index", "table", table, "index", name)
+		_, err := client.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName:            aws.String(table),
+			AttributeDefinitions: attrs,
+			GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{
+				{Create: &types.CreateGlobalSecondaryIndexAction{
+					IndexName:  gsi.IndexName,
+					KeySchema:  gsi.KeySchema,
+					Projection: gsi.Projection,
+				}},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("create index %s: %w", name, err)
+		}
+	}
+
+	for status != types.IndexStatusActive {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+		if status, err = indexStatus(ctx, client, table, name); err != nil {
+			return err
+		}
+		log.Info("waiting for index", "table", table, "index", name, "status", status)
+	}
 	return nil
 }
 
+// indexStatus returns the status of a table's GSI, or "" if it has none by that name.
+func indexStatus(ctx context.Context, client *dynamodb.Client, table, index string) (types.IndexStatus, error) {
+	out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(table)})
+	if err != nil {
+		return "", fmt.Errorf("describe table %s: %w", table, err)
+	}
+	for _, gsi := range out.Table.GlobalSecondaryIndexes {
+		if aws.ToString(gsi.IndexName) == index {
+			return gsi.IndexStatus, nil
+		}
+	}
+	return "", nil
+}
+
 func tableExists(ctx context.Context, client *dynamodb.Client, name string) (bool, error) {
 	_, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
 		TableName: aws.String(name),
@@ -230,8 +530,11 @@ func createPoliciesTable(ctx context.Context, client *dynamodb.Client) error {
 			{AttributeName: aws.String("number"), AttributeType: types.ScalarAttributeTypeS},
 			{AttributeName: aws.String("application_id"), AttributeType: types.ScalarAttributeTypeS},
 			{AttributeName: aws.String("offer_id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("status"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("issued_at"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("list_key"), AttributeType: types.ScalarAttributeTypeS},
 		},
-		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+		GlobalSecondaryIndexes: append([]types.GlobalSecondaryIndex{
 			{
 				IndexName: aws.String(GSIPoliciesNumber),
 				KeySchema: []types.KeySchemaElement{
@@ -253,7 +556,7 @@ func createPoliciesTable(ctx context.Context, client *dynamodb.Client) error {
 				},
 				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
 			},
-		},
+		}, policyListIndexes()...),
 		BillingMode: types.BillingModePayPerRequest,
 	})
 	return err
@@ -272,3 +575,191 @@ func createCountersTable(ctx context.Context, client *dynamodb.Client) error {
 	})
 	return err
 }
+
+func createAPIKeysTable(ctx context.Context, client *dynamodb.Client) error {
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName: aws.String(TableAPIKeys),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+		},
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("hash"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String(GSIAPIKeysHash),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("hash"), KeyType: types.KeyTypeHash},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	return err
+}
+
+func createLeasesTable(ctx context.Context, client *dynamodb.Client) error {
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName: aws.String(TableLeases),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("name"), KeyType: types.KeyTypeHash},
+		},
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("name"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	return err
+}
+
+func createIdempotencyTable(ctx context.Context, client *dynamodb.Client) error {
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName: aws.String(TableIdempotency),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+		},
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	if err != nil {
+		return err
+	}
+
+	// TTL can only be enabled once the table is active
+	waiter := dynamodb.NewTableExistsWaiter(client)
+	if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(TableIdempotency)}, 2*time.Minute); err != nil {
+		return err
+	}
+	_, err = client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(TableIdempotency),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String("expires_at"),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	return err
+}
+
+func createJobsTable(ctx context.Context, client *dynamodb.Client) error {
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName: aws.String(TableJobs),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+		},
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("status"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("run_at"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String(GSIJobsStatusRunAt),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("status"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("run_at"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+		},
+		StreamSpecification: jobsStream(),
+		BillingMode:         types.BillingModePayPerRequest,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Succeeded jobs expire; TTL can only be enabled once the table is active
+	waiter := dynamodb.NewTableExistsWaiter(client)
+	if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(TableJobs)}, 2*time.Minute); err != nil {
+		return err
+	}
+	_, err = client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(TableJobs),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String("expires_at"),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	return err
+}
+
+func createScheduledRunsTable(ctx context.Context, client *dynamodb.Client) error {
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName: aws.String(TableScheduledRuns),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+		},
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("task"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("started_at"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("list_key"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String(GSIRunsTask),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("task"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("started_at"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+			{
+				IndexName: aws.String(GSIRunsStarted),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("list_key"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("started_at"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Old runs expire; TTL can only be enabled once the table is active
+	waiter := dynamodb.NewTableExistsWaiter(client)
+	if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(TableScheduledRuns)}, 2*time.Minute); err != nil {
+		return err
+	}
+	_, err = client.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(TableScheduledRuns),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String("expires_at"),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	return err
+}
+
+func createAuditTable(ctx context.Context, client *dynamodb.Client) error {
+	_, err := client.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName: aws.String(TableAudit),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+		},
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("subject"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+			{
+				// A record's history: ULID IDs sort in the order entries were made
+				IndexName: aws.String(GSIAuditSubject),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("subject"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("id"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	return err
+}
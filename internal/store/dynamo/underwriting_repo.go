@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -28,6 +30,35 @@ type RiskScoreItem struct {
 	Recommended string   `dynamodbav:"recommended"`
 }
 
+type UWDecisionStepItem struct {
+	Underwriter string `dynamodbav:"underwriter"`
+	Action      string `dynamodbav:"action"`
+	Decision    string `dynamodbav:"decision"`
+	Reason      string `dynamodbav:"reason"`
+	At          string `dynamodbav:"at"`
+}
+
+func (i UWDecisionStepItem) ToCore() core.UWDecisionStep {
+	at, _ := time.Parse(time.RFC3339, i.At)
+	return core.UWDecisionStep{
+		Underwriter: i.Underwriter,
+		Action:      core.UWStepAction(i.Action),
+		Decision:    core.UWDecision(i.Decision),
+		Reason:      i.Reason,
+		At:          at,
+	}
+}
+
+func uwDecisionStepItemFromCore(st core.UWDecisionStep) UWDecisionStepItem {
+	return UWDecisionStepItem{
+		Underwriter: st.Underwriter,
+		Action:      string(st.Action),
+		Decision:    string(st.Decision),
+		Reason:      st.Reason,
+		At:          st.At.Format(time.RFC3339),
+	}
+}
+
 type UnderwritingCaseItem struct {
 	ID            string          `dynamodbav:"id"`
 	ApplicationID string          `dynamodbav:"application_id"`
@@ -37,9 +68,35 @@ type UnderwritingCaseItem struct {
 	Method        string          `dynamodbav:"method"`
 	DecidedBy     string          `dynamodbav:"decided_by"`
 	Reason        string          `dynamodbav:"reason"`
-	CreatedAt     string          `dynamodbav:"created_at"`
+	CreatedAt     string          `dynamodbav:"created_at"` // RFC3339 in UTC, so ranges compare as strings
 	UpdatedAt     string          `dynamodbav:"updated_at"`
 	DecidedAt     string          `dynamodbav:"decided_at,omitempty"`
+	AssignedTo    string          `dynamodbav:"assigned_to,omitempty"`
+	AssignedAt    string          `dynamodbav:"assigned_at,omitempty"`
+	SLADueAt      string          `dynamodbav:"sla_due_at,omitempty"`
+	EscalatedAt   string          `dynamodbav:"escalated_at,omitempty"`
+
+	PendingDecision *UWDecisionStepItem  `dynamodbav:"pending_decision,omitempty"`
+	DecisionChain   []UWDecisionStepItem `dynamodbav:"decision_chain,omitempty"`
+
+	Version int `dynamodbav:"version"`
+}
+
+// parseOptionalTime converts an omitempty RFC3339 attribute back to a pointer.
+func parseOptionalTime(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, _ := time.Parse(time.RFC3339, s)
+	return &t
+}
+
+// formatOptionalTime is the inverse of parseOptionalTime.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
 }
 
 func (i UnderwritingCaseItem) ToCore() core.UnderwritingCase {
@@ -56,6 +113,16 @@ func (i UnderwritingCaseItem) ToCore() core.UnderwritingCase {
 		flags = []string{}
 	}
 
+	var pending *core.UWDecisionStep
+	if i.PendingDecision != nil {
+		st := i.PendingDecision.ToCore()
+		pending = &st
+	}
+	var chain []core.UWDecisionStep
+	for _, st := range i.DecisionChain {
+		chain = append(chain, st.ToCore())
+	}
+
 	return core.UnderwritingCase{
 		ID:            i.ID,
 		ApplicationID: i.ApplicationID,
@@ -70,13 +137,22 @@ func (i UnderwritingCaseItem) ToCore() core.UnderwritingCase {
 			Flags:       flags,
 			Recommended: core.UWDecision(i.RiskScore.Recommended),
 		},
-		Decision:  core.UWDecision(i.Decision),
-		Method:    core.UWMethod(i.Method),
-		DecidedBy: i.DecidedBy,
-		Reason:    i.Reason,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
-		DecidedAt: decidedAt,
+		Decision:    core.UWDecision(i.Decision),
+		Method:      core.UWMethod(i.Method),
+		DecidedBy:   i.DecidedBy,
+		Reason:      i.Reason,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		DecidedAt:   decidedAt,
+		AssignedTo:  i.AssignedTo,
+		AssignedAt:  parseOptionalTime(i.AssignedAt),
+		SLADueAt:    parseOptionalTime(i.SLADueAt),
+		EscalatedAt: parseOptionalTime(i.EscalatedAt),
+
+		PendingDecision: pending,
+		DecisionChain:   chain,
+
+		Version: i.Version,
 	}
 }
 
@@ -95,16 +171,28 @@ func uwCaseItemFromCore(uw core.UnderwritingCase) UnderwritingCaseItem {
 			Flags:       uw.RiskScore.Flags,
 			Recommended: string(uw.RiskScore.Recommended),
 		},
-		Decision:  string(uw.Decision),
-		Method:    string(uw.Method),
-		DecidedBy: uw.DecidedBy,
-		Reason:    uw.Reason,
-		CreatedAt: uw.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: uw.UpdatedAt.Format(time.RFC3339),
+		Decision:    string(uw.Decision),
+		Method:      string(uw.Method),
+		DecidedBy:   uw.DecidedBy,
+		Reason:      uw.Reason,
+		CreatedAt:   uw.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   uw.UpdatedAt.Format(time.RFC3339),
+		AssignedTo:  uw.AssignedTo,
+		AssignedAt:  formatOptionalTime(uw.AssignedAt),
+		SLADueAt:    formatOptionalTime(uw.SLADueAt),
+		EscalatedAt: formatOptionalTime(uw.EscalatedAt),
+		Version:     uw.Version,
 	}
 	if uw.DecidedAt != nil {
 		item.DecidedAt = uw.DecidedAt.Format(time.RFC3339)
 	}
+	if uw.PendingDecision != nil {
+		st := uwDecisionStepItemFromCore(*uw.PendingDecision)
+		item.PendingDecision = &st
+	}
+	for _, st := range uw.DecisionChain {
+		item.DecisionChain = append(item.DecisionChain, uwDecisionStepItemFromCore(st))
+	}
 	return item
 }
 
@@ -196,15 +284,19 @@ func (r *UnderwritingRepo) GetByApplicationID(ctx context.Context, appID string)
 	return item.ToCore(), nil
 }
 
+func (r *UnderwritingRepo) FindByApplicationIDs(ctx context.Context, appIDs []string) ([]core.UnderwritingCase, error) {
+	return getEach(ctx, appIDs, r.GetByApplicationID)
+}
+
 func (r *UnderwritingRepo) Update(ctx context.Context, uw core.UnderwritingCase) error {
 	item := uwCaseItemFromCore(uw)
+	item.Version++
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
 		return fmt.Errorf("underwriting.marshal: %w", err)
 	}
 
-	cond := expression.AttributeExists(expression.Name("id"))
-	expr, err := expression.NewBuilder().WithCondition(cond).Build()
+	expr, err := expression.NewBuilder().WithCondition(atVersion(uw.Version)).Build()
 	if err != nil {
 		return fmt.Errorf("underwriting.buildExpr: %w", err)
 	}
@@ -219,7 +311,7 @@ func (r *UnderwritingRepo) Update(ctx context.Context, uw core.UnderwritingCase)
 	if err != nil {
 		var ccf *types.ConditionalCheckFailedException
 		if errors.As(err, &ccf) {
-			return core.ErrUWCaseNotFound
+			return versionFailure(ctx, uw.ID, r.Get)
 		}
 		return fmt.Errorf("underwriting.putItem: %w", err)
 	}
@@ -260,3 +352,188 @@ func (r *UnderwritingRepo) findByDecision(ctx context.Context, decision string,
 	}
 	return cases, nil
 }
+
+// uwPageCursor resumes the queue after the last case returned.
+type uwPageCursor struct {
+	CreatedAt string `json:"c"`
+	ID        string `json:"i"`
+}
+
+// List pages through every matching case and returns the oldest first.
+// Referred queues are small, so sorting client-side is acceptable here; the
+// cursor is the (created_at, id) of the last case, so pages stay stable.
+func (r *UnderwritingRepo) List(ctx context.Context, filter core.UWCaseFilter, page core.PageRequest) (core.Page[core.UnderwritingCase], error) {
+	var after uwPageCursor
+	if page.Cursor != "" {
+		if err := core.DecodeCursor(page.Cursor, &after); err != nil {
+			return core.Page[core.UnderwritingCase]{}, err
+		}
+	}
+
+	var conds []expression.ConditionBuilder
+	switch filter.Assignee {
+	case "":
+	case core.UWUnassigned:
+		conds = append(conds, expression.AttributeNotExists(expression.Name("assigned_to")).
+			Or(expression.Name("assigned_to").Equal(expression.Value(""))))
+	default:
+		conds = append(conds, expression.Name("assigned_to").Equal(expression.Value(filter.Assignee)))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		conds = append(conds, expression.Name("created_at").LessThanEqual(expression.Value(filter.CreatedBefore.UTC().Format(time.RFC3339))))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		conds = append(conds, expression.Name("created_at").GreaterThanEqual(expression.Value(filter.CreatedAfter.UTC().Format(time.RFC3339))))
+	}
+
+	builder := expression.NewBuilder()
+	if len(conds) > 0 {
+		cond := conds[0]
+		for _, c := range conds[1:] {
+			cond = cond.And(c)
+		}
+		builder = builder.WithFilter(cond)
+	}
+	if filter.Decision != "" {
+		builder = builder.WithKeyCondition(expression.Key("decision").Equal(expression.Value(string(filter.Decision))))
+	}
+
+	var items []UnderwritingCaseItem
+	var startKey map[string]types.AttributeValue
+	for {
+		var batch []map[string]types.AttributeValue
+		var lastKey map[string]types.AttributeValue
+
+		if filter.Decision != "" {
+			expr, err := builder.Build()
+			if err != nil {
+				return core.Page[core.UnderwritingCase]{}, fmt.Errorf("underwriting.buildExpr: %w", err)
+			}
+			out, err := r.client.Query(ctx, &dynamodb.QueryInput{
+				TableName:                 aws.String(TableUWCases),
+				IndexName:                 aws.String(GSIUWCasesDecision),
+				KeyConditionExpression:    expr.KeyCondition(),
+				FilterExpression:          expr.Filter(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+				ExclusiveStartKey:         startKey,
+			})
+			if err != nil {
+				return core.Page[core.UnderwritingCase]{}, fmt.Errorf("underwriting.query: %w", err)
+			}
+			batch, lastKey = out.Items, out.LastEvaluatedKey
+		} else {
+			input := &dynamodb.ScanInput{
+				TableName:         aws.String(TableUWCases),
+				ExclusiveStartKey: startKey,
+			}
+			if len(conds) > 0 {
+				expr, err := builder.Build()
+				if err != nil {
+					return core.Page[core.UnderwritingCase]{}, fmt.Errorf("underwriting.buildExpr: %w", err)
+				}
+				input.FilterExpression = expr.Filter()
+				input.ExpressionAttributeNames = expr.Names()
+				input.ExpressionAttributeValues = expr.Values()
+			}
+			out, err := r.client.Scan(ctx, input)
+			if err != nil {
+				return core.Page[core.UnderwritingCase]{}, fmt.Errorf("underwriting.scan: %w", err)
+			}
+			batch, lastKey = out.Items, out.LastEvaluatedKey
+		}
+
+		var pageItems []UnderwritingCaseItem
+		if err := attributevalue.UnmarshalListOfMaps(batch, &pageItems); err != nil {
+			return core.Page[core.UnderwritingCase]{}, fmt.Errorf("underwriting.unmarshal: %w", err)
+		}
+		items = append(items, pageItems...)
+
+		if len(lastKey) == 0 {
+			break
+		}
+		startKey = lastKey
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].CreatedAt != items[j].CreatedAt {
+			return items[i].CreatedAt < items[j].CreatedAt
+		}
+		return items[i].ID < items[j].ID
+	})
+	if page.Cursor != "" {
+		items = items[sort.Search(len(items), func(i int) bool {
+			return items[i].CreatedAt > after.CreatedAt ||
+				(items[i].CreatedAt == after.CreatedAt && items[i].ID > after.ID)
+		}):]
+	}
+
+	var result core.Page[core.UnderwritingCase]
+	if len(items) > page.Limit {
+		items = items[:page.Limit]
+		last := items[len(items)-1]
+		next, err := core.EncodeCursor(uwPageCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		if err != nil {
+			return core.Page[core.UnderwritingCase]{}, err
+		}
+		result.NextCursor = next
+	}
+
+	result.Items = make([]core.UnderwritingCase, len(items))
+	for i, item := range items {
+		result.Items[i] = item.ToCore()
+	}
+	return result, nil
+}
+
+func (r *UnderwritingRepo) Assign(ctx context.Context, id, assignee string, version int, at time.Time) error {
+	ts := at.Format(time.RFC3339)
+	update := expression.Set(expression.Name("assigned_to"), expression.Value(assignee)).
+		Set(expression.Name("assigned_at"), expression.Value(ts)).
+		Set(expression.Name("updated_at"), expression.Value(ts)).
+		Add(expression.Name("version"), expression.Value(1))
+
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(atVersion(version)).Build()
+	if err != nil {
+		return fmt.Errorf("underwriting.buildExpr: %w", err)
+	}
+
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(TableUWCases),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var ccf *types.ConditionalCheckFailedException
+		if errors.As(err, &ccf) {
+			return versionFailure(ctx, id, r.Get)
+		}
+		return fmt.Errorf("underwriting.updateItem: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UnderwritingRepo) FindSLABreached(ctx context.Context, now time.Time, limit int) ([]core.UnderwritingCase, error) {
+	filter := core.UWCaseFilter{Decision: core.UWDecisionReferred}
+	referred, err := r.List(ctx, filter, core.PageRequest{Limit: math.MaxInt})
+	if err != nil {
+		return nil, err
+	}
+
+	var breached []core.UnderwritingCase
+	for _, c := range referred.Items {
+		if c.EscalatedAt == nil && c.SLABreached(now) {
+			breached = append(breached, c)
+			if len(breached) == limit {
+				break
+			}
+		}
+	}
+	return breached, nil
+}
@@ -0,0 +1,28 @@
+package dynamo
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// atVersion is the condition that the item exists and is still at version.
+// Items written before records had versions have none, which counts as 0.
+func atVersion(version int) expression.ConditionBuilder {
+	current := expression.Name("version").Equal(expression.Value(version))
+	if version == 0 {
+		current = current.Or(expression.AttributeNotExists(expression.Name("version")))
+	}
+	return expression.AttributeExists(expression.Name("id")).And(current)
+}
+
+// versionFailure tells a missing item apart from one that moved on from
+// the version a conditional write expected.
+func versionFailure[T any](ctx context.Context, id string, get func(context.Context, string) (T, error)) error {
+	if _, err := get(ctx, id); err != nil {
+		return err
+	}
+	return core.ErrChanged
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type ApplicationRepo struct {
+	mu   sync.Mutex
+	apps map[string]core.Application
+}
+
+func NewApplicationRepo() *ApplicationRepo {
+	return &ApplicationRepo{apps: make(map[string]core.Application)}
+}
+
+func (repo *ApplicationRepo) Create(_ context.Context, app core.Application) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.apps[app.ID]; ok {
+		return core.ErrConflict
+	}
+	repo.apps[app.ID] = app
+	return nil
+}
+
+func (repo *ApplicationRepo) Get(_ context.Context, id string) (core.Application, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	app, ok := repo.apps[id]
+	if !ok {
+		return core.Application{}, core.ErrApplicationNotFound
+	}
+	return app, nil
+}
+
+func (repo *ApplicationRepo) GetMany(_ context.Context, ids []string) ([]core.Application, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var found []core.Application
+	for _, id := range ids {
+		if app, ok := repo.apps[id]; ok {
+			found = append(found, app)
+		}
+	}
+	return found, nil
+}
+
+func (repo *ApplicationRepo) Update(_ context.Context, app core.Application) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored, ok := repo.apps[app.ID]
+	if !ok {
+		return core.ErrApplicationNotFound
+	}
+	if stored.Version != app.Version {
+		return core.ErrChanged
+	}
+	app.Version++
+	repo.apps[app.ID] = app
+	return nil
+}
+
+func (repo *ApplicationRepo) UpdateStatus(_ context.Context, id string, status core.ApplicationStatus, updatedAt time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	app, ok := repo.apps[id]
+	if !ok {
+		return core.ErrApplicationNotFound
+	}
+	app.Status, app.UpdatedAt = status, updatedAt
+	app.Version++
+	repo.apps[id] = app
+	return nil
+}
+
+func (repo *ApplicationRepo) FindByStatus(_ context.Context, status core.ApplicationStatus, limit int) ([]core.Application, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return find(repo.apps, func(app core.Application) bool { return app.Status == status },
+		func(a, b core.Application) int { return a.CreatedAt.Compare(b.CreatedAt) }, limit), nil
+}
+
+func (repo *ApplicationRepo) List(_ context.Context, filter core.ApplicationFilter, page core.PageRequest) (core.Page[core.Application], error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return listPage(repo.apps, func(app core.Application) bool {
+		return (filter.Status == "" || app.Status == filter.Status) &&
+			(filter.ProductSlug == "" || app.ProductSlug == filter.ProductSlug) &&
+			(filter.ApplicantEmail == "" || app.Applicant.Email == filter.ApplicantEmail) &&
+			(filter.OwnerID == "" || app.OwnerID == filter.OwnerID) &&
+			filter.Created.Contains(app.CreatedAt)
+	}, page, false)
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type AuditRepo struct {
+	mu      sync.Mutex
+	entries map[string]core.AuditEntry
+}
+
+func NewAuditRepo() *AuditRepo {
+	return &AuditRepo{entries: make(map[string]core.AuditEntry)}
+}
+
+func (repo *AuditRepo) Append(_ context.Context, entries ...core.AuditEntry) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, e := range entries {
+		e.Changes = slices.Clone(e.Changes)
+		repo.entries[e.ID] = e
+	}
+	return nil
+}
+
+func (repo *AuditRepo) History(_ context.Context, resource core.AuditResource, resourceID string, page core.PageRequest) (core.Page[core.AuditEntry], error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return listPage(repo.entries, func(e core.AuditEntry) bool {
+		return e.Resource == resource && e.ResourceID == resourceID
+	}, page, true)
+}
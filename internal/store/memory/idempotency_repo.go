@@ -0,0 +1,86 @@
+// Package memory holds in-process stores, for single-instance deployments
+// and local development.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// IdempotencyRepo keeps idempotency records in process. Records are per
+// replica, so a retry routed to another replica is not recognised.
+type IdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]core.IdempotencyRecord
+}
+
+func NewIdempotencyRepo() *IdempotencyRepo {
+	return &IdempotencyRepo{records: make(map[string]core.IdempotencyRecord)}
+}
+
+func (repo *IdempotencyRepo) Reserve(_ context.Context, rec core.IdempotencyRecord) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if prev, ok := repo.records[rec.Key]; ok && prev.ExpiresAt.After(rec.CreatedAt) {
+		return core.ErrIdempotencyKeyExists
+	}
+	repo.records[rec.Key] = rec
+	return nil
+}
+
+func (repo *IdempotencyRepo) Get(_ context.Context, key string) (core.IdempotencyRecord, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	rec, ok := repo.records[key]
+	if !ok || !rec.ExpiresAt.After(time.Now()) {
+		return core.IdempotencyRecord{}, core.ErrIdempotencyKeyNotFound
+	}
+	return rec, nil
+}
+
+func (repo *IdempotencyRepo) Complete(_ context.Context, rec core.IdempotencyRecord) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.records[rec.Key]; !ok {
+		return core.ErrIdempotencyKeyNotFound
+	}
+	repo.records[rec.Key] = rec
+	return nil
+}
+
+func (repo *IdempotencyRepo) Release(_ context.Context, key string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.records, key)
+	return nil
+}
+
+// StartWithContext drops expired records every minute until ctx is done.
+func (repo *IdempotencyRepo) StartWithContext(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				repo.mu.Lock()
+				for key, rec := range repo.records {
+					if !rec.ExpiresAt.After(now) {
+						delete(repo.records, key)
+					}
+				}
+				repo.mu.Unlock()
+			}
+		}
+	}()
+}
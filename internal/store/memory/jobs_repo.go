@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type JobRepo struct {
+	mu   sync.Mutex
+	jobs map[string]core.Job
+}
+
+func NewJobRepo() *JobRepo {
+	return &JobRepo{jobs: make(map[string]core.Job)}
+}
+
+func (repo *JobRepo) Enqueue(_ context.Context, job core.Job) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.jobs[job.ID]; ok {
+		return core.ErrJobExists
+	}
+	repo.jobs[job.ID] = job
+	return nil
+}
+
+func (repo *JobRepo) Get(_ context.Context, id string) (core.Job, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	job, ok := repo.jobs[id]
+	if !ok {
+		return core.Job{}, core.ErrJobNotFound
+	}
+	return job, nil
+}
+
+// waiting reports whether job is a queued job of kind that is due and not
+// under an unexpired lease.
+func waiting(job core.Job, kind core.JobKind, now time.Time) bool {
+	return job.Kind == kind && job.Status == core.JobStatusQueued && !job.RunAt.After(now) &&
+		(job.LeaseExpiresAt == nil || job.LeaseExpiresAt.Before(now))
+}
+
+func (repo *JobRepo) Claim(_ context.Context, kind core.JobKind, owner string, ttl time.Duration, limit int) ([]core.Job, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now().UTC()
+	claimed := find(repo.jobs, func(job core.Job) bool { return waiting(job, kind, now) },
+		func(a, b core.Job) int { return a.RunAt.Compare(b.RunAt) }, limit)
+	expires := now.Add(ttl)
+	for i := range claimed {
+		claimed[i].LeaseOwner, claimed[i].LeaseExpiresAt = owner, &expires
+		repo.jobs[claimed[i].ID] = claimed[i]
+	}
+	return claimed, nil
+}
+
+func (repo *JobRepo) Backlog(_ context.Context, kind core.JobKind) (int64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now().UTC()
+	var n int64
+	for _, job := range repo.jobs {
+		if waiting(job, kind, now) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (repo *JobRepo) Renew(_ context.Context, id, owner string, ttl time.Duration) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	job, ok := repo.jobs[id]
+	if !ok || job.LeaseOwner != owner {
+		return core.ErrLeaseLost
+	}
+	expires := time.Now().UTC().Add(ttl)
+	job.LeaseExpiresAt = &expires
+	repo.jobs[id] = job
+	return nil
+}
+
+func (repo *JobRepo) Finish(_ context.Context, job core.Job, owner string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if stored, ok := repo.jobs[job.ID]; !ok || stored.LeaseOwner != owner {
+		return core.ErrLeaseLost
+	}
+	job.LeaseOwner, job.LeaseExpiresAt = "", nil
+	repo.jobs[job.ID] = job
+	return nil
+}
+
+func (repo *JobRepo) Update(_ context.Context, job core.Job, from core.JobStatus) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if stored, ok := repo.jobs[job.ID]; !ok || stored.Status != from {
+		return core.ErrConflict
+	}
+	repo.jobs[job.ID] = job
+	return nil
+}
+
+func (repo *JobRepo) List(_ context.Context, filter core.JobFilter, page core.PageRequest) (core.Page[core.Job], error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return listPage(repo.jobs, func(job core.Job) bool {
+		return (filter.Status == "" || job.Status == filter.Status) &&
+			(filter.Kind == "" || job.Kind == filter.Kind)
+	}, page, true)
+}
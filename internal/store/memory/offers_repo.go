@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type OfferRepo struct {
+	mu     sync.Mutex
+	offers map[string]core.Offer
+}
+
+func NewOfferRepo() *OfferRepo {
+	return &OfferRepo{offers: make(map[string]core.Offer)}
+}
+
+func (repo *OfferRepo) Create(_ context.Context, offer core.Offer) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, o := range repo.offers {
+		if id == offer.ID || o.ApplicationID == offer.ApplicationID {
+			return core.ErrOfferExists
+		}
+	}
+	repo.offers[offer.ID] = offer
+	return nil
+}
+
+func (repo *OfferRepo) Get(_ context.Context, id string) (core.Offer, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	offer, ok := repo.offers[id]
+	if !ok {
+		return core.Offer{}, core.ErrOfferNotFound
+	}
+	return offer, nil
+}
+
+func (repo *OfferRepo) GetByApplicationID(_ context.Context, appID string) (core.Offer, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, offer := range repo.offers {
+		if offer.ApplicationID == appID {
+			return offer, nil
+		}
+	}
+	return core.Offer{}, core.ErrOfferNotFound
+}
+
+func (repo *OfferRepo) FindByApplicationIDs(_ context.Context, appIDs []string) ([]core.Offer, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return byApplicationID(repo.offers, appIDs, func(o core.Offer) string { return o.ApplicationID }), nil
+}
+
+func (repo *OfferRepo) Update(_ context.Context, offer core.Offer) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored, ok := repo.offers[offer.ID]
+	if !ok {
+		return core.ErrOfferNotFound
+	}
+	if stored.Version != offer.Version {
+		return core.ErrChanged
+	}
+	offer.Version++
+	repo.offers[offer.ID] = offer
+	return nil
+}
+
+func (repo *OfferRepo) FindAccepted(_ context.Context, limit int) ([]core.Offer, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return find(repo.offers, func(o core.Offer) bool { return o.Status == core.OfferStatusAccepted },
+		func(a, b core.Offer) int { return acceptedAt(a).Compare(acceptedAt(b)) }, limit), nil
+}
+
+func acceptedAt(o core.Offer) time.Time {
+	if o.AcceptedAt == nil {
+		return time.Time{}
+	}
+	return *o.AcceptedAt
+}
+
+func (repo *OfferRepo) ExpireOffers(_ context.Context, before time.Time) ([]core.Offer, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var expired []core.Offer
+	for id, o := range repo.offers {
+		if o.Status == core.OfferStatusPending && o.ExpiresAt.Before(before) {
+			o.Status = core.OfferStatusExpired
+			o.Version++
+			repo.offers[id] = o
+			expired = append(expired, o)
+		}
+	}
+	return expired, nil
+}
+
+func (repo *OfferRepo) List(_ context.Context, filter core.OfferFilter, page core.PageRequest) (core.Page[core.Offer], error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return listPage(repo.offers, func(o core.Offer) bool {
+		return (filter.Status == "" || o.Status == filter.Status) &&
+			(filter.ProductSlug == "" || o.ProductSlug == filter.ProductSlug) &&
+			(filter.ApplicationID == "" || o.ApplicationID == filter.ApplicationID) &&
+			filter.Created.Contains(o.CreatedAt)
+	}, page, false)
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// pageCursor resumes a list after the last ID returned. IDs are ULIDs, so
+// ID order is creation order, as in the other stores.
+type pageCursor struct {
+	After string `json:"after"`
+}
+
+// listPage returns one page of the records in m that match, in ID order,
+// newest first unless ascending is set.
+func listPage[T any](m map[string]T, match func(T) bool, page core.PageRequest, ascending bool) (core.Page[T], error) {
+	var after string
+	if page.Cursor != "" {
+		var c pageCursor
+		if err := core.DecodeCursor(page.Cursor, &c); err != nil {
+			return core.Page[T]{}, err
+		}
+		after = c.After
+	}
+
+	keys := make([]string, 0, len(m))
+	for id, v := range m {
+		if after != "" && (ascending && id <= after || !ascending && id >= after) {
+			continue
+		}
+		if match(v) {
+			keys = append(keys, id)
+		}
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if ascending {
+			return strings.Compare(a, b)
+		}
+		return strings.Compare(b, a)
+	})
+
+	result := core.Page[T]{Items: make([]T, 0, min(len(keys), page.Limit))}
+	if len(keys) > page.Limit {
+		keys = keys[:page.Limit]
+		next, err := core.EncodeCursor(pageCursor{After: keys[len(keys)-1]})
+		if err != nil {
+			return core.Page[T]{}, err
+		}
+		result.NextCursor = next
+	}
+	for _, id := range keys {
+		result.Items = append(result.Items, m[id])
+	}
+	return result, nil
+}
+
+// find returns up to limit records in m that match, ordered by less.
+// A limit of zero or less returns them all.
+func find[T any](m map[string]T, match func(T) bool, less func(a, b T) int, limit int) []T {
+	var found []T
+	for _, v := range m {
+		if match(v) {
+			found = append(found, v)
+		}
+	}
+	slices.SortFunc(found, less)
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+// byApplicationID returns the records in m whose application is one of appIDs.
+func byApplicationID[T any](m map[string]T, appIDs []string, appID func(T) string) []T {
+	var found []T
+	for _, v := range m {
+		if slices.Contains(appIDs, appID(v)) {
+			found = append(found, v)
+		}
+	}
+	return found
+}
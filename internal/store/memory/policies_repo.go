@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type PolicyRepo struct {
+	mu       sync.Mutex
+	policies map[string]core.Policy
+	counters map[int]int64 // Last policy number issued, by year
+}
+
+func NewPolicyRepo() *PolicyRepo {
+	return &PolicyRepo{policies: make(map[string]core.Policy), counters: make(map[int]int64)}
+}
+
+func (repo *PolicyRepo) Create(_ context.Context, policy core.Policy) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, p := range repo.policies {
+		if id == policy.ID || p.Number == policy.Number || p.OfferID == policy.OfferID {
+			return core.ErrPolicyExists
+		}
+	}
+	repo.policies[policy.ID] = policy
+	return nil
+}
+
+func (repo *PolicyRepo) Get(_ context.Context, id string) (core.Policy, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	policy, ok := repo.policies[id]
+	if !ok {
+		return core.Policy{}, core.ErrPolicyNotFound
+	}
+	return policy, nil
+}
+
+func (repo *PolicyRepo) GetByNumber(_ context.Context, number string) (core.Policy, error) {
+	return repo.findOne(func(p core.Policy) bool { return p.Number == number })
+}
+
+func (repo *PolicyRepo) GetByOfferID(_ context.Context, offerID string) (core.Policy, error) {
+	return repo.findOne(func(p core.Policy) bool { return p.OfferID == offerID })
+}
+
+func (repo *PolicyRepo) GetByApplicationID(_ context.Context, appID string) (core.Policy, error) {
+	return repo.findOne(func(p core.Policy) bool { return p.ApplicationID == appID })
+}
+
+func (repo *PolicyRepo) findOne(match func(core.Policy) bool) (core.Policy, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, p := range repo.policies {
+		if match(p) {
+			return p, nil
+		}
+	}
+	return core.Policy{}, core.ErrPolicyNotFound
+}
+
+func (repo *PolicyRepo) FindByApplicationIDs(_ context.Context, appIDs []string) ([]core.Policy, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return byApplicationID(repo.policies, appIDs, func(p core.Policy) string { return p.ApplicationID }), nil
+}
+
+func (repo *PolicyRepo) List(_ context.Context, filter core.PolicyFilter, page core.PageRequest) (core.Page[core.Policy], error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return listPage(repo.policies, func(p core.Policy) bool {
+		return (filter.ApplicationID == "" || p.ApplicationID == filter.ApplicationID) &&
+			(filter.Status == "" || p.Status == filter.Status) &&
+			(filter.ProductSlug == "" || p.ProductSlug == filter.ProductSlug) &&
+			(filter.InsuredEmail == "" || p.Insured.Email == filter.InsuredEmail) &&
+			filter.Issued.Contains(p.IssuedAt)
+	}, page, false)
+}
+
+func (repo *PolicyRepo) NextPolicyNumber(_ context.Context) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	year := time.Now().Year()
+	repo.counters[year]++
+	return fmt.Sprintf("POL-%d-%06d", year, repo.counters[year]), nil
+}
+
+func (repo *PolicyRepo) ExpirePolicies(_ context.Context, before time.Time) ([]core.Policy, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var expired []core.Policy
+	for id, p := range repo.policies {
+		if p.Status == core.PolicyStatusActive && p.ExpiryDate.Before(before) {
+			p.Status = core.PolicyStatusExpired
+			repo.policies[id] = p
+			expired = append(expired, p)
+		}
+	}
+	return expired, nil
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/internal/platform/ids"
+)
+
+type ProductRepo struct {
+	mu       sync.Mutex
+	products map[string]core.Product
+}
+
+func NewProductRepo() *ProductRepo {
+	return &ProductRepo{products: make(map[string]core.Product)}
+}
+
+func (repo *ProductRepo) List(_ context.Context) ([]core.Product, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	all := func(core.Product) bool { return true }
+	return find(repo.products, all, func(a, b core.Product) int { return strings.Compare(a.ID, b.ID) }, 0), nil
+}
+
+func (repo *ProductRepo) GetBySlug(_ context.Context, slug string) (core.Product, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, p := range repo.products {
+		if p.Slug == slug {
+			return p, nil
+		}
+	}
+	return core.Product{}, core.ErrNotFound
+}
+
+func (repo *ProductRepo) GetByID(_ context.Context, id string) (core.Product, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	p, ok := repo.products[id]
+	if !ok {
+		return core.Product{}, core.ErrNotFound
+	}
+	return p, nil
+}
+
+func (repo *ProductRepo) UpsertBySlug(_ context.Context, p core.Product) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, existing := range repo.products {
+		if existing.Slug == p.Slug {
+			p.ID = id // The ID is only set on insert
+			repo.products[id] = p
+			return nil
+		}
+	}
+	if p.ID == "" {
+		p.ID = ids.New()
+	}
+	repo.products[p.ID] = p
+	return nil
+}
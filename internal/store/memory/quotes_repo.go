@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type QuoteRepo struct {
+	mu     sync.Mutex
+	quotes map[string]core.Quote
+}
+
+func NewQuoteRepo() *QuoteRepo {
+	return &QuoteRepo{quotes: make(map[string]core.Quote)}
+}
+
+func (repo *QuoteRepo) Create(_ context.Context, q core.Quote) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if _, ok := repo.quotes[q.ID]; ok {
+		return core.ErrConflict
+	}
+	repo.quotes[q.ID] = q
+	return nil
+}
+
+func (repo *QuoteRepo) Get(_ context.Context, id string) (core.Quote, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	q, ok := repo.quotes[id]
+	if !ok {
+		return core.Quote{}, core.ErrNotFound
+	}
+	return q, nil
+}
+
+func (repo *QuoteRepo) GetMany(_ context.Context, ids []string) ([]core.Quote, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var found []core.Quote
+	for _, id := range ids {
+		if q, ok := repo.quotes[id]; ok {
+			found = append(found, q)
+		}
+	}
+	return found, nil
+}
+
+func (repo *QuoteRepo) ExpireQuotes(_ context.Context, before time.Time) (int64, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var n int64
+	for id, q := range repo.quotes {
+		if (q.Status == core.QuoteStatusNew || q.Status == core.QuoteStatusPriced) && q.ExpiresAt.Before(before) {
+			q.Status = core.QuoteStatusExpired
+			repo.quotes[id] = q
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (repo *QuoteRepo) List(_ context.Context, filter core.QuoteFilter, page core.PageRequest) (core.Page[core.Quote], error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	return listPage(repo.quotes, func(q core.Quote) bool {
+		return (filter.Status == "" || q.Status == filter.Status) &&
+			(filter.ProductSlug == "" || q.ProductSlug == filter.ProductSlug) &&
+			filter.Created.Contains(q.CreatedAt)
+	}, page, false)
+}
@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+type UnderwritingRepo struct {
+	mu    sync.Mutex
+	cases map[string]core.UnderwritingCase
+}
+
+func NewUnderwritingRepo() *UnderwritingRepo {
+	return &UnderwritingRepo{cases: make(map[string]core.UnderwritingCase)}
+}
+
+// detach copies the decision chain, so appending to a returned case never
+// writes into a stored one.
+func detach(uw core.UnderwritingCase) core.UnderwritingCase {
+	uw.DecisionChain = slices.Clip(slices.Clone(uw.DecisionChain))
+	return uw
+}
+
+func (repo *UnderwritingRepo) Create(_ context.Context, uw core.UnderwritingCase) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for id, c := range repo.cases {
+		if id == uw.ID || c.ApplicationID == uw.ApplicationID {
+			return core.ErrUWCaseExists
+		}
+	}
+	repo.cases[uw.ID] = detach(uw)
+	return nil
+}
+
+func (repo *UnderwritingRepo) Get(_ context.Context, id string) (core.UnderwritingCase, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	uw, ok := repo.cases[id]
+	if !ok {
+		return core.UnderwritingCase{}, core.ErrUWCaseNotFound
+	}
+	return detach(uw), nil
+}
+
+func (repo *UnderwritingRepo) GetByApplicationID(_ context.Context, appID string) (core.UnderwritingCase, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, uw := range repo.cases {
+		if uw.ApplicationID == appID {
+			return detach(uw), nil
+		}
+	}
+	return core.UnderwritingCase{}, core.ErrUWCaseNotFound
+}
+
+func (repo *UnderwritingRepo) FindByApplicationIDs(_ context.Context, appIDs []string) ([]core.UnderwritingCase, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	found := byApplicationID(repo.cases, appIDs, func(uw core.UnderwritingCase) string { return uw.ApplicationID })
+	for i := range found {
+		found[i] = detach(found[i])
+	}
+	return found, nil
+}
+
+func (repo *UnderwritingRepo) Update(_ context.Context, uw core.UnderwritingCase) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored, ok := repo.cases[uw.ID]
+	if !ok {
+		return core.ErrUWCaseNotFound
+	}
+	if stored.Version != uw.Version {
+		return core.ErrChanged
+	}
+	uw.Version++
+	repo.cases[uw.ID] = detach(uw)
+	return nil
+}
+
+func (repo *UnderwritingRepo) FindPending(_ context.Context, limit int) ([]core.UnderwritingCase, error) {
+	return repo.findDecision(core.UWDecisionPending, limit), nil
+}
+
+func (repo *UnderwritingRepo) FindReferred(_ context.Context, limit int) ([]core.UnderwritingCase, error) {
+	return repo.findDecision(core.UWDecisionReferred, limit), nil
+}
+
+// findDecision returns the oldest cases with the given decision.
+func (repo *UnderwritingRepo) findDecision(decision core.UWDecision, limit int) []core.UnderwritingCase {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	found := find(repo.cases, func(uw core.UnderwritingCase) bool { return uw.Decision == decision },
+		func(a, b core.UnderwritingCase) int { return a.CreatedAt.Compare(b.CreatedAt) }, limit)
+	for i := range found {
+		found[i] = detach(found[i])
+	}
+	return found
+}
+
+func (repo *UnderwritingRepo) List(_ context.Context, filter core.UWCaseFilter, page core.PageRequest) (core.Page[core.UnderwritingCase], error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	assignee := filter.Assignee
+	if assignee == core.UWUnassigned {
+		assignee = ""
+	}
+	// Oldest first, so the queue is worked in arrival order
+	result, err := listPage(repo.cases, func(uw core.UnderwritingCase) bool {
+		return (filter.Decision == "" || uw.Decision == filter.Decision) &&
+			(filter.Assignee == "" || uw.AssignedTo == assignee) &&
+			(filter.CreatedBefore.IsZero() || !uw.CreatedAt.After(filter.CreatedBefore)) &&
+			(filter.CreatedAfter.IsZero() || !uw.CreatedAt.Before(filter.CreatedAfter))
+	}, page, true)
+	for i := range result.Items {
+		result.Items[i] = detach(result.Items[i])
+	}
+	return result, err
+}
+
+func (repo *UnderwritingRepo) Assign(_ context.Context, id, assignee string, version int, at time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	uw, ok := repo.cases[id]
+	if !ok {
+		return core.ErrUWCaseNotFound
+	}
+	if uw.Version != version {
+		return core.ErrChanged
+	}
+	uw.AssignedTo, uw.AssignedAt, uw.UpdatedAt = assignee, &at, at
+	uw.Version++
+	repo.cases[id] = uw
+	return nil
+}
+
+func (repo *UnderwritingRepo) FindSLABreached(_ context.Context, now time.Time, limit int) ([]core.UnderwritingCase, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	found := find(repo.cases, func(uw core.UnderwritingCase) bool {
+		return uw.Decision == core.UWDecisionReferred && uw.SLADueAt != nil && uw.SLADueAt.Before(now) && uw.EscalatedAt == nil
+	}, func(a, b core.UnderwritingCase) int { return a.SLADueAt.Compare(*b.SLADueAt) }, limit)
+	for i := range found {
+		found[i] = detach(found[i])
+	}
+	return found, nil
+}
@@ -0,0 +1,115 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type APIKeyRepoMongo struct {
+	coll      *mongodrv.Collection
+	opTimeout time.Duration
+}
+
+func NewAPIKeyRepo(db *mongodrv.Database, opTimeout time.Duration) *APIKeyRepoMongo {
+	return &APIKeyRepoMongo{
+		coll:      db.Collection(ColAPIKeys),
+		opTimeout: opTimeout,
+	}
+}
+
+func (repo *APIKeyRepoMongo) Create(ctx context.Context, key core.APIKey) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	_, err := repo.coll.InsertOne(ctx, toAPIKeyDoc(key))
+	if err != nil {
+		var we mongodrv.WriteException
+		if errors.As(err, &we) {
+			for _, e := range we.WriteErrors {
+				if e.Code == 11000 {
+					return core.ErrAPIKeyExists
+				}
+			}
+		}
+		return fmt.Errorf("api_keys.insert: %w", err)
+	}
+	return nil
+}
+
+func (repo *APIKeyRepoMongo) Get(ctx context.Context, id string) (core.APIKey, error) {
+	return repo.findOne(ctx, bson.M{"_id": id})
+}
+
+func (repo *APIKeyRepoMongo) GetByHash(ctx context.Context, hash string) (core.APIKey, error) {
+	return repo.findOne(ctx, bson.M{"hash": hash})
+}
+
+func (repo *APIKeyRepoMongo) findOne(ctx context.Context, filter bson.M) (core.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	var doc APIKeyDoc
+	err := repo.coll.FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongodrv.ErrNoDocuments) {
+			return core.APIKey{}, core.ErrAPIKeyNotFound
+		}
+		return core.APIKey{}, fmt.Errorf("api_keys.findOne: %w", err)
+	}
+	return fromAPIKeyDoc(doc), nil
+}
+
+func (repo *APIKeyRepoMongo) List(ctx context.Context) ([]core.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := repo.coll.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("api_keys.find: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []APIKeyDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("api_keys.decode: %w", err)
+	}
+
+	keys := make([]core.APIKey, len(docs))
+	for i, d := range docs {
+		keys[i] = fromAPIKeyDoc(d)
+	}
+	return keys, nil
+}
+
+func (repo *APIKeyRepoMongo) Update(ctx context.Context, key core.APIKey) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	result, err := repo.coll.ReplaceOne(ctx, bson.M{"_id": key.ID}, toAPIKeyDoc(key))
+	if err != nil {
+		return fmt.Errorf("api_keys.replace: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return core.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (repo *APIKeyRepoMongo) TouchLastUsed(ctx context.Context, id string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	_, err := repo.coll.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": at}})
+	if err != nil {
+		return fmt.Errorf("api_keys.touch: %w", err)
+	}
+	return nil
+}
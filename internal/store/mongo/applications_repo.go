@@ -59,17 +59,29 @@ func (repo *ApplicationRepoMongo) Get(ctx context.Context, id string) (core.Appl
 	return fromApplicationDoc(doc), nil
 }
 
+func (repo *ApplicationRepoMongo) GetMany(ctx context.Context, ids []string) ([]core.Application, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	items, err := findIn(ctx, repo.coll, "_id", ids, fromApplicationDoc)
+	if err != nil {
+		return nil, fmt.Errorf("applications.findMany: %w", err)
+	}
+	return items, nil
+}
+
 func (repo *ApplicationRepoMongo) Update(ctx context.Context, app core.Application) error {
 	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
 	defer cancel()
 
 	doc := toApplicationDoc(app)
-	result, err := repo.coll.ReplaceOne(ctx, bson.M{"_id": app.ID}, doc)
+	doc.Version++
+	result, err := repo.coll.ReplaceOne(ctx, atVersion(app.ID, app.Version), doc)
 	if err != nil {
 		return fmt.Errorf("applications.replace: %w", err)
 	}
 	if result.MatchedCount == 0 {
-		return core.ErrApplicationNotFound
+		return versionFailure(ctx, repo.coll, app.ID, core.ErrApplicationNotFound)
 	}
 	return nil
 }
@@ -83,6 +95,7 @@ func (repo *ApplicationRepoMongo) UpdateStatus(ctx context.Context, id string, s
 			"status":     string(status),
 			"updated_at": updatedAt,
 		},
+		"$inc": bson.M{"version": 1},
 	}
 
 	result, err := repo.coll.UpdateOne(ctx, bson.M{"_id": id}, update)
@@ -123,3 +136,26 @@ func (repo *ApplicationRepoMongo) FindByStatus(ctx context.Context, status core.
 
 	return apps, nil
 }
+
+func (repo *ApplicationRepoMongo) List(ctx context.Context, filter core.ApplicationFilter, page core.PageRequest) (core.Page[core.Application], error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	mongoFilter := bson.M{}
+	if filter.Status != "" {
+		mongoFilter["status"] = string(filter.Status)
+	}
+	if filter.ProductSlug != "" {
+		mongoFilter["product_slug"] = filter.ProductSlug
+	}
+	if filter.ApplicantEmail != "" {
+		mongoFilter["applicant.email"] = filter.ApplicantEmail
+	}
+	if filter.OwnerID != "" {
+		mongoFilter["owner_id"] = filter.OwnerID
+	}
+	timeRange(mongoFilter, "created_at", filter.Created)
+
+	return findPage(ctx, repo.coll, mongoFilter, page, false,
+		func(d ApplicationDoc) string { return d.ID }, fromApplicationDoc)
+}
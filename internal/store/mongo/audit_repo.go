@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+)
+
+// AuditRepoMongo only inserts and reads; nothing in the app updates or
+// deletes an entry.
+type AuditRepoMongo struct {
+	coll      *mongodrv.Collection
+	opTimeout time.Duration
+}
+
+func NewAuditRepo(db *mongodrv.Database, opTimeout time.Duration) *AuditRepoMongo {
+	return &AuditRepoMongo{
+		coll:      db.Collection(ColAudit),
+		opTimeout: opTimeout,
+	}
+}
+
+func (repo *AuditRepoMongo) Append(ctx context.Context, entries ...core.AuditEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	docs := make([]any, len(entries))
+	for i, e := range entries {
+		docs[i] = toAuditDoc(e)
+	}
+	if _, err := repo.coll.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("audit_log.insert: %w", err)
+	}
+	return nil
+}
+
+func (repo *AuditRepoMongo) History(ctx context.Context, resource core.AuditResource, resourceID string, page core.PageRequest) (core.Page[core.AuditEntry], error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"resource":    string(resource),
+		"resource_id": resourceID,
+	}
+	return findPage(ctx, repo.coll, filter, page, true,
+		func(d AuditDoc) string { return d.ID }, fromAuditDoc)
+}
@@ -0,0 +1,32 @@
+package mongo
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+)
+
+// findIn returns every document whose field is one of values, in no
+// particular order. It backs the repos' batch lookups.
+func findIn[D any, T any](ctx context.Context, coll *mongodrv.Collection, field string, values []string, conv func(D) T) ([]T, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := coll.Find(ctx, bson.M{field: bson.M{"$in": values}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []D
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	items := make([]T, len(docs))
+	for i, d := range docs {
+		items[i] = conv(d)
+	}
+	return items, nil
+}
@@ -24,7 +24,7 @@ type MongoClient struct {
 }
 
 func NewClient(cfg *config.Config) (*MongoClient, error) {
-	clientOpts := options.Client().ApplyURI(cfg.MongoURI)
+	clientOpts := options.Client().ApplyURI(cfg.MongoURI).SetMonitor(commandMonitor())
 
 	var client *mongo.Client
 	var err error
@@ -0,0 +1,87 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IdempotencyRepoMongo struct {
+	coll      *mongodrv.Collection
+	opTimeout time.Duration
+}
+
+func NewIdempotencyRepo(db *mongodrv.Database, opTimeout time.Duration) *IdempotencyRepoMongo {
+	return &IdempotencyRepoMongo{
+		coll:      db.Collection(ColIdempotency),
+		opTimeout: opTimeout,
+	}
+}
+
+// Reserve replaces an expired record the TTL monitor hasn't removed yet; a
+// live record doesn't match the filter, so the upsert hits the duplicate _id.
+func (repo *IdempotencyRepoMongo) Reserve(ctx context.Context, rec core.IdempotencyRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	filter := bson.M{"_id": rec.Key, "expires_at": bson.M{"$lte": rec.CreatedAt}}
+	opts := options.Replace().SetUpsert(true)
+	_, err := repo.coll.ReplaceOne(ctx, filter, toIdempotencyDoc(rec), opts)
+	if err != nil {
+		var we mongodrv.WriteException
+		if errors.As(err, &we) {
+			for _, e := range we.WriteErrors {
+				if e.Code == 11000 {
+					return core.ErrIdempotencyKeyExists
+				}
+			}
+		}
+		return fmt.Errorf("idempotency_keys.reserve: %w", err)
+	}
+	return nil
+}
+
+func (repo *IdempotencyRepoMongo) Get(ctx context.Context, key string) (core.IdempotencyRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	var doc IdempotencyDoc
+	err := repo.coll.FindOne(ctx, bson.M{"_id": key}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongodrv.ErrNoDocuments) {
+			return core.IdempotencyRecord{}, core.ErrIdempotencyKeyNotFound
+		}
+		return core.IdempotencyRecord{}, fmt.Errorf("idempotency_keys.findOne: %w", err)
+	}
+	return fromIdempotencyDoc(doc), nil
+}
+
+func (repo *IdempotencyRepoMongo) Complete(ctx context.Context, rec core.IdempotencyRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	result, err := repo.coll.ReplaceOne(ctx, bson.M{"_id": rec.Key}, toIdempotencyDoc(rec))
+	if err != nil {
+		return fmt.Errorf("idempotency_keys.replace: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return core.ErrIdempotencyKeyNotFound
+	}
+	return nil
+}
+
+func (repo *IdempotencyRepoMongo) Release(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	if _, err := repo.coll.DeleteOne(ctx, bson.M{"_id": key}); err != nil {
+		return fmt.Errorf("idempotency_keys.delete: %w", err)
+	}
+	return nil
+}
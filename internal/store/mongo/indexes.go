@@ -2,7 +2,12 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -28,6 +33,24 @@ func EnsureIndexes(ctx context.Context, db *mongo.Database) error {
 	if err := ensurePoliciesIndexes(ctx, db); err != nil {
 		return fmt.Errorf("ensure policies indexes: %w", err)
 	}
+	if err := ensureSearchIndexes(ctx, db); err != nil {
+		return fmt.Errorf("ensure search_index indexes: %w", err)
+	}
+	if err := ensureAPIKeysIndexes(ctx, db); err != nil {
+		return fmt.Errorf("ensure api_keys indexes: %w", err)
+	}
+	if err := ensureIdempotencyIndexes(ctx, db); err != nil {
+		return fmt.Errorf("ensure idempotency_keys indexes: %w", err)
+	}
+	if err := ensureJobsIndexes(ctx, db); err != nil {
+		return fmt.Errorf("ensure jobs indexes: %w", err)
+	}
+	if err := ensureScheduledRunsIndexes(ctx, db); err != nil {
+		return fmt.Errorf("ensure scheduled_runs indexes: %w", err)
+	}
+	if err := ensureAuditIndexes(ctx, db); err != nil {
+		return fmt.Errorf("ensure audit_log indexes: %w", err)
+	}
 	return nil
 }
 
@@ -36,10 +59,13 @@ func ensureQuotesIndexes(ctx context.Context, db *mongo.Database) error {
 	models := []mongo.IndexModel{
 		newIndex("product_slug", 1, "quotes_product_slug", false),
 		newIndex("created_at", 1, "quotes_created_at", false),
-		newTTLIndex("expires_at", "quotes_expiry_ttl", 0), // optional: enable later if you want TTL
+		newIndex("expires_at", 1, "quotes_expires_at", false),
+	}
+	// Expired quotes are kept (quote_expiry marks them), not deleted at expiry
+	if err := dropIndexes(ctx, coll, "quotes_expiry_ttl"); err != nil {
+		return err
 	}
-	_, err := coll.Indexes().CreateMany(ctx, models)
-	return err
+	return createIndexes(ctx, coll, models)
 }
 
 func ensureProductsIndexes(ctx context.Context, db *mongo.Database) error {
@@ -52,8 +78,7 @@ func ensureProductsIndexes(ctx context.Context, db *mongo.Database) error {
 			Options: options.Index().SetName("products_term_years_asc"),
 		},
 	}
-	_, err := coll.Indexes().CreateMany(ctx, models)
-	return err
+	return createIndexes(ctx, coll, models)
 }
 
 func ensureApplicationsIndexes(ctx context.Context, db *mongo.Database) error {
@@ -61,18 +86,24 @@ func ensureApplicationsIndexes(ctx context.Context, db *mongo.Database) error {
 	models := []mongo.IndexModel{
 		newIndex("quote_id", 1, "apps_quote_id", false),
 		newIndex("status", 1, "apps_status", false),
+		newIndex("owner_id", 1, "apps_owner_id", false),
+		newIndex("applicant.email", 1, "apps_applicant_email", false),
 	}
-	_, err := coll.Indexes().CreateMany(ctx, models)
-	return err
+	return createIndexes(ctx, coll, models)
 }
 
 func ensureUnderwritingCasesIndexes(ctx context.Context, db *mongo.Database) error {
 	coll := db.Collection(ColUnderwriting)
 	models := []mongo.IndexModel{
 		newIndex("application_id", 1, "uwc_application_id_unique", true),
+		{Keys: bson.D{{Key: "decision", Value: 1}, {Key: "assigned_to", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("uwc_queue"),
+		},
+		{Keys: bson.D{{Key: "decision", Value: 1}, {Key: "sla_due_at", Value: 1}},
+			Options: options.Index().SetName("uwc_sla_due"),
+		},
 	}
-	_, err := coll.Indexes().CreateMany(ctx, models)
-	return err
+	return createIndexes(ctx, coll, models)
 }
 
 func ensureOffersIndexes(ctx context.Context, db *mongo.Database) error {
@@ -80,10 +111,13 @@ func ensureOffersIndexes(ctx context.Context, db *mongo.Database) error {
 	models := []mongo.IndexModel{
 		newIndex("application_id", 1, "offers_application_id_unique", true),
 		newIndex("status", 1, "offers_status", false),
-		newTTLIndex("expires_at", "offers_expiry_ttl", 0),
+		newIndex("expires_at", 1, "offers_expires_at", false),
+	}
+	// Expired offers are kept (offer_expiry marks them), not deleted at expiry
+	if err := dropIndexes(ctx, coll, "offers_expiry_ttl"); err != nil {
+		return err
 	}
-	_, err := coll.Indexes().CreateMany(ctx, models)
-	return err
+	return createIndexes(ctx, coll, models)
 }
 
 func ensurePoliciesIndexes(ctx context.Context, db *mongo.Database) error {
@@ -91,9 +125,127 @@ func ensurePoliciesIndexes(ctx context.Context, db *mongo.Database) error {
 	models := []mongo.IndexModel{
 		newIndex("number", 1, "policies_number_unique", true),
 		newIndex("application_id", 1, "policies_application_id", false),
+		newIndex("status", 1, "policies_status", false),
+		newIndex("expiry_date", 1, "policies_expiry_date", false),
+		newIndex("insured.email", 1, "policies_insured_email", false),
+	}
+	return createIndexes(ctx, coll, models)
+}
+
+func ensureAPIKeysIndexes(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(ColAPIKeys)
+	models := []mongo.IndexModel{
+		newIndex("hash", 1, "api_keys_hash_unique", true),
+		newIndex("created_at", -1, "api_keys_created_at", false),
+	}
+	return createIndexes(ctx, coll, models)
+}
+
+func ensureIdempotencyIndexes(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(ColIdempotency)
+	models := []mongo.IndexModel{
+		newTTLIndex("expires_at", "idempotency_keys_expiry_ttl", 0),
+	}
+	return createIndexes(ctx, coll, models)
+}
+
+func ensureJobsIndexes(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(ColJobs)
+	models := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "kind", Value: 1}, {Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+			Options: options.Index().SetName("jobs_kind_status_run_at"),
+		},
+		newIndex("status", 1, "jobs_status", false),
+		newTTLIndex("expires_at", "jobs_expiry_ttl", 0),
+	}
+	return createIndexes(ctx, coll, models)
+}
+
+func ensureScheduledRunsIndexes(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(ColRuns)
+	models := []mongo.IndexModel{
+		newIndex("task", 1, "scheduled_runs_task", false),
+		newTTLIndex("expires_at", "scheduled_runs_expiry_ttl", 0),
+	}
+	return createIndexes(ctx, coll, models)
+}
+
+func ensureAuditIndexes(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(ColAudit)
+	models := []mongo.IndexModel{
+		// A record's history, in the order it was made
+		{Keys: bson.D{{Key: "resource", Value: 1}, {Key: "resource_id", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("audit_log_resource_id"),
+		},
+	}
+	return createIndexes(ctx, coll, models)
+}
+
+func ensureSearchIndexes(ctx context.Context, db *mongo.Database) error {
+	coll := db.Collection(ColSearch)
+	models := []mongo.IndexModel{
+		// Names are matched as written, without stemming
+		{Keys: bson.D{{Key: "first_name", Value: "text"}, {Key: "last_name", Value: "text"}},
+			Options: options.Index().SetName("search_names_text").SetDefaultLanguage("none"),
+		},
+		newIndex("email_key", 1, "search_email_key", false),
+		newIndex("policy_key", 1, "search_policy_key", false),
+		newIndex("date_of_birth", 1, "search_date_of_birth", false),
+	}
+	return createIndexes(ctx, coll, models)
+}
+
+// ensured records the indexes EnsureIndexes created, by collection, for
+// CheckIndexes.
+var ensured = struct {
+	sync.Mutex
+	indexes map[string][]string
+}{indexes: make(map[string][]string)}
+
+func createIndexes(ctx context.Context, coll *mongo.Collection, models []mongo.IndexModel) error {
+	if _, err := coll.Indexes().CreateMany(ctx, models); err != nil {
+		return err
 	}
-	_, err := coll.Indexes().CreateMany(ctx, models)
-	return err
+	ensured.Lock()
+	defer ensured.Unlock()
+	for _, m := range models {
+		if m.Options != nil && m.Options.Name != nil {
+			ensured.indexes[coll.Name()] = append(ensured.indexes[coll.Name()], *m.Options.Name)
+		}
+	}
+	return nil
+}
+
+// CheckIndexes reports how many indexes each collection has, and fails if
+// any index EnsureIndexes created has gone, for the health report.
+func CheckIndexes(ctx context.Context, db *mongo.Database) (map[string]int, error) {
+	ensured.Lock()
+	want := maps.Clone(ensured.indexes)
+	ensured.Unlock()
+
+	counts := make(map[string]int, len(want))
+	var missing []string
+	for coll, names := range want {
+		specs, err := db.Collection(coll).Indexes().ListSpecifications(ctx)
+		if err != nil {
+			return counts, fmt.Errorf("list indexes of %s: %w", coll, err)
+		}
+		counts[coll] = len(specs)
+		have := make(map[string]bool, len(specs))
+		for _, spec := range specs {
+			have[spec.Name] = true
+		}
+		for _, name := range names {
+			if !have[name] {
+				missing = append(missing, coll+"."+name)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return counts, fmt.Errorf("missing indexes: %s", strings.Join(missing, ", "))
+	}
+	return counts, nil
 }
 
 func newIndex(field string, asc int32, name string, unique bool) mongo.IndexModel {
@@ -107,6 +259,18 @@ func newIndex(field string, asc int32, name string, unique bool) mongo.IndexMode
 	}
 }
 
+// dropIndexes removes indexes that earlier versions created, if present.
+func dropIndexes(ctx context.Context, coll *mongo.Collection, names ...string) error {
+	for _, name := range names {
+		_, err := coll.Indexes().DropOne(ctx, name)
+		var ce mongo.CommandError
+		if err != nil && !(errors.As(err, &ce) && (ce.Code == 26 || ce.Code == 27)) { // NamespaceNotFound, IndexNotFound
+			return fmt.Errorf("drop index %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
 func newTTLIndex(field, name string, expireAfterSeconds int32) mongo.IndexModel {
 	return mongo.IndexModel{
 		Keys:    bson.D{{Key: field, Value: 1}},
@@ -0,0 +1,85 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// watchRetryDelay is how long WatchQueuedJobs waits before reopening a
+	// change stream that failed.
+	watchRetryDelay = 5 * time.Second
+
+	errCodeChangeStreamsUnsupported = 40573 // Standalone server
+	errCodeChangeStreamHistoryLost  = 286   // Resume token fell off the oplog
+)
+
+// WatchQueuedJobs calls notify with the kind of each job that becomes ready
+// to run, on any replica, until ctx is cancelled: newly enqueued jobs and
+// dead jobs an admin queued again. Jobs queued for a retry after a failed
+// attempt aren't reported; polling picks them up when they come due.
+//
+// Change streams need a replica set. On a standalone server it logs a
+// warning and returns, leaving workers to poll.
+func WatchQueuedJobs(ctx context.Context, db *mongodrv.Database, log *slog.Logger, notify func(core.JobKind)) {
+	coll := db.Collection(ColJobs)
+	pipeline := mongodrv.Pipeline{{{Key: "$match", Value: bson.M{
+		"operationType":         bson.M{"$in": bson.A{"insert", "replace"}},
+		"fullDocument.status":   string(core.JobStatusQueued),
+		"fullDocument.attempts": 0,
+	}}}}
+
+	var resumeToken bson.Raw
+	for {
+		opts := options.ChangeStream()
+		if resumeToken != nil {
+			opts.SetResumeAfter(resumeToken)
+		}
+
+		stream, err := coll.Watch(ctx, pipeline, opts)
+		if err == nil {
+			log.Info("watching job queue")
+			for stream.Next(ctx) {
+				var event struct {
+					FullDocument JobDoc `bson:"fullDocument"`
+				}
+				if err := stream.Decode(&event); err != nil {
+					log.Warn("failed to decode job change", "err", err)
+				} else {
+					notify(core.JobKind(event.FullDocument.Kind))
+				}
+				resumeToken = stream.ResumeToken()
+			}
+			err = stream.Err()
+			_ = stream.Close(context.WithoutCancel(ctx))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		var serverErr mongodrv.ServerError
+		if errors.As(err, &serverErr) {
+			switch {
+			case serverErr.HasErrorCode(errCodeChangeStreamsUnsupported):
+				log.Warn("change streams unsupported, workers will poll", "err", err)
+				return
+			case serverErr.HasErrorCode(errCodeChangeStreamHistoryLost):
+				resumeToken = nil
+			}
+		}
+		log.Warn("job change stream failed, reopening", "err", err, "retry_in", watchRetryDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryDelay):
+		}
+	}
+}
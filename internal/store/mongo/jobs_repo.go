@@ -0,0 +1,147 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+)
+
+type JobRepoMongo struct {
+	coll      *mongodrv.Collection
+	opTimeout time.Duration
+}
+
+func NewJobRepo(db *mongodrv.Database, opTimeout time.Duration) *JobRepoMongo {
+	return &JobRepoMongo{
+		coll:      db.Collection(ColJobs),
+		opTimeout: opTimeout,
+	}
+}
+
+func (repo *JobRepoMongo) Enqueue(ctx context.Context, job core.Job) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	_, err := repo.coll.InsertOne(ctx, toJobDoc(job))
+	if err != nil {
+		var we mongodrv.WriteException
+		if errors.As(err, &we) {
+			for _, e := range we.WriteErrors {
+				if e.Code == 11000 {
+					return core.ErrJobExists
+				}
+			}
+		}
+		return fmt.Errorf("jobs.insert: %w", err)
+	}
+	return nil
+}
+
+func (repo *JobRepoMongo) Get(ctx context.Context, id string) (core.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	var doc JobDoc
+	err := repo.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongodrv.ErrNoDocuments) {
+			return core.Job{}, core.ErrJobNotFound
+		}
+		return core.Job{}, fmt.Errorf("jobs.findOne: %w", err)
+	}
+	return fromJobDoc(doc), nil
+}
+
+func (repo *JobRepoMongo) Claim(ctx context.Context, kind core.JobKind, owner string, ttl time.Duration, limit int) ([]core.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"kind":   string(kind),
+		"status": string(core.JobStatusQueued),
+		"run_at": bson.M{"$lte": time.Now().UTC()},
+	}
+	jobs, err := claim(ctx, repo.coll, filter, bson.D{{Key: "run_at", Value: 1}}, owner, ttl, limit, fromJobDoc)
+	if err != nil {
+		return jobs, fmt.Errorf("jobs.claim: %w", err)
+	}
+	return jobs, nil
+}
+
+func (repo *JobRepoMongo) Backlog(ctx context.Context, kind core.JobKind) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	n, err := repo.coll.CountDocuments(ctx, bson.M{
+		"kind":              string(kind),
+		"status":            string(core.JobStatusQueued),
+		"run_at":            bson.M{"$lte": now},
+		fieldLeaseExpiresAt: bson.M{"$not": bson.M{"$gte": now}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("jobs.count: %w", err)
+	}
+	return n, nil
+}
+
+func (repo *JobRepoMongo) Renew(ctx context.Context, id, owner string, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	err := renewClaim(ctx, repo.coll, id, owner, ttl)
+	if err != nil && !errors.Is(err, core.ErrLeaseLost) {
+		return fmt.Errorf("jobs.renew: %w", err)
+	}
+	return err
+}
+
+func (repo *JobRepoMongo) Finish(ctx context.Context, job core.Job, owner string) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	job.LeaseOwner, job.LeaseExpiresAt = "", nil
+	result, err := repo.coll.ReplaceOne(ctx, bson.M{"_id": job.ID, fieldLeaseOwner: owner}, toJobDoc(job))
+	if err != nil {
+		return fmt.Errorf("jobs.replace: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return core.ErrLeaseLost
+	}
+	return nil
+}
+
+func (repo *JobRepoMongo) Update(ctx context.Context, job core.Job, from core.JobStatus) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	result, err := repo.coll.ReplaceOne(ctx, bson.M{"_id": job.ID, "status": string(from)}, toJobDoc(job))
+	if err != nil {
+		return fmt.Errorf("jobs.replace: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return core.ErrConflict
+	}
+	return nil
+}
+
+func (repo *JobRepoMongo) List(ctx context.Context, filter core.JobFilter, page core.PageRequest) (core.Page[core.Job], error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	mongoFilter := bson.M{}
+	if filter.Status != "" {
+		mongoFilter["status"] = string(filter.Status)
+	}
+	if filter.Kind != "" {
+		mongoFilter["kind"] = string(filter.Kind)
+	}
+
+	return findPage(ctx, repo.coll, mongoFilter, page, true,
+		func(d JobDoc) string { return d.ID }, fromJobDoc)
+}
@@ -0,0 +1,116 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Claimed documents carry their lease in these fields.
+const (
+	fieldLeaseOwner     = "lease_owner"
+	fieldLeaseExpiresAt = "lease_expires_at"
+)
+
+// claim leases up to limit documents matching filter to owner, in sort
+// order. Each FindOneAndUpdate matches only documents without a live lease,
+// so concurrent claimers never get the same one.
+func claim[D any, T any](ctx context.Context, coll *mongodrv.Collection, filter bson.M, sort bson.D, owner string, ttl time.Duration, limit int, conv func(D) T) ([]T, error) {
+	now := time.Now().UTC()
+	filter[fieldLeaseExpiresAt] = bson.M{"$not": bson.M{"$gte": now}} // Missing or expired
+	update := bson.M{"$set": bson.M{fieldLeaseOwner: owner, fieldLeaseExpiresAt: now.Add(ttl)}}
+	opts := options.FindOneAndUpdate().SetSort(sort).SetReturnDocument(options.After)
+
+	var items []T
+	for len(items) < limit {
+		var doc D
+		err := coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+		if errors.Is(err, mongodrv.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return items, err
+		}
+		items = append(items, conv(doc))
+	}
+	return items, nil
+}
+
+// renewClaim extends owner's lease, unless another owner has taken it over.
+func renewClaim(ctx context.Context, coll *mongodrv.Collection, id, owner string, ttl time.Duration) error {
+	filter := bson.M{"_id": id, fieldLeaseOwner: owner}
+	update := bson.M{"$set": bson.M{fieldLeaseExpiresAt: time.Now().UTC().Add(ttl)}}
+	result, err := coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return core.ErrLeaseLost
+	}
+	return nil
+}
+
+// LeaseDoc is a named lease, e.g. the leader lease of a singleton job.
+type LeaseDoc struct {
+	Name      string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+type LeaseRepoMongo struct {
+	coll      *mongodrv.Collection
+	opTimeout time.Duration
+}
+
+func NewLeaseRepo(db *mongodrv.Database, opTimeout time.Duration) *LeaseRepoMongo {
+	return &LeaseRepoMongo{
+		coll:      db.Collection(ColLeases),
+		opTimeout: opTimeout,
+	}
+}
+
+// Acquire upserts the lease if it is owner's or has expired; a live lease of
+// another owner doesn't match the filter, so the upsert hits the duplicate _id.
+func (repo *LeaseRepoMongo) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"owner": owner},
+			bson.M{"expires_at": bson.M{"$lt": now}},
+		},
+	}
+	doc := LeaseDoc{Name: name, Owner: owner, ExpiresAt: now.Add(ttl)}
+	_, err := repo.coll.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		var we mongodrv.WriteException
+		if errors.As(err, &we) {
+			for _, e := range we.WriteErrors {
+				if e.Code == 11000 {
+					return false, nil
+				}
+			}
+		}
+		return false, fmt.Errorf("leases.acquire: %w", err)
+	}
+	return true, nil
+}
+
+func (repo *LeaseRepoMongo) Release(ctx context.Context, name, owner string) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	if _, err := repo.coll.DeleteOne(ctx, bson.M{"_id": name, "owner": owner}); err != nil {
+		return fmt.Errorf("leases.delete: %w", err)
+	}
+	return nil
+}
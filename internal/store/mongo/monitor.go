@@ -0,0 +1,55 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+
+	"github.com/MrKriegler/go-insurance/internal/platform/metrics"
+	"github.com/MrKriegler/go-insurance/internal/platform/tracing"
+)
+
+// commandMonitor records every command the client runs, by command name
+// (find, update, ...) and collection, as a metric and, inside a trace, as a
+// span.
+func commandMonitor() *event.CommandMonitor {
+	type inflight struct {
+		collection string
+		end        func(error)
+	}
+	var commands sync.Map // Request ID -> inflight, from start to finish
+
+	finish := func(e event.CommandFinishedEvent, err error) {
+		v, ok := commands.LoadAndDelete(e.RequestID)
+		if !ok {
+			return
+		}
+		cmd := v.(inflight)
+		metrics.ObserveStore("mongodb", e.CommandName, cmd.collection, e.Duration, err)
+		cmd.end(err)
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			// Most commands name their collection as the command's value;
+			// getMore names it in a field of its own
+			key := e.CommandName
+			if key == "getMore" {
+				key = "collection"
+			}
+			var collection string
+			if v, err := e.Command.LookupErr(key); err == nil {
+				collection, _ = v.StringValueOK()
+			}
+			_, end := tracing.StoreSpan(ctx, "mongodb", e.CommandName, collection)
+			commands.Store(e.RequestID, inflight{collection: collection, end: end})
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			finish(e.CommandFinishedEvent, nil)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			finish(e.CommandFinishedEvent, errors.New(e.Failure))
+		},
+	}
+}
@@ -74,17 +74,29 @@ func (repo *OfferRepoMongo) GetByApplicationID(ctx context.Context, appID string
 	return fromOfferDoc(doc), nil
 }
 
+func (repo *OfferRepoMongo) FindByApplicationIDs(ctx context.Context, appIDs []string) ([]core.Offer, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	items, err := findIn(ctx, repo.coll, "application_id", appIDs, fromOfferDoc)
+	if err != nil {
+		return nil, fmt.Errorf("offers.findByApps: %w", err)
+	}
+	return items, nil
+}
+
 func (repo *OfferRepoMongo) Update(ctx context.Context, offer core.Offer) error {
 	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
 	defer cancel()
 
 	doc := toOfferDoc(offer)
-	result, err := repo.coll.ReplaceOne(ctx, bson.M{"_id": offer.ID}, doc)
+	doc.Version++
+	result, err := repo.coll.ReplaceOne(ctx, atVersion(offer.ID, offer.Version), doc)
 	if err != nil {
 		return fmt.Errorf("offers.replace: %w", err)
 	}
 	if result.MatchedCount == 0 {
-		return core.ErrOfferNotFound
+		return versionFailure(ctx, repo.coll, offer.ID, core.ErrOfferNotFound)
 	}
 	return nil
 }
@@ -118,21 +130,51 @@ func (repo *OfferRepoMongo) FindAccepted(ctx context.Context, limit int) ([]core
 	return offers, nil
 }
 
-func (repo *OfferRepoMongo) ExpireOffers(ctx context.Context, before time.Time) (int64, error) {
-	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
-	defer cancel()
-
+// ExpireOffers updates one offer at a time, each within the operation
+// timeout, so that it can return the offers it changed.
+func (repo *OfferRepoMongo) ExpireOffers(ctx context.Context, before time.Time) ([]core.Offer, error) {
 	filter := bson.M{
 		"status":     string(core.OfferStatusPending),
 		"expires_at": bson.M{"$lt": before},
 	}
 	update := bson.M{
 		"$set": bson.M{"status": string(core.OfferStatusExpired)},
+		"$inc": bson.M{"version": 1},
 	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	result, err := repo.coll.UpdateMany(ctx, filter, update)
-	if err != nil {
-		return 0, fmt.Errorf("offers.expireMany: %w", err)
+	var expired []core.Offer
+	for {
+		opCtx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+		var doc OfferDoc
+		err := repo.coll.FindOneAndUpdate(opCtx, filter, update, opts).Decode(&doc)
+		cancel()
+		if errors.Is(err, mongodrv.ErrNoDocuments) {
+			return expired, nil
+		}
+		if err != nil {
+			return expired, fmt.Errorf("offers.expire: %w", err)
+		}
+		expired = append(expired, fromOfferDoc(doc))
+	}
+}
+
+func (repo *OfferRepoMongo) List(ctx context.Context, filter core.OfferFilter, page core.PageRequest) (core.Page[core.Offer], error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	mongoFilter := bson.M{}
+	if filter.Status != "" {
+		mongoFilter["status"] = string(filter.Status)
 	}
-	return result.ModifiedCount, nil
+	if filter.ProductSlug != "" {
+		mongoFilter["product_slug"] = filter.ProductSlug
+	}
+	if filter.ApplicationID != "" {
+		mongoFilter["application_id"] = filter.ApplicationID
+	}
+	timeRange(mongoFilter, "created_at", filter.Created)
+
+	return findPage(ctx, repo.coll, mongoFilter, page, false,
+		func(d OfferDoc) string { return d.ID }, fromOfferDoc)
 }
@@ -0,0 +1,79 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// pageCursor resumes a list after the last _id returned. IDs are ULIDs,
+// so _id order is creation order.
+type pageCursor struct {
+	After string `json:"after"`
+}
+
+// findPage returns one page of documents matching filter in _id order,
+// newest first unless ascending is set. It reads one extra document to
+// know whether there is a next page.
+func findPage[D any, T any](ctx context.Context, coll *mongodrv.Collection, filter bson.M, page core.PageRequest,
+	ascending bool, id func(D) string, conv func(D) T) (core.Page[T], error) {
+
+	op, dir := "$lt", -1
+	if ascending {
+		op, dir = "$gt", 1
+	}
+	if page.Cursor != "" {
+		var c pageCursor
+		if err := core.DecodeCursor(page.Cursor, &c); err != nil {
+			return core.Page[T]{}, err
+		}
+		filter["_id"] = bson.M{op: c.After}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: dir}}).
+		SetLimit(int64(page.Limit) + 1)
+
+	cursor, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return core.Page[T]{}, fmt.Errorf("%s.find: %w", coll.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []D
+	if err := cursor.All(ctx, &docs); err != nil {
+		return core.Page[T]{}, fmt.Errorf("%s.decode: %w", coll.Name(), err)
+	}
+
+	result := core.Page[T]{Items: make([]T, 0, min(len(docs), page.Limit))}
+	if len(docs) > page.Limit {
+		docs = docs[:page.Limit]
+		next, err := core.EncodeCursor(pageCursor{After: id(docs[len(docs)-1])})
+		if err != nil {
+			return core.Page[T]{}, err
+		}
+		result.NextCursor = next
+	}
+	for _, d := range docs {
+		result.Items = append(result.Items, conv(d))
+	}
+	return result, nil
+}
+
+// timeRange turns a core.TimeRange into a query on field, if bounded.
+func timeRange(filter bson.M, field string, r core.TimeRange) {
+	bounds := bson.M{}
+	if !r.From.IsZero() {
+		bounds["$gte"] = r.From
+	}
+	if !r.To.IsZero() {
+		bounds["$lt"] = r.To
+	}
+	if len(bounds) > 0 {
+		filter[field] = bounds
+	}
+}
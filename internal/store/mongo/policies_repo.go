@@ -106,7 +106,18 @@ func (repo *PolicyRepoMongo) GetByApplicationID(ctx context.Context, appID strin
 	return fromPolicyDoc(doc), nil
 }
 
-func (repo *PolicyRepoMongo) List(ctx context.Context, filter core.PolicyFilter, limit, offset int) ([]core.Policy, int64, error) {
+func (repo *PolicyRepoMongo) FindByApplicationIDs(ctx context.Context, appIDs []string) ([]core.Policy, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	items, err := findIn(ctx, repo.coll, "application_id", appIDs, fromPolicyDoc)
+	if err != nil {
+		return nil, fmt.Errorf("policies.findByApps: %w", err)
+	}
+	return items, nil
+}
+
+func (repo *PolicyRepoMongo) List(ctx context.Context, filter core.PolicyFilter, page core.PageRequest) (core.Page[core.Policy], error) {
 	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
 	defer cancel()
 
@@ -117,39 +128,17 @@ func (repo *PolicyRepoMongo) List(ctx context.Context, filter core.PolicyFilter,
 	if filter.Status != "" {
 		mongoFilter["status"] = string(filter.Status)
 	}
-
-	// Get total count
-	total, err := repo.coll.CountDocuments(ctx, mongoFilter)
-	if err != nil {
-		return nil, 0, fmt.Errorf("policies.count: %w", err)
-	}
-
-	// Get paginated results
-	opts := options.Find().
-		SetLimit(int64(limit)).
-		SetSkip(int64(offset)).
-		SetSort(bson.D{{Key: "issued_at", Value: -1}})
-
-	cursor, err := repo.coll.Find(ctx, mongoFilter, opts)
-	if err != nil {
-		return nil, 0, fmt.Errorf("policies.find: %w", err)
-	}
-	defer cursor.Close(ctx)
-
-	var policies []core.Policy
-	for cursor.Next(ctx) {
-		var doc PolicyDoc
-		if err := cursor.Decode(&doc); err != nil {
-			return nil, 0, fmt.Errorf("policies.decode: %w", err)
-		}
-		policies = append(policies, fromPolicyDoc(doc))
+	if filter.ProductSlug != "" {
+		mongoFilter["product_slug"] = filter.ProductSlug
 	}
-
-	if err := cursor.Err(); err != nil {
-		return nil, 0, fmt.Errorf("policies.cursor: %w", err)
+	if filter.InsuredEmail != "" {
+		mongoFilter["insured.email"] = filter.InsuredEmail
 	}
+	timeRange(mongoFilter, "issued_at", filter.Issued)
 
-	return policies, total, nil
+	// Newest first; IDs are issued in time order
+	return findPage(ctx, repo.coll, mongoFilter, page, false,
+		func(d PolicyDoc) string { return d.ID }, fromPolicyDoc)
 }
 
 func (repo *PolicyRepoMongo) NextPolicyNumber(ctx context.Context) (string, error) {
@@ -178,3 +167,31 @@ func (repo *PolicyRepoMongo) NextPolicyNumber(ctx context.Context) (string, erro
 	// Format: POL-YYYY-NNNNNN
 	return fmt.Sprintf("POL-%d-%06d", year, result.Seq), nil
 }
+
+// ExpirePolicies updates one policy at a time, each within the operation
+// timeout, so that it can return the policies it changed.
+func (repo *PolicyRepoMongo) ExpirePolicies(ctx context.Context, before time.Time) ([]core.Policy, error) {
+	filter := bson.M{
+		"status":      string(core.PolicyStatusActive),
+		"expiry_date": bson.M{"$lt": before},
+	}
+	update := bson.M{
+		"$set": bson.M{"status": string(core.PolicyStatusExpired)},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var expired []core.Policy
+	for {
+		opCtx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+		var doc PolicyDoc
+		err := repo.coll.FindOneAndUpdate(opCtx, filter, update, opts).Decode(&doc)
+		cancel()
+		if errors.Is(err, mongodrv.ErrNoDocuments) {
+			return expired, nil
+		}
+		if err != nil {
+			return expired, fmt.Errorf("policies.expire: %w", err)
+		}
+		expired = append(expired, fromPolicyDoc(doc))
+	}
+}
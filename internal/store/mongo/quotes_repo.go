@@ -58,3 +58,50 @@ func (repo *QuoteRepoMongo) Get(ctx context.Context, id string) (core.Quote, err
 	}
 	return fromQuoteDoc(quote), nil
 }
+
+func (repo *QuoteRepoMongo) GetMany(ctx context.Context, ids []string) ([]core.Quote, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	items, err := findIn(ctx, repo.coll, "_id", ids, fromQuoteDoc)
+	if err != nil {
+		return nil, fmt.Errorf("quotes.findMany: %w", err)
+	}
+	return items, nil
+}
+
+func (repo *QuoteRepoMongo) List(ctx context.Context, filter core.QuoteFilter, page core.PageRequest) (core.Page[core.Quote], error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	mongoFilter := bson.M{}
+	if filter.Status != "" {
+		mongoFilter["status"] = string(filter.Status)
+	}
+	if filter.ProductSlug != "" {
+		mongoFilter["product_slug"] = filter.ProductSlug
+	}
+	timeRange(mongoFilter, "created_at", filter.Created)
+
+	return findPage(ctx, repo.coll, mongoFilter, page, false,
+		func(d QuoteDoc) string { return d.ID }, fromQuoteDoc)
+}
+
+func (repo *QuoteRepoMongo) ExpireQuotes(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"status":     bson.M{"$in": bson.A{string(core.QuoteStatusNew), string(core.QuoteStatusPriced)}},
+		"expires_at": bson.M{"$lt": before},
+	}
+	update := bson.M{
+		"$set": bson.M{"status": string(core.QuoteStatusExpired)},
+	}
+
+	result, err := repo.coll.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("quotes.expireMany: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
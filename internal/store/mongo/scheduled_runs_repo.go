@@ -0,0 +1,49 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+)
+
+type ScheduledRunRepoMongo struct {
+	coll      *mongodrv.Collection
+	opTimeout time.Duration
+}
+
+func NewScheduledRunRepo(db *mongodrv.Database, opTimeout time.Duration) *ScheduledRunRepoMongo {
+	return &ScheduledRunRepoMongo{
+		coll:      db.Collection(ColRuns),
+		opTimeout: opTimeout,
+	}
+}
+
+func (repo *ScheduledRunRepoMongo) Create(ctx context.Context, run core.ScheduledRun) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	if _, err := repo.coll.InsertOne(ctx, toScheduledRunDoc(run)); err != nil {
+		return fmt.Errorf("scheduled_runs.insert: %w", err)
+	}
+	return nil
+}
+
+func (repo *ScheduledRunRepoMongo) List(ctx context.Context, filter core.ScheduledRunFilter, page core.PageRequest) (core.Page[core.ScheduledRun], error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	mongoFilter := bson.M{}
+	if filter.Task != "" {
+		mongoFilter["task"] = filter.Task
+	}
+	if filter.Status != "" {
+		mongoFilter["status"] = string(filter.Status)
+	}
+
+	return findPage(ctx, repo.coll, mongoFilter, page, false,
+		func(d ScheduledRunDoc) string { return d.ID }, fromScheduledRunDoc)
+}
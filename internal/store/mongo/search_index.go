@@ -0,0 +1,128 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SearchIndexMongo is a core.SearchIndex on a MongoDB collection. Names use
+// a text index, so they match whole words; emails and policy numbers match
+// by prefix.
+type SearchIndexMongo struct {
+	coll      *mongodrv.Collection
+	opTimeout time.Duration
+}
+
+func NewSearchIndex(db *mongodrv.Database, opTimeout time.Duration) *SearchIndexMongo {
+	return &SearchIndexMongo{
+		coll:      db.Collection(ColSearch),
+		opTimeout: opTimeout,
+	}
+}
+
+// Put upserts the document unless a later version is stored; that one
+// doesn't match the filter, so the upsert hits the duplicate _id.
+func (repo *SearchIndexMongo) Put(ctx context.Context, doc core.SearchDoc) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	d := toSearchDoc(doc)
+	filter := bson.M{"_id": d.ID, "updated_at": bson.M{"$lte": d.UpdatedAt}}
+	_, err := repo.coll.ReplaceOne(ctx, filter, d, options.Replace().SetUpsert(true))
+	if err != nil {
+		var we mongodrv.WriteException
+		if errors.As(err, &we) {
+			for _, e := range we.WriteErrors {
+				if e.Code == 11000 {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("search_index.replace: %w", err)
+	}
+	return nil
+}
+
+func (repo *SearchIndexMongo) Search(ctx context.Context, q core.SearchQuery, page core.PageRequest) (core.Page[core.SearchHit], error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	offset, err := core.DecodeSearchCursor(page.Cursor)
+	if err != nil {
+		return core.Page[core.SearchHit]{}, err
+	}
+
+	filter := bson.M{}
+	if len(q.Kinds) > 0 {
+		filter["kind"] = bson.M{"$in": q.Kinds}
+	}
+	opts := options.Find().SetSkip(int64(offset)).SetLimit(int64(page.Limit) + 1)
+
+	switch q.Field {
+	case core.SearchByName:
+		// Quoted terms must all be present
+		phrases := make([]string, len(q.Terms))
+		for i, t := range q.Terms {
+			phrases[i] = `"` + strings.ReplaceAll(t, `"`, "") + `"`
+		}
+		filter["$text"] = bson.M{"$search": strings.Join(phrases, " ")}
+		score := bson.M{"$meta": "textScore"}
+		opts.SetProjection(bson.M{"score": score}).
+			SetSort(bson.D{{Key: "score", Value: score}, {Key: "updated_at", Value: -1}})
+	case core.SearchByEmail:
+		filter["email_key"] = bson.M{"$regex": "^" + regexp.QuoteMeta(q.Terms[0])}
+		opts.SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	case core.SearchByPolicyNumber:
+		filter["policy_key"] = bson.M{"$regex": "^" + regexp.QuoteMeta(q.Terms[0])}
+		opts.SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	case core.SearchByDateOfBirth:
+		filter["date_of_birth"] = q.Terms[0]
+		opts.SetSort(bson.D{{Key: "updated_at", Value: -1}})
+	}
+
+	cursor, err := repo.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return core.Page[core.SearchHit]{}, fmt.Errorf("search_index.find: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []SearchDocMongo
+	if err := cursor.All(ctx, &docs); err != nil {
+		return core.Page[core.SearchHit]{}, fmt.Errorf("search_index.decode: %w", err)
+	}
+
+	result := core.Page[core.SearchHit]{Items: make([]core.SearchHit, 0, min(len(docs), page.Limit))}
+	if len(docs) > page.Limit {
+		docs = docs[:page.Limit]
+		next, err := core.EncodeCursor(core.SearchCursor{Offset: offset + page.Limit})
+		if err != nil {
+			return core.Page[core.SearchHit]{}, err
+		}
+		result.NextCursor = next
+	}
+	for _, d := range docs {
+		result.Items = append(result.Items, fromSearchDoc(d))
+	}
+	return result, nil
+}
+
+// Empty reports whether nothing has been indexed yet.
+func (repo *SearchIndexMongo) Empty(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	n, err := repo.coll.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return false, fmt.Errorf("search_index.count: %w", err)
+	}
+	return n == 0, nil
+}
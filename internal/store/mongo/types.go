@@ -1,6 +1,8 @@
 package mongo
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/MrKriegler/go-insurance/internal/core"
@@ -13,6 +15,13 @@ const (
 	ColUnderwriting = "underwriting_cases"
 	ColOffers       = "offers"
 	ColPolicies     = "policies"
+	ColAPIKeys      = "api_keys"
+	ColIdempotency  = "idempotency_keys"
+	ColSearch       = "search_index"
+	ColLeases       = "leases"
+	ColJobs         = "jobs"
+	ColRuns         = "scheduled_runs"
+	ColAudit        = "audit_log"
 )
 
 // Product
@@ -137,9 +146,11 @@ type ApplicationDoc struct {
 	MonthlyPremium float64      `bson:"monthly_premium"`
 	Applicant      ApplicantDoc `bson:"applicant"`
 	Status         string       `bson:"status"`
+	OwnerID        string       `bson:"owner_id,omitempty"`
 	CreatedAt      time.Time    `bson:"created_at"`
 	UpdatedAt      time.Time    `bson:"updated_at"`
 	SubmittedAt    *time.Time   `bson:"submitted_at,omitempty"`
+	Version        int          `bson:"version"` // Missing on documents written before versions, i.e. 0
 }
 
 func fromApplicationDoc(d ApplicationDoc) core.Application {
@@ -153,9 +164,11 @@ func fromApplicationDoc(d ApplicationDoc) core.Application {
 		MonthlyPremium: d.MonthlyPremium,
 		Applicant:      fromApplicantDoc(d.Applicant),
 		Status:         core.ApplicationStatus(d.Status),
+		OwnerID:        d.OwnerID,
 		CreatedAt:      d.CreatedAt,
 		UpdatedAt:      d.UpdatedAt,
 		SubmittedAt:    d.SubmittedAt,
+		Version:        d.Version,
 	}
 }
 
@@ -170,9 +183,11 @@ func toApplicationDoc(a core.Application) ApplicationDoc {
 		MonthlyPremium: a.MonthlyPremium,
 		Applicant:      toApplicantDoc(a.Applicant),
 		Status:         string(a.Status),
+		OwnerID:        a.OwnerID,
 		CreatedAt:      a.CreatedAt,
 		UpdatedAt:      a.UpdatedAt,
 		SubmittedAt:    a.SubmittedAt,
+		Version:        a.Version,
 	}
 }
 
@@ -190,6 +205,34 @@ type RiskScoreDoc struct {
 	Recommended string   `bson:"recommended"`
 }
 
+type UWDecisionStepDoc struct {
+	Underwriter string    `bson:"underwriter"`
+	Action      string    `bson:"action"`
+	Decision    string    `bson:"decision"`
+	Reason      string    `bson:"reason"`
+	At          time.Time `bson:"at"`
+}
+
+func fromUWDecisionStepDoc(d UWDecisionStepDoc) core.UWDecisionStep {
+	return core.UWDecisionStep{
+		Underwriter: d.Underwriter,
+		Action:      core.UWStepAction(d.Action),
+		Decision:    core.UWDecision(d.Decision),
+		Reason:      d.Reason,
+		At:          d.At,
+	}
+}
+
+func toUWDecisionStepDoc(st core.UWDecisionStep) UWDecisionStepDoc {
+	return UWDecisionStepDoc{
+		Underwriter: st.Underwriter,
+		Action:      string(st.Action),
+		Decision:    string(st.Decision),
+		Reason:      st.Reason,
+		At:          st.At,
+	}
+}
+
 type UnderwritingCaseDoc struct {
 	ID            string         `bson:"_id"`
 	ApplicationID string         `bson:"application_id"`
@@ -202,9 +245,28 @@ type UnderwritingCaseDoc struct {
 	CreatedAt     time.Time      `bson:"created_at"`
 	UpdatedAt     time.Time      `bson:"updated_at"`
 	DecidedAt     *time.Time     `bson:"decided_at,omitempty"`
+	AssignedTo    string         `bson:"assigned_to"`
+	AssignedAt    *time.Time     `bson:"assigned_at,omitempty"`
+	SLADueAt      *time.Time     `bson:"sla_due_at,omitempty"`
+	EscalatedAt   *time.Time     `bson:"escalated_at,omitempty"`
+
+	PendingDecision *UWDecisionStepDoc  `bson:"pending_decision,omitempty"`
+	DecisionChain   []UWDecisionStepDoc `bson:"decision_chain,omitempty"`
+
+	Version int `bson:"version"`
 }
 
 func fromUnderwritingCaseDoc(d UnderwritingCaseDoc) core.UnderwritingCase {
+	var pending *core.UWDecisionStep
+	if d.PendingDecision != nil {
+		st := fromUWDecisionStepDoc(*d.PendingDecision)
+		pending = &st
+	}
+	var chain []core.UWDecisionStep
+	for _, st := range d.DecisionChain {
+		chain = append(chain, fromUWDecisionStepDoc(st))
+	}
+
 	return core.UnderwritingCase{
 		ID:            d.ID,
 		ApplicationID: d.ApplicationID,
@@ -219,17 +281,36 @@ func fromUnderwritingCaseDoc(d UnderwritingCaseDoc) core.UnderwritingCase {
 			Flags:       d.RiskScore.Flags,
 			Recommended: core.UWDecision(d.RiskScore.Recommended),
 		},
-		Decision:  core.UWDecision(d.Decision),
-		Method:    core.UWMethod(d.Method),
-		DecidedBy: d.DecidedBy,
-		Reason:    d.Reason,
-		CreatedAt: d.CreatedAt,
-		UpdatedAt: d.UpdatedAt,
-		DecidedAt: d.DecidedAt,
+		Decision:    core.UWDecision(d.Decision),
+		Method:      core.UWMethod(d.Method),
+		DecidedBy:   d.DecidedBy,
+		Reason:      d.Reason,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		DecidedAt:   d.DecidedAt,
+		AssignedTo:  d.AssignedTo,
+		AssignedAt:  d.AssignedAt,
+		SLADueAt:    d.SLADueAt,
+		EscalatedAt: d.EscalatedAt,
+
+		PendingDecision: pending,
+		DecisionChain:   chain,
+
+		Version: d.Version,
 	}
 }
 
 func toUnderwritingCaseDoc(uw core.UnderwritingCase) UnderwritingCaseDoc {
+	var pending *UWDecisionStepDoc
+	if uw.PendingDecision != nil {
+		st := toUWDecisionStepDoc(*uw.PendingDecision)
+		pending = &st
+	}
+	var chain []UWDecisionStepDoc
+	for _, st := range uw.DecisionChain {
+		chain = append(chain, toUWDecisionStepDoc(st))
+	}
+
 	return UnderwritingCaseDoc{
 		ID:            uw.ID,
 		ApplicationID: uw.ApplicationID,
@@ -244,13 +325,22 @@ func toUnderwritingCaseDoc(uw core.UnderwritingCase) UnderwritingCaseDoc {
 			Flags:       uw.RiskScore.Flags,
 			Recommended: string(uw.RiskScore.Recommended),
 		},
-		Decision:  string(uw.Decision),
-		Method:    string(uw.Method),
-		DecidedBy: uw.DecidedBy,
-		Reason:    uw.Reason,
-		CreatedAt: uw.CreatedAt,
-		UpdatedAt: uw.UpdatedAt,
-		DecidedAt: uw.DecidedAt,
+		Decision:    string(uw.Decision),
+		Method:      string(uw.Method),
+		DecidedBy:   uw.DecidedBy,
+		Reason:      uw.Reason,
+		CreatedAt:   uw.CreatedAt,
+		UpdatedAt:   uw.UpdatedAt,
+		DecidedAt:   uw.DecidedAt,
+		AssignedTo:  uw.AssignedTo,
+		AssignedAt:  uw.AssignedAt,
+		SLADueAt:    uw.SLADueAt,
+		EscalatedAt: uw.EscalatedAt,
+
+		PendingDecision: pending,
+		DecisionChain:   chain,
+
+		Version: uw.Version,
 	}
 }
 
@@ -267,6 +357,7 @@ type OfferDoc struct {
 	ExpiresAt      time.Time  `bson:"expires_at"`
 	AcceptedAt     *time.Time `bson:"accepted_at,omitempty"`
 	DeclinedAt     *time.Time `bson:"declined_at,omitempty"`
+	Version        int        `bson:"version"`
 }
 
 func fromOfferDoc(d OfferDoc) core.Offer {
@@ -282,6 +373,7 @@ func fromOfferDoc(d OfferDoc) core.Offer {
 		ExpiresAt:      d.ExpiresAt,
 		AcceptedAt:     d.AcceptedAt,
 		DeclinedAt:     d.DeclinedAt,
+		Version:        d.Version,
 	}
 }
 
@@ -298,6 +390,7 @@ func toOfferDoc(o core.Offer) OfferDoc {
 		ExpiresAt:      o.ExpiresAt,
 		AcceptedAt:     o.AcceptedAt,
 		DeclinedAt:     o.DeclinedAt,
+		Version:        o.Version,
 	}
 }
 
@@ -353,3 +446,314 @@ func toPolicyDoc(p core.Policy) PolicyDoc {
 		IssuedAt:       p.IssuedAt,
 	}
 }
+
+// APIKey
+type APIKeyDoc struct {
+	ID         string     `bson:"_id"`
+	Name       string     `bson:"name"`
+	Prefix     string     `bson:"prefix"`
+	Hash       string     `bson:"hash"` // unique index
+	Scopes     []string   `bson:"scopes"`
+	Roles      []string   `bson:"roles"`
+	CreatedBy  string     `bson:"created_by"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	ExpiresAt  *time.Time `bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time `bson:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `bson:"revoked_at,omitempty"`
+	RotatedTo  string     `bson:"rotated_to,omitempty"`
+}
+
+func fromAPIKeyDoc(d APIKeyDoc) core.APIKey {
+	roles := make([]core.Role, len(d.Roles))
+	for i, r := range d.Roles {
+		roles[i] = core.Role(r)
+	}
+	return core.APIKey{
+		ID:         d.ID,
+		Name:       d.Name,
+		Prefix:     d.Prefix,
+		Hash:       d.Hash,
+		Scopes:     d.Scopes,
+		Roles:      roles,
+		CreatedBy:  d.CreatedBy,
+		CreatedAt:  d.CreatedAt,
+		ExpiresAt:  d.ExpiresAt,
+		LastUsedAt: d.LastUsedAt,
+		RevokedAt:  d.RevokedAt,
+		RotatedTo:  d.RotatedTo,
+	}
+}
+
+func toAPIKeyDoc(k core.APIKey) APIKeyDoc {
+	roles := make([]string, len(k.Roles))
+	for i, r := range k.Roles {
+		roles[i] = string(r)
+	}
+	return APIKeyDoc{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Hash:       k.Hash,
+		Scopes:     k.Scopes,
+		Roles:      roles,
+		CreatedBy:  k.CreatedBy,
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		RotatedTo:  k.RotatedTo,
+	}
+}
+
+// IdempotencyRecord
+type IdempotencyDoc struct {
+	ID          string            `bson:"_id"` // Client identity plus Idempotency-Key
+	Fingerprint string            `bson:"fingerprint"`
+	Status      int               `bson:"status"`
+	Header      map[string]string `bson:"header,omitempty"`
+	Body        []byte            `bson:"body,omitempty"`
+	CreatedAt   time.Time         `bson:"created_at"`
+	ExpiresAt   time.Time         `bson:"expires_at"` // TTL index
+}
+
+func fromIdempotencyDoc(d IdempotencyDoc) core.IdempotencyRecord {
+	return core.IdempotencyRecord{
+		Key:         d.ID,
+		Fingerprint: d.Fingerprint,
+		Status:      d.Status,
+		Header:      d.Header,
+		Body:        d.Body,
+		CreatedAt:   d.CreatedAt,
+		ExpiresAt:   d.ExpiresAt,
+	}
+}
+
+func toIdempotencyDoc(r core.IdempotencyRecord) IdempotencyDoc {
+	return IdempotencyDoc{
+		ID:          r.Key,
+		Fingerprint: r.Fingerprint,
+		Status:      r.Status,
+		Header:      r.Header,
+		Body:        r.Body,
+		CreatedAt:   r.CreatedAt,
+		ExpiresAt:   r.ExpiresAt,
+	}
+}
+
+// Job
+type JobDoc struct {
+	ID             string     `bson:"_id"` // Kind.subject
+	Kind           string     `bson:"kind"`
+	SubjectID      string     `bson:"subject_id"`
+	Status         string     `bson:"status"`
+	Attempts       int        `bson:"attempts"`
+	RunAt          time.Time  `bson:"run_at"`
+	LastError      string     `bson:"last_error,omitempty"`
+	LeaseOwner     string     `bson:"lease_owner,omitempty"`
+	LeaseExpiresAt *time.Time `bson:"lease_expires_at,omitempty"`
+	CreatedAt      time.Time  `bson:"created_at"`
+	UpdatedAt      time.Time  `bson:"updated_at"`
+	ExpiresAt      *time.Time `bson:"expires_at,omitempty"` // TTL index
+	TraceParent    string     `bson:"trace_parent,omitempty"`
+}
+
+func fromJobDoc(d JobDoc) core.Job {
+	return core.Job{
+		ID:             d.ID,
+		Kind:           core.JobKind(d.Kind),
+		SubjectID:      d.SubjectID,
+		Status:         core.JobStatus(d.Status),
+		Attempts:       d.Attempts,
+		RunAt:          d.RunAt,
+		LastError:      d.LastError,
+		LeaseOwner:     d.LeaseOwner,
+		LeaseExpiresAt: d.LeaseExpiresAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		ExpiresAt:      d.ExpiresAt,
+		TraceParent:    d.TraceParent,
+	}
+}
+
+func toJobDoc(j core.Job) JobDoc {
+	return JobDoc{
+		ID:             j.ID,
+		Kind:           string(j.Kind),
+		SubjectID:      j.SubjectID,
+		Status:         string(j.Status),
+		Attempts:       j.Attempts,
+		RunAt:          j.RunAt,
+		LastError:      j.LastError,
+		LeaseOwner:     j.LeaseOwner,
+		LeaseExpiresAt: j.LeaseExpiresAt,
+		CreatedAt:      j.CreatedAt,
+		UpdatedAt:      j.UpdatedAt,
+		ExpiresAt:      j.ExpiresAt,
+		TraceParent:    j.TraceParent,
+	}
+}
+
+type ScheduledRunDoc struct {
+	ID         string    `bson:"_id"`
+	Task       string    `bson:"task"`
+	Status     string    `bson:"status"`
+	Affected   int64     `bson:"affected"`
+	Error      string    `bson:"error,omitempty"`
+	Owner      string    `bson:"owner"`
+	StartedAt  time.Time `bson:"started_at"`
+	FinishedAt time.Time `bson:"finished_at"`
+	ExpiresAt  time.Time `bson:"expires_at"` // TTL index
+}
+
+func fromScheduledRunDoc(d ScheduledRunDoc) core.ScheduledRun {
+	return core.ScheduledRun{
+		ID:         d.ID,
+		Task:       d.Task,
+		Status:     core.ScheduledRunStatus(d.Status),
+		Affected:   d.Affected,
+		Error:      d.Error,
+		Owner:      d.Owner,
+		StartedAt:  d.StartedAt,
+		FinishedAt: d.FinishedAt,
+		ExpiresAt:  d.ExpiresAt,
+	}
+}
+
+func toScheduledRunDoc(r core.ScheduledRun) ScheduledRunDoc {
+	return ScheduledRunDoc{
+		ID:         r.ID,
+		Task:       r.Task,
+		Status:     string(r.Status),
+		Affected:   r.Affected,
+		Error:      r.Error,
+		Owner:      r.Owner,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		ExpiresAt:  r.ExpiresAt,
+	}
+}
+
+// SearchDoc
+type SearchDocMongo struct {
+	ID           string    `bson:"_id"` // Kind|resource ID
+	Kind         string    `bson:"kind"`
+	RefID        string    `bson:"ref_id"`
+	FirstName    string    `bson:"first_name"` // Text index, with last_name
+	LastName     string    `bson:"last_name"`
+	Email        string    `bson:"email"`
+	EmailKey     string    `bson:"email_key"` // Lowercased, for prefix matches
+	DateOfBirth  string    `bson:"date_of_birth"`
+	PolicyNumber string    `bson:"policy_number,omitempty"`
+	PolicyKey    string    `bson:"policy_key,omitempty"` // Lowercased, for prefix matches
+	ProductSlug  string    `bson:"product_slug,omitempty"`
+	Status       string    `bson:"status,omitempty"`
+	UpdatedAt    time.Time `bson:"updated_at"`
+	Score        float64   `bson:"score,omitempty"` // Text score, projected on name searches
+}
+
+func fromSearchDoc(d SearchDocMongo) core.SearchHit {
+	score := d.Score
+	if score == 0 {
+		score = 1
+	}
+	return core.SearchHit{
+		SearchDoc: core.SearchDoc{
+			Kind:         core.SearchKind(d.Kind),
+			ID:           d.RefID,
+			FirstName:    d.FirstName,
+			LastName:     d.LastName,
+			Email:        d.Email,
+			DateOfBirth:  d.DateOfBirth,
+			PolicyNumber: d.PolicyNumber,
+			ProductSlug:  d.ProductSlug,
+			Status:       d.Status,
+			UpdatedAt:    d.UpdatedAt,
+		},
+		Score: score,
+	}
+}
+
+func toSearchDoc(d core.SearchDoc) SearchDocMongo {
+	return SearchDocMongo{
+		ID:           string(d.Kind) + "|" + d.ID,
+		Kind:         string(d.Kind),
+		RefID:        d.ID,
+		FirstName:    d.FirstName,
+		LastName:     d.LastName,
+		Email:        d.Email,
+		EmailKey:     strings.ToLower(d.Email),
+		DateOfBirth:  d.DateOfBirth,
+		PolicyNumber: d.PolicyNumber,
+		PolicyKey:    strings.ToLower(d.PolicyNumber),
+		ProductSlug:  d.ProductSlug,
+		Status:       d.Status,
+		UpdatedAt:    d.UpdatedAt,
+	}
+}
+
+// AuditDoc
+type AuditDoc struct {
+	ID         string           `bson:"_id"` // ULID: _id order is the order entries were made
+	Resource   string           `bson:"resource"`
+	ResourceID string           `bson:"resource_id"`
+	Action     string           `bson:"action"`
+	Actor      string           `bson:"actor"`
+	APIKeyID   string           `bson:"api_key_id,omitempty"`
+	Changes    []AuditChangeDoc `bson:"changes"`
+	RequestID  string           `bson:"request_id,omitempty"`
+	Reason     string           `bson:"reason,omitempty"`
+	At         time.Time        `bson:"at"`
+}
+
+// AuditChangeDoc keeps the values as JSON text, exactly as they were.
+type AuditChangeDoc struct {
+	Path   string `bson:"path"`
+	Before string `bson:"before,omitempty"`
+	After  string `bson:"after,omitempty"`
+}
+
+func fromAuditDoc(d AuditDoc) core.AuditEntry {
+	changes := make([]core.AuditChange, len(d.Changes))
+	for i, c := range d.Changes {
+		changes[i] = core.AuditChange{Path: c.Path, Before: rawJSON(c.Before), After: rawJSON(c.After)}
+	}
+	return core.AuditEntry{
+		ID:         d.ID,
+		Resource:   core.AuditResource(d.Resource),
+		ResourceID: d.ResourceID,
+		Action:     core.AuditAction(d.Action),
+		Actor:      d.Actor,
+		APIKeyID:   d.APIKeyID,
+		Changes:    changes,
+		RequestID:  d.RequestID,
+		Reason:     d.Reason,
+		At:         d.At,
+	}
+}
+
+func toAuditDoc(e core.AuditEntry) AuditDoc {
+	changes := make([]AuditChangeDoc, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i] = AuditChangeDoc{Path: c.Path, Before: string(c.Before), After: string(c.After)}
+	}
+	return AuditDoc{
+		ID:         e.ID,
+		Resource:   string(e.Resource),
+		ResourceID: e.ResourceID,
+		Action:     string(e.Action),
+		Actor:      e.Actor,
+		APIKeyID:   e.APIKeyID,
+		Changes:    changes,
+		RequestID:  e.RequestID,
+		Reason:     e.Reason,
+		At:         e.At,
+	}
+}
+
+// rawJSON turns stored JSON text back into a value; empty means absent.
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+	return json.RawMessage(s)
+}
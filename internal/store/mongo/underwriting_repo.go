@@ -74,17 +74,29 @@ func (repo *UnderwritingRepoMongo) GetByApplicationID(ctx context.Context, appID
 	return fromUnderwritingCaseDoc(doc), nil
 }
 
+func (repo *UnderwritingRepoMongo) FindByApplicationIDs(ctx context.Context, appIDs []string) ([]core.UnderwritingCase, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	items, err := findIn(ctx, repo.coll, "application_id", appIDs, fromUnderwritingCaseDoc)
+	if err != nil {
+		return nil, fmt.Errorf("underwriting.findByApps: %w", err)
+	}
+	return items, nil
+}
+
 func (repo *UnderwritingRepoMongo) Update(ctx context.Context, uw core.UnderwritingCase) error {
 	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
 	defer cancel()
 
 	doc := toUnderwritingCaseDoc(uw)
-	result, err := repo.coll.ReplaceOne(ctx, bson.M{"_id": uw.ID}, doc)
+	doc.Version++
+	result, err := repo.coll.ReplaceOne(ctx, atVersion(uw.ID, uw.Version), doc)
 	if err != nil {
 		return fmt.Errorf("underwriting.replace: %w", err)
 	}
 	if result.MatchedCount == 0 {
-		return core.ErrUWCaseNotFound
+		return versionFailure(ctx, repo.coll, uw.ID, core.ErrUWCaseNotFound)
 	}
 	return nil
 }
@@ -146,3 +158,91 @@ func (repo *UnderwritingRepoMongo) FindReferred(ctx context.Context, limit int)
 
 	return cases, nil
 }
+
+func (repo *UnderwritingRepoMongo) List(ctx context.Context, filter core.UWCaseFilter, page core.PageRequest) (core.Page[core.UnderwritingCase], error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	mongoFilter := bson.M{}
+	if filter.Decision != "" {
+		mongoFilter["decision"] = string(filter.Decision)
+	}
+	switch filter.Assignee {
+	case "":
+	case core.UWUnassigned:
+		// Older documents may not have the field at all
+		mongoFilter["assigned_to"] = bson.M{"$in": bson.A{"", nil}}
+	default:
+		mongoFilter["assigned_to"] = filter.Assignee
+	}
+	created := bson.M{}
+	if !filter.CreatedBefore.IsZero() {
+		created["$lte"] = filter.CreatedBefore
+	}
+	if !filter.CreatedAfter.IsZero() {
+		created["$gte"] = filter.CreatedAfter
+	}
+	if len(created) > 0 {
+		mongoFilter["created_at"] = created
+	}
+
+	// Oldest first, so the queue is worked in arrival order
+	return findPage(ctx, repo.coll, mongoFilter, page, true,
+		func(d UnderwritingCaseDoc) string { return d.ID }, fromUnderwritingCaseDoc)
+}
+
+func (repo *UnderwritingRepoMongo) Assign(ctx context.Context, id, assignee string, version int, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"assigned_to": assignee,
+			"assigned_at": at,
+			"updated_at":  at,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := repo.coll.UpdateOne(ctx, atVersion(id, version), update)
+	if err != nil {
+		return fmt.Errorf("underwriting.assign: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return versionFailure(ctx, repo.coll, id, core.ErrUWCaseNotFound)
+	}
+	return nil
+}
+
+func (repo *UnderwritingRepoMongo) FindSLABreached(ctx context.Context, now time.Time, limit int) ([]core.UnderwritingCase, error) {
+	ctx, cancel := context.WithTimeout(ctx, repo.opTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"decision":     string(core.UWDecisionReferred),
+		"sla_due_at":   bson.M{"$lt": now},
+		"escalated_at": bson.M{"$exists": false},
+	}
+	opts := options.Find().SetLimit(int64(limit)).SetSort(bson.D{{Key: "sla_due_at", Value: 1}})
+
+	cursor, err := repo.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("underwriting.findBreached: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var cases []core.UnderwritingCase
+	for cursor.Next(ctx) {
+		var doc UnderwritingCaseDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("underwriting.decode: %w", err)
+		}
+		cases = append(cases, fromUnderwritingCaseDoc(doc))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("underwriting.cursor: %w", err)
+	}
+
+	return cases, nil
+}
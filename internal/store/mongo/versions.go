@@ -0,0 +1,32 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"go.mongodb.org/mongo-driver/bson"
+	mongodrv "go.mongodb.org/mongo-driver/mongo"
+)
+
+// atVersion matches the document id while it is still at version.
+// Documents written before records had versions have none, which counts as 0.
+func atVersion(id string, version int) bson.M {
+	if version == 0 {
+		return bson.M{"_id": id, "version": bson.M{"$in": bson.A{0, nil}}}
+	}
+	return bson.M{"_id": id, "version": version}
+}
+
+// versionFailure tells a missing document apart from one that moved on
+// from the version a conditional write expected.
+func versionFailure(ctx context.Context, coll *mongodrv.Collection, id string, notFound error) error {
+	n, err := coll.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("%s.count: %w", coll.Name(), err)
+	}
+	if n == 0 {
+		return notFound
+	}
+	return core.ErrChanged
+}
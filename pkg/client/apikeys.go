@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// ListAPIKeys returns every API key, without secrets (admin only).
+func (c *Client) ListAPIKeys(ctx context.Context, opts ...CallOption) ([]core.APIKey, error) {
+	var keys []core.APIKey
+	err := c.do(ctx, http.MethodGet, "/admin/api-keys", nil, nil, &keys, opts)
+	return keys, err
+}
+
+// CreateAPIKey issues a key. The plaintext key is only in this response.
+func (c *Client) CreateAPIKey(ctx context.Context, in core.APIKeyInput, opts ...CallOption) (core.IssuedAPIKey, error) {
+	var issued core.IssuedAPIKey
+	err := c.do(ctx, http.MethodPost, "/admin/api-keys", nil, in, &issued, opts)
+	return issued, err
+}
+
+// GetAPIKey returns a key's metadata.
+func (c *Client) GetAPIKey(ctx context.Context, id string, opts ...CallOption) (core.APIKey, error) {
+	var key core.APIKey
+	err := c.do(ctx, http.MethodGet, "/admin/api-keys/"+url.PathEscape(id), nil, nil, &key, opts)
+	return key, err
+}
+
+// RotateAPIKey issues a replacement key. The old key stays valid for grace
+// (whole seconds), or is revoked at once when grace is zero.
+func (c *Client) RotateAPIKey(ctx context.Context, id string, grace time.Duration, opts ...CallOption) (core.IssuedAPIKey, error) {
+	body := struct {
+		GraceSeconds int `json:"grace_seconds"`
+	}{int(grace / time.Second)}
+
+	var issued core.IssuedAPIKey
+	err := c.do(ctx, http.MethodPost, "/admin/api-keys/"+url.PathEscape(id)+":rotate", nil, body, &issued, opts)
+	return issued, err
+}
+
+// RevokeAPIKey disables a key immediately.
+func (c *Client) RevokeAPIKey(ctx context.Context, id string, opts ...CallOption) (core.APIKey, error) {
+	var key core.APIKey
+	err := c.do(ctx, http.MethodPost, "/admin/api-keys/"+url.PathEscape(id)+":revoke", nil, nil, &key, opts)
+	return key, err
+}
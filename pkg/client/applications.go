@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// CreateApplication starts a draft application from a quote.
+func (c *Client) CreateApplication(ctx context.Context, in core.ApplicationInput, opts ...CallOption) (core.Application, error) {
+	var app core.Application
+	err := c.do(ctx, http.MethodPost, "/applications", nil, in, &app, opts)
+	return app, err
+}
+
+// GetApplication returns the application with the given ID.
+func (c *Client) GetApplication(ctx context.Context, id string, opts ...CallOption) (core.Application, error) {
+	var app core.Application
+	err := c.do(ctx, http.MethodGet, "/applications/"+url.PathEscape(id), nil, nil, &app, opts)
+	return app, err
+}
+
+// PatchApplication updates a draft application.
+func (c *Client) PatchApplication(ctx context.Context, id string, patch core.ApplicationPatch, opts ...CallOption) (core.Application, error) {
+	var app core.Application
+	err := c.do(ctx, http.MethodPatch, "/applications/"+url.PathEscape(id), nil, patch, &app, opts)
+	return app, err
+}
+
+// SubmitApplication submits a draft application for underwriting.
+func (c *Client) SubmitApplication(ctx context.Context, id string, opts ...CallOption) (core.Application, error) {
+	var app core.Application
+	err := c.do(ctx, http.MethodPost, "/applications/"+url.PathEscape(id)+":submit", nil, nil, &app, opts)
+	return app, err
+}
+
+// ListApplications returns one page of applications, newest first.
+// Applicants only see their own.
+func (c *Client) ListApplications(ctx context.Context, filter core.ApplicationFilter, page core.PageRequest, opts ...CallOption) (core.Page[core.Application], error) {
+	q := listQuery(page, filter.Created)
+	setIf(q, "status", string(filter.Status))
+	setIf(q, "product", filter.ProductSlug)
+	setIf(q, "email", filter.ApplicantEmail)
+
+	var apps core.Page[core.Application]
+	err := c.do(ctx, http.MethodGet, "/applications", q, nil, &apps, opts)
+	return apps, err
+}
@@ -0,0 +1,268 @@
+// Package client is a Go client for the insurance REST API (/api/v1).
+//
+// Requests and responses use the core types the handlers use, and problem
+// responses come back as *Error, which errors.Is matches against the core
+// errors (core.ErrNotFound, core.ErrConflict, ...):
+//
+//	c := client.New("http://localhost:8080", client.WithAPIKey(key))
+//	quote, err := c.CreateQuote(ctx, core.QuoteInput{ProductSlug: "term-life-20", ...})
+//	if errors.Is(err, core.ErrValidation) { ... }
+package client
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string // Server root plus /api/v1
+	httpClient *http.Client
+	apiKey     string
+	token      string
+	userAgent  string
+	retry      RetryPolicy
+}
+
+// RetryPolicy controls retries of requests that fail with a network error,
+// 429 or 502-504. Waits double from Backoff up to MaxBackoff, with jitter,
+// unless the server sends Retry-After.
+type RetryPolicy struct {
+	MaxAttempts int // Including the first; 1 disables retries
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+type Option func(*Client)
+
+// WithAPIKey authenticates with an API key, sent as X-API-Key.
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithBearerToken authenticates with a JWT, sent as Authorization: Bearer.
+func WithBearerToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithHTTPClient replaces http.DefaultClient, e.g. to set timeouts or a transport.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+func WithUserAgent(ua string) Option {
+	return func(c *Client) { c.userAgent = ua }
+}
+
+// New creates a client for the server at baseURL, e.g. "https://api.example.com".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/") + "/api/v1",
+		httpClient: http.DefaultClient,
+		userAgent:  "go-insurance-client",
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+	return c
+}
+
+// CallOption sets per-call headers or reads response headers.
+type CallOption func(*call)
+
+type call struct {
+	idempotencyKey string
+	ifMatch        string
+	etag           *string
+	link           *string // Receives the Link header of bare-array lists
+	readOnly       bool    // A POST that changes nothing, so needs no idempotency key
+}
+
+// WithIdempotencyKey sends Idempotency-Key, so the server replays the first
+// response to a repeated request. Mutations get a random key when none is
+// given, so retries never apply them twice.
+func WithIdempotencyKey(key string) CallOption {
+	return func(c *call) { c.idempotencyKey = key }
+}
+
+// WithIfMatch makes a state change conditional on the resource's ETag.
+func WithIfMatch(etag string) CallOption {
+	return func(c *call) { c.ifMatch = etag }
+}
+
+// ETag stores the response's ETag in dst, for a later WithIfMatch.
+func ETag(dst *string) CallOption {
+	return func(c *call) { c.etag = dst }
+}
+
+// do sends a request and decodes a 2xx JSON response into out (if non-nil).
+// body, when non-nil, is sent as JSON.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any, opts []CallOption) error {
+	var cl call
+	for _, opt := range opts {
+		opt(&cl)
+	}
+
+	var payload []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		payload = b
+	}
+	if method != http.MethodGet && !cl.readOnly && cl.idempotencyKey == "" {
+		cl.idempotencyKey = newIdempotencyKey()
+	}
+
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, u, payload, cl)
+		if err == nil && (resp.StatusCode < 300 || !retryable(resp.StatusCode)) {
+			defer resp.Body.Close()
+			return c.read(resp, out, cl)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if attempt >= c.retry.MaxAttempts {
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			defer resp.Body.Close()
+			return c.read(resp, out, cl)
+		}
+
+		wait := c.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = after
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, u string, payload []byte, cl call) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case c.apiKey != "":
+		req.Header.Set("X-API-Key", c.apiKey)
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if cl.idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", cl.idempotencyKey)
+	}
+	if cl.ifMatch != "" {
+		req.Header.Set("If-Match", cl.ifMatch)
+	}
+	return c.httpClient.Do(req)
+}
+
+func (c *Client) read(resp *http.Response, out any, cl call) error {
+	if resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+	if cl.etag != nil {
+		*cl.etag = resp.Header.Get("ETag")
+	}
+	if cl.link != nil {
+		*cl.link = strings.Join(resp.Header.Values("Link"), ", ")
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.retry.Backoff << (attempt - 1)
+	if c.retry.MaxBackoff > 0 && (d > c.retry.MaxBackoff || d <= 0) {
+		d = c.retry.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Jitter spreads out clients that failed together
+	return d/2 + rand.N(d/2+1)
+}
+
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter reads a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = crand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,304 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	transporthttp "github.com/MrKriegler/go-insurance/internal/http"
+	"github.com/MrKriegler/go-insurance/internal/http/handlers"
+	"github.com/MrKriegler/go-insurance/internal/middleware"
+	"github.com/MrKriegler/go-insurance/internal/store/memory"
+	"github.com/MrKriegler/go-insurance/pkg/client"
+)
+
+// The contract tests run the client against the v1 router as main.go
+// builds it, on the in-memory stores, so a change on either side that the
+// other does not expect fails here.
+
+var principals = map[string]core.Principal{
+	"applicant-key":   {Subject: "ada", Roles: []core.Role{core.RoleApplicant}},
+	"agent-key":       {Subject: "agent-1", Roles: []core.Role{core.RoleAgent}},
+	"underwriter-key": {Subject: "uw-1", Roles: []core.Role{core.RoleUnderwriter}},
+}
+
+type keys struct{}
+
+func (keys) Authenticate(_ context.Context, key string) (core.Principal, error) {
+	if p, ok := principals[key]; ok {
+		return p, nil
+	}
+	return core.Principal{}, core.ErrAPIKeyInvalid
+}
+
+type server struct {
+	*httptest.Server
+	apps        core.ApplicationRepo
+	underwriter core.UnderwritingService
+	policies    core.PolicyService
+	failNext    atomic.Bool // Drop the next response and answer 502, as a proxy might
+}
+
+func newServer(t *testing.T) *server {
+	t.Helper()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ctx := context.Background()
+
+	productRepo := memory.NewProductRepo()
+	quoteRepo := memory.NewQuoteRepo()
+	appRepo := memory.NewApplicationRepo()
+	uwRepo := memory.NewUnderwritingRepo()
+	offerRepo := memory.NewOfferRepo()
+	policyRepo := memory.NewPolicyRepo()
+	jobRepo := memory.NewJobRepo()
+	auditRepo := memory.NewAuditRepo()
+
+	err := productRepo.UpsertBySlug(ctx, core.Product{
+		Slug: "term-life-20", Name: "Term Life 20", TermYears: 20,
+		MinCoverage: 50000, MaxCoverage: 1000000, BaseRate: 0.1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quoteService := core.NewQuoteService(productRepo, quoteRepo)
+	appService := core.NewApplicationService(appRepo, quoteRepo, jobRepo, auditRepo)
+	offerService := core.NewOfferService(offerRepo, appRepo, jobRepo, auditRepo)
+	policyService := core.NewPolicyService(policyRepo, offerRepo, appRepo, auditRepo)
+	uwService := core.NewUnderwritingService(uwRepo, appRepo, offerRepo, auditRepo, core.UWConfig{SLA: 24 * time.Hour})
+
+	r := chi.NewRouter()
+	r.Use(middleware.Authenticate(keys{}, nil))
+	r.Use(middleware.NewIdempotency(memory.NewIdempotencyRepo(), time.Hour, log).Middleware)
+	transporthttp.MountVersions(r, transporthttp.Version{Name: "v1", Deps: transporthttp.Deps{Mounts: []handlers.Mountable{
+		handlers.NewProductHandler(productRepo, log),
+		handlers.NewQuoteHandler(quoteService, quoteRepo, log),
+		handlers.NewApplicationHandler(appService, log),
+		handlers.NewUWHandler(uwService, log),
+		handlers.NewOfferHandler(offerService, log),
+		handlers.NewPolicyHandler(policyService, log),
+	}}})
+
+	s := &server{apps: appRepo, underwriter: uwService, policies: policyService}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if s.failNext.CompareAndSwap(true, false) {
+			r.ServeHTTP(httptest.NewRecorder(), req)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		r.ServeHTTP(w, req)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *server) client(key string) *client.Client {
+	return client.New(s.URL, client.WithAPIKey(key),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+}
+
+// work runs what the background workers would, as they would.
+func (s *server) work(t *testing.T, run func(ctx context.Context) error) {
+	t.Helper()
+	if err := run(core.WithPrincipal(context.Background(), core.SystemPrincipal)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func applicant(age int, smoker bool) core.Applicant {
+	return core.Applicant{
+		FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com",
+		DateOfBirth: time.Now().AddDate(-age, -1, 0).Format(time.DateOnly), Age: age, Smoker: smoker, State: "CA",
+	}
+}
+
+// submit quotes, applies and submits as the applicant.
+func submit(t *testing.T, c *client.Client, in core.Applicant, coverage int64) core.Application {
+	t.Helper()
+	ctx := context.Background()
+
+	quote, err := c.CreateQuote(ctx, core.QuoteInput{
+		ProductSlug: "term-life-20", CoverageAmount: coverage, TermYears: 20, Age: in.Age, Smoker: in.Smoker,
+	})
+	if err != nil {
+		t.Fatalf("CreateQuote: %v", err)
+	}
+	app, err := c.CreateApplication(ctx, core.ApplicationInput{QuoteID: quote.ID, Applicant: in})
+	if err != nil {
+		t.Fatalf("CreateApplication: %v", err)
+	}
+	app, err = c.SubmitApplication(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("SubmitApplication: %v", err)
+	}
+	return app
+}
+
+func TestJourney(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+	ada, agent := s.client("applicant-key"), s.client("agent-key")
+
+	products, err := ada.ListProducts(ctx)
+	if err != nil || len(products) != 1 || products[0].Slug != "term-life-20" {
+		t.Fatalf("ListProducts = %+v, %v; want term-life-20", products, err)
+	}
+
+	app := submit(t, ada, applicant(35, false), 250000)
+	if app.Status != core.ApplicationStatusSubmitted {
+		t.Fatalf("submitted application status = %q", app.Status)
+	}
+	s.work(t, func(ctx context.Context) error {
+		_, err := s.underwriter.ProcessApplication(ctx, app.ID)
+		return err
+	})
+
+	offers, err := agent.ListOffers(ctx, core.OfferFilter{ApplicationID: app.ID}, core.PageRequest{})
+	if err != nil || len(offers.Items) != 1 {
+		t.Fatalf("ListOffers = %+v, %v; want the auto-approved offer", offers, err)
+	}
+	offer, err := ada.AcceptOffer(ctx, offers.Items[0].ID)
+	if err != nil || offer.Status != core.OfferStatusAccepted || offer.AcceptedAt == nil {
+		t.Fatalf("AcceptOffer = %+v, %v; want accepted", offer, err)
+	}
+	if _, err := ada.AcceptOffer(ctx, offer.ID); !errors.Is(err, core.ErrInvalidState) {
+		t.Errorf("AcceptOffer again: %v, want ErrInvalidState", err)
+	}
+
+	var issued core.Policy
+	s.work(t, func(ctx context.Context) (err error) {
+		issued, err = s.policies.IssueFromOffer(ctx, offer.ID)
+		return err
+	})
+	policy, err := agent.GetPolicy(ctx, issued.Number)
+	if err != nil || policy.OfferID != offer.ID || policy.Insured.Email != "ada@example.com" {
+		t.Fatalf("GetPolicy = %+v, %v; want the policy for offer %s", policy, err, offer.ID)
+	}
+	policies, err := agent.ListPolicies(ctx, core.PolicyFilter{ApplicationID: app.ID}, core.PageRequest{})
+	if err != nil || len(policies.Items) != 1 || policies.Items[0].Number != issued.Number {
+		t.Fatalf("ListPolicies = %+v, %v; want %s", policies, err, issued.Number)
+	}
+}
+
+func TestConditionalPatch(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+	ada := s.client("applicant-key")
+
+	quote, err := ada.CreateQuote(ctx, core.QuoteInput{ProductSlug: "term-life-20", CoverageAmount: 100000, TermYears: 20, Age: 35})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := ada.CreateApplication(ctx, core.ApplicationInput{QuoteID: quote.ID, Applicant: applicant(35, false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var etag string
+	if _, err := ada.GetApplication(ctx, app.ID, client.ETag(&etag)); err != nil || etag == "" {
+		t.Fatalf("GetApplication: ETag %q, %v", etag, err)
+	}
+	changed := applicant(35, false)
+	changed.State = "NY"
+	var next string
+	app, err = ada.PatchApplication(ctx, app.ID, core.ApplicationPatch{Applicant: &changed}, client.WithIfMatch(etag), client.ETag(&next))
+	if err != nil || app.Applicant.State != "NY" || next == etag {
+		t.Fatalf("PatchApplication = %+v, ETag %q, %v; want NY under a new ETag", app.Applicant, next, err)
+	}
+
+	_, err = ada.PatchApplication(ctx, app.ID, core.ApplicationPatch{Applicant: &changed}, client.WithIfMatch(etag))
+	var apiErr *client.Error
+	if !errors.Is(err, core.ErrPreconditionFailed) || !errors.Is(err, core.ErrConflict) || !errors.As(err, &apiErr) {
+		t.Fatalf("stale If-Match: %v, want a 412 *client.Error", err)
+	}
+	if got := apiErr.Header.Get("ETag"); got != next {
+		t.Errorf("412 ETag = %q, want the current %q", got, next)
+	}
+}
+
+func TestRetryReplaysIdempotentResponse(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+	agent := s.client("agent-key")
+
+	// The first attempt is applied but its response is lost; the retry
+	// carries the same Idempotency-Key, so the server replays it.
+	s.failNext.Store(true)
+	quote, err := agent.CreateQuote(ctx, core.QuoteInput{ProductSlug: "term-life-20", CoverageAmount: 100000, TermYears: 20, Age: 35})
+	if err != nil {
+		t.Fatalf("CreateQuote: %v", err)
+	}
+	quotes, err := agent.ListQuotes(ctx, core.QuoteFilter{}, core.PageRequest{})
+	if err != nil || len(quotes.Items) != 1 || quotes.Items[0].ID != quote.ID {
+		t.Fatalf("ListQuotes = %+v, %v; want only %s", quotes.Items, err, quote.ID)
+	}
+}
+
+func TestListCasesFollowsLink(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+	ada, uw := s.client("applicant-key"), s.client("underwriter-key")
+
+	for range 3 {
+		app := submit(t, ada, applicant(58, true), 600000)
+		s.work(t, func(ctx context.Context) error {
+			c, err := s.underwriter.ProcessApplication(ctx, app.ID)
+			if err == nil && c.Decision != core.UWDecisionReferred {
+				t.Fatalf("case decision = %q, want referred", c.Decision)
+			}
+			return err
+		})
+	}
+
+	var seen int
+	page := core.PageRequest{Limit: 2}
+	for {
+		cases, err := uw.ListCases(ctx, core.UWCaseFilter{Decision: core.UWDecisionReferred}, page)
+		if err != nil {
+			t.Fatalf("ListCases: %v", err)
+		}
+		seen += len(cases.Items)
+		if cases.NextCursor == "" {
+			break
+		}
+		page.Cursor = cases.NextCursor
+	}
+	if seen != 3 {
+		t.Errorf("listed %d cases over the pages, want 3", seen)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := newServer(t)
+	ctx := context.Background()
+
+	_, err := s.client("applicant-key").GetQuote(ctx, "01JQ0000000000000000000000")
+	var apiErr *client.Error
+	if !errors.Is(err, core.ErrNotFound) || !errors.As(err, &apiErr) || apiErr.Code == "" {
+		t.Errorf("missing quote: %v, want a coded ErrNotFound", err)
+	}
+
+	_, err = s.client("applicant-key").CreateQuote(ctx, core.QuoteInput{ProductSlug: "term-life-20", TermYears: 20})
+	if !errors.Is(err, core.ErrValidation) {
+		t.Errorf("invalid quote: %v, want ErrValidation", err)
+	}
+
+	_, err = s.client("applicant-key").ListCases(ctx, core.UWCaseFilter{}, core.PageRequest{})
+	if !errors.Is(err, core.ErrForbidden) {
+		t.Errorf("applicant listing cases: %v, want ErrForbidden", err)
+	}
+
+	_, err = s.client("stolen-key").ListProducts(ctx)
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("unknown key: %v, want ErrUnauthorized", err)
+	}
+}
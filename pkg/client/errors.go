@@ -0,0 +1,62 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+	"github.com/MrKriegler/go-insurance/pkg/problem"
+)
+
+// Error is a problem response (RFC 7807) from the API. It unwraps to the
+// core error the server mapped to its status, so errors.Is(err,
+// core.ErrNotFound) works as it does against the services.
+type Error struct {
+	problem.Problem
+	Header http.Header // e.g. Retry-After on 429, the current ETag on 412
+}
+
+func (e *Error) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%d %s", e.Status, e.Title)
+	}
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Title, e.Detail)
+}
+
+func (e *Error) Unwrap() error {
+	switch e.Status {
+	case http.StatusNotFound:
+		return core.ErrNotFound
+	case http.StatusBadRequest:
+		return core.ErrValidation
+	case http.StatusConflict:
+		if e.Title == "Invalid State" {
+			return core.ErrInvalidState
+		}
+		return core.ErrConflict
+	case http.StatusPreconditionFailed:
+		return core.ErrPreconditionFailed // Changed since the ETag was read; also an ErrConflict
+	case http.StatusUnauthorized:
+		return core.ErrUnauthorized
+	case http.StatusForbidden:
+		return core.ErrForbidden
+	}
+	return nil
+}
+
+// decodeError reads a problem response; other error bodies get a problem
+// built from the status alone.
+func decodeError(resp *http.Response) error {
+	e := &Error{Header: resp.Header}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(body, &e.Problem); err != nil || e.Status == 0 {
+		e.Problem = problem.Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(resp.StatusCode),
+			Status: resp.StatusCode,
+		}
+	}
+	return e
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// CreateOffer generates the offer for an approved application (staff only).
+func (c *Client) CreateOffer(ctx context.Context, applicationID string, opts ...CallOption) (core.Offer, error) {
+	var offer core.Offer
+	err := c.do(ctx, http.MethodPost, "/applications/"+url.PathEscape(applicationID)+"/offers", nil, nil, &offer, opts)
+	return offer, err
+}
+
+// GetOffer returns the offer with the given ID.
+func (c *Client) GetOffer(ctx context.Context, id string, opts ...CallOption) (core.Offer, error) {
+	var offer core.Offer
+	err := c.do(ctx, http.MethodGet, "/offers/"+url.PathEscape(id), nil, nil, &offer, opts)
+	return offer, err
+}
+
+// AcceptOffer accepts a pending offer; the policy is issued in the background.
+func (c *Client) AcceptOffer(ctx context.Context, id string, opts ...CallOption) (core.Offer, error) {
+	var offer core.Offer
+	err := c.do(ctx, http.MethodPost, "/offers/"+url.PathEscape(id)+":accept", nil, nil, &offer, opts)
+	return offer, err
+}
+
+// DeclineOffer declines a pending offer.
+func (c *Client) DeclineOffer(ctx context.Context, id string, opts ...CallOption) (core.Offer, error) {
+	var offer core.Offer
+	err := c.do(ctx, http.MethodPost, "/offers/"+url.PathEscape(id)+":decline", nil, nil, &offer, opts)
+	return offer, err
+}
+
+// ListOffers returns one page of offers, newest first (staff only).
+func (c *Client) ListOffers(ctx context.Context, filter core.OfferFilter, page core.PageRequest, opts ...CallOption) (core.Page[core.Offer], error) {
+	q := listQuery(page, filter.Created)
+	setIf(q, "status", string(filter.Status))
+	setIf(q, "product", filter.ProductSlug)
+	setIf(q, "application_id", filter.ApplicationID)
+
+	var offers core.Page[core.Offer]
+	err := c.do(ctx, http.MethodGet, "/offers", q, nil, &offers, opts)
+	return offers, err
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// listQuery builds the query parameters shared by every list endpoint.
+// rng bounds creation time (issue time for policies).
+func listQuery(page core.PageRequest, rng core.TimeRange) url.Values {
+	q := url.Values{}
+	if page.Limit > 0 {
+		q.Set("limit", strconv.Itoa(page.Limit))
+	}
+	setIf(q, "cursor", page.Cursor)
+	setTime(q, "from", rng.From)
+	setTime(q, "to", rng.To)
+	return q
+}
+
+func setIf(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+func setTime(q url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		q.Set(key, t.UTC().Format(time.RFC3339Nano))
+	}
+}
+
+// nextCursor reads the cursor from the rel="next" entry of a Link header,
+// which v1 lists that are bare arrays send instead of next_cursor.
+func nextCursor(link string) string {
+	for _, entry := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(entry, ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return ""
+		}
+		return u.Query().Get("cursor")
+	}
+	return ""
+}
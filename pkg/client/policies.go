@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// GetPolicy returns the policy with the given number.
+func (c *Client) GetPolicy(ctx context.Context, number string, opts ...CallOption) (core.Policy, error) {
+	var policy core.Policy
+	err := c.do(ctx, http.MethodGet, "/policies/"+url.PathEscape(number), nil, nil, &policy, opts)
+	return policy, err
+}
+
+// ListPolicies returns one page of policies, newest first (staff only).
+func (c *Client) ListPolicies(ctx context.Context, filter core.PolicyFilter, page core.PageRequest, opts ...CallOption) (core.Page[core.Policy], error) {
+	q := listQuery(page, filter.Issued)
+	setIf(q, "status", string(filter.Status))
+	setIf(q, "product", filter.ProductSlug)
+	setIf(q, "email", filter.InsuredEmail)
+	setIf(q, "application_id", filter.ApplicationID)
+
+	var policies core.Page[core.Policy]
+	err := c.do(ctx, http.MethodGet, "/policies", q, nil, &policies, opts)
+	return policies, err
+}
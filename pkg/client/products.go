@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// ListProducts returns the product catalog.
+func (c *Client) ListProducts(ctx context.Context, opts ...CallOption) ([]core.Product, error) {
+	var products []core.Product
+	err := c.do(ctx, http.MethodGet, "/products", nil, nil, &products, opts)
+	return products, err
+}
+
+// GetProduct returns the product with the given slug.
+func (c *Client) GetProduct(ctx context.Context, slug string, opts ...CallOption) (core.Product, error) {
+	var product core.Product
+	err := c.do(ctx, http.MethodGet, "/products/"+url.PathEscape(slug), nil, nil, &product, opts)
+	return product, err
+}
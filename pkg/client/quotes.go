@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// CreateQuote prices a quote and stores it.
+func (c *Client) CreateQuote(ctx context.Context, in core.QuoteInput, opts ...CallOption) (core.Quote, error) {
+	var quote core.Quote
+	err := c.do(ctx, http.MethodPost, "/quotes", nil, in, &quote, opts)
+	return quote, err
+}
+
+// GetQuote returns the quote with the given ID.
+func (c *Client) GetQuote(ctx context.Context, id string, opts ...CallOption) (core.Quote, error) {
+	var quote core.Quote
+	err := c.do(ctx, http.MethodGet, "/quotes/"+url.PathEscape(id), nil, nil, &quote, opts)
+	return quote, err
+}
+
+// ListQuotes returns one page of quotes, newest first (staff only).
+func (c *Client) ListQuotes(ctx context.Context, filter core.QuoteFilter, page core.PageRequest, opts ...CallOption) (core.Page[core.Quote], error) {
+	q := listQuery(page, filter.Created)
+	setIf(q, "status", string(filter.Status))
+	setIf(q, "product", filter.ProductSlug)
+
+	var quotes core.Page[core.Quote]
+	err := c.do(ctx, http.MethodGet, "/quotes", q, nil, &quotes, opts)
+	return quotes, err
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// Search finds applicants, applications and policies, best match first
+// (staff only). No kinds means all of them.
+func (c *Client) Search(ctx context.Context, text string, kinds []core.SearchKind, page core.PageRequest, opts ...CallOption) (core.Page[core.SearchHit], error) {
+	q := listQuery(page, core.TimeRange{})
+	q.Set("q", text)
+	if len(kinds) > 0 {
+		names := make([]string, len(kinds))
+		for i, k := range kinds {
+			names[i] = string(k)
+		}
+		q.Set("type", strings.Join(names, ","))
+	}
+
+	var hits core.Page[core.SearchHit]
+	err := c.do(ctx, http.MethodGet, "/search", q, nil, &hits, opts)
+	return hits, err
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/MrKriegler/go-insurance/internal/core"
+)
+
+// UWCase is an underwriting case as listed in the work queue, with the
+// queue tracking the server computes at read time.
+type UWCase struct {
+	core.UnderwritingCase
+	AgeSeconds  int64 `json:"age_seconds"`
+	SLABreached bool  `json:"sla_breached"`
+}
+
+// GetCase returns the underwriting case with the given ID (underwriters only).
+func (c *Client) GetCase(ctx context.Context, id string, opts ...CallOption) (core.UnderwritingCase, error) {
+	var uwCase core.UnderwritingCase
+	err := c.do(ctx, http.MethodGet, casePath(id), nil, nil, &uwCase, opts)
+	return uwCase, err
+}
+
+// ListCases returns one page of the work queue, oldest first. As in the
+// service, an empty Decision lists cases in any state.
+func (c *Client) ListCases(ctx context.Context, filter core.UWCaseFilter, page core.PageRequest, opts ...CallOption) (core.Page[UWCase], error) {
+	q := listQuery(page, core.TimeRange{From: filter.CreatedAfter, To: filter.CreatedBefore})
+	q.Set("status", string(filter.Decision)) // Sent even when empty; omitted means referred
+	setIf(q, "assignee", filter.Assignee)
+
+	// v1 sends the cases as an array and links the next page
+	var cases core.Page[UWCase]
+	var link string
+	opts = append(opts, func(c *call) { c.link = &link })
+	if err := c.do(ctx, http.MethodGet, "/underwriting/cases", q, nil, &cases.Items, opts); err != nil {
+		return cases, err
+	}
+	cases.NextCursor = nextCursor(link)
+	return cases, nil
+}
+
+// ClaimCase assigns an unclaimed referred case to the caller.
+func (c *Client) ClaimCase(ctx context.Context, id string, opts ...CallOption) (core.UnderwritingCase, error) {
+	var uwCase core.UnderwritingCase
+	err := c.do(ctx, http.MethodPost, casePath(id)+":claim", nil, nil, &uwCase, opts)
+	return uwCase, err
+}
+
+// AssignCase reassigns a referred case to another underwriter.
+func (c *Client) AssignCase(ctx context.Context, id, assignee string, opts ...CallOption) (core.UnderwritingCase, error) {
+	body := struct {
+		Assignee string `json:"assignee"`
+	}{assignee}
+
+	var uwCase core.UnderwritingCase
+	err := c.do(ctx, http.MethodPost, casePath(id)+":assign", nil, body, &uwCase, opts)
+	return uwCase, err
+}
+
+// DecideCase makes a manual decision. Approvals above the caller's authority
+// come back with Decision core.UWDecisionPendingApproval.
+func (c *Client) DecideCase(ctx context.Context, id string, in core.UWDecisionInput, opts ...CallOption) (core.UnderwritingCase, error) {
+	var uwCase core.UnderwritingCase
+	err := c.do(ctx, http.MethodPost, casePath(id)+":decide", nil, in, &uwCase, opts)
+	return uwCase, err
+}
+
+// ConfirmCase confirms or rejects a decision awaiting four-eyes approval.
+func (c *Client) ConfirmCase(ctx context.Context, id string, in core.UWConfirmInput, opts ...CallOption) (core.UnderwritingCase, error) {
+	var uwCase core.UnderwritingCase
+	err := c.do(ctx, http.MethodPost, casePath(id)+":confirm", nil, in, &uwCase, opts)
+	return uwCase, err
+}
+
+func casePath(id string) string {
+	return "/underwriting/cases/" + url.PathEscape(id)
+}
@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.3
+// source: insurance/v1/underwriting.proto
+
+package insurancev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	UnderwritingService_GetCase_FullMethodName     = "/insurance.v1.UnderwritingService/GetCase"
+	UnderwritingService_ListCases_FullMethodName   = "/insurance.v1.UnderwritingService/ListCases"
+	UnderwritingService_ClaimCase_FullMethodName   = "/insurance.v1.UnderwritingService/ClaimCase"
+	UnderwritingService_AssignCase_FullMethodName  = "/insurance.v1.UnderwritingService/AssignCase"
+	UnderwritingService_DecideCase_FullMethodName  = "/insurance.v1.UnderwritingService/DecideCase"
+	UnderwritingService_ConfirmCase_FullMethodName = "/insurance.v1.UnderwritingService/ConfirmCase"
+)
+
+// UnderwritingServiceClient is the client API for UnderwritingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UnderwritingServiceClient interface {
+	GetCase(ctx context.Context, in *GetCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error)
+	ListCases(ctx context.Context, in *ListCasesRequest, opts ...grpc.CallOption) (*ListCasesResponse, error)
+	ClaimCase(ctx context.Context, in *ClaimCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error)
+	AssignCase(ctx context.Context, in *AssignCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error)
+	DecideCase(ctx context.Context, in *DecideCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error)
+	ConfirmCase(ctx context.Context, in *ConfirmCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error)
+}
+
+type underwritingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUnderwritingServiceClient(cc grpc.ClientConnInterface) UnderwritingServiceClient {
+	return &underwritingServiceClient{cc}
+}
+
+func (c *underwritingServiceClient) GetCase(ctx context.Context, in *GetCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnderwritingCase)
+	err := c.cc.Invoke(ctx, UnderwritingService_GetCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *underwritingServiceClient) ListCases(ctx context.Context, in *ListCasesRequest, opts ...grpc.CallOption) (*ListCasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCasesResponse)
+	err := c.cc.Invoke(ctx, UnderwritingService_ListCases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *underwritingServiceClient) ClaimCase(ctx context.Context, in *ClaimCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnderwritingCase)
+	err := c.cc.Invoke(ctx, UnderwritingService_ClaimCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *underwritingServiceClient) AssignCase(ctx context.Context, in *AssignCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnderwritingCase)
+	err := c.cc.Invoke(ctx, UnderwritingService_AssignCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *underwritingServiceClient) DecideCase(ctx context.Context, in *DecideCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnderwritingCase)
+	err := c.cc.Invoke(ctx, UnderwritingService_DecideCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *underwritingServiceClient) ConfirmCase(ctx context.Context, in *ConfirmCaseRequest, opts ...grpc.CallOption) (*UnderwritingCase, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnderwritingCase)
+	err := c.cc.Invoke(ctx, UnderwritingService_ConfirmCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnderwritingServiceServer is the server API for UnderwritingService service.
+// All implementations must embed UnimplementedUnderwritingServiceServer
+// for forward compatibility.
+type UnderwritingServiceServer interface {
+	GetCase(context.Context, *GetCaseRequest) (*UnderwritingCase, error)
+	ListCases(context.Context, *ListCasesRequest) (*ListCasesResponse, error)
+	ClaimCase(context.Context, *ClaimCaseRequest) (*UnderwritingCase, error)
+	AssignCase(context.Context, *AssignCaseRequest) (*UnderwritingCase, error)
+	DecideCase(context.Context, *DecideCaseRequest) (*UnderwritingCase, error)
+	ConfirmCase(context.Context, *ConfirmCaseRequest) (*UnderwritingCase, error)
+	mustEmbedUnimplementedUnderwritingServiceServer()
+}
+
+// UnimplementedUnderwritingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedUnderwritingServiceServer struct{}
+
+func (UnimplementedUnderwritingServiceServer) GetCase(context.Context, *GetCaseRequest) (*UnderwritingCase, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCase not implemented")
+}
+func (UnimplementedUnderwritingServiceServer) ListCases(context.Context, *ListCasesRequest) (*ListCasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCases not implemented")
+}
+func (UnimplementedUnderwritingServiceServer) ClaimCase(context.Context, *ClaimCaseRequest) (*UnderwritingCase, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimCase not implemented")
+}
+func (UnimplementedUnderwritingServiceServer) AssignCase(context.Context, *AssignCaseRequest) (*UnderwritingCase, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignCase not implemented")
+}
+func (UnimplementedUnderwritingServiceServer) DecideCase(context.Context, *DecideCaseRequest) (*UnderwritingCase, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideCase not implemented")
+}
+func (UnimplementedUnderwritingServiceServer) ConfirmCase(context.Context, *ConfirmCaseRequest) (*UnderwritingCase, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmCase not implemented")
+}
+func (UnimplementedUnderwritingServiceServer) mustEmbedUnimplementedUnderwritingServiceServer() {}
+func (UnimplementedUnderwritingServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeUnderwritingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UnderwritingServiceServer will
+// result in compilation errors.
+type UnsafeUnderwritingServiceServer interface {
+	mustEmbedUnimplementedUnderwritingServiceServer()
+}
+
+func RegisterUnderwritingServiceServer(s grpc.ServiceRegistrar, srv UnderwritingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedUnderwritingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&UnderwritingService_ServiceDesc, srv)
+}
+
+func _UnderwritingService_GetCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnderwritingServiceServer).GetCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnderwritingService_GetCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnderwritingServiceServer).GetCase(ctx, req.(*GetCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnderwritingService_ListCases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnderwritingServiceServer).ListCases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnderwritingService_ListCases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnderwritingServiceServer).ListCases(ctx, req.(*ListCasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnderwritingService_ClaimCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnderwritingServiceServer).ClaimCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnderwritingService_ClaimCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnderwritingServiceServer).ClaimCase(ctx, req.(*ClaimCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnderwritingService_AssignCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnderwritingServiceServer).AssignCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnderwritingService_AssignCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnderwritingServiceServer).AssignCase(ctx, req.(*AssignCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnderwritingService_DecideCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnderwritingServiceServer).DecideCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnderwritingService_DecideCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnderwritingServiceServer).DecideCase(ctx, req.(*DecideCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UnderwritingService_ConfirmCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UnderwritingServiceServer).ConfirmCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnderwritingService_ConfirmCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UnderwritingServiceServer).ConfirmCase(ctx, req.(*ConfirmCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UnderwritingService_ServiceDesc is the grpc.ServiceDesc for UnderwritingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UnderwritingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "insurance.v1.UnderwritingService",
+	HandlerType: (*UnderwritingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCase",
+			Handler:    _UnderwritingService_GetCase_Handler,
+		},
+		{
+			MethodName: "ListCases",
+			Handler:    _UnderwritingService_ListCases_Handler,
+		},
+		{
+			MethodName: "ClaimCase",
+			Handler:    _UnderwritingService_ClaimCase_Handler,
+		},
+		{
+			MethodName: "AssignCase",
+			Handler:    _UnderwritingService_AssignCase_Handler,
+		},
+		{
+			MethodName: "DecideCase",
+			Handler:    _UnderwritingService_DecideCase_Handler,
+		},
+		{
+			MethodName: "ConfirmCase",
+			Handler:    _UnderwritingService_ConfirmCase_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "insurance/v1/underwriting.proto",
+}
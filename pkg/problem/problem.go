@@ -3,23 +3,49 @@ package problem
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
 )
 
+// TypeBase is the namespace of problem type URIs. Each problem's type is
+// TypeBase plus its code, e.g. TypeBase+"offer_not_pending", and is
+// documented in docs/problems.md. Codes are never renamed or reused.
+const TypeBase = "https://api.insurance.labs.iron-pig.com/problems/"
+
 type Problem struct {
-	Type     string `json:"type"`
-	Title    string `json:"title"`
-	Status   int    `json:"status"`
-	Detail   string `json:"detail,omitempty"`
-	Instance string `json:"instance,omitempty"`
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"` // ID of the request, as logged
+	Code     string       `json:"code,omitempty"`     // Last segment of Type, for switching on
+	Errors   []FieldError `json:"errors,omitempty"`   // Invalid fields of the request body
+}
+
+// FieldError is one invalid field of a request, located by a JSON Pointer
+// (RFC 6901) into the request body.
+type FieldError struct {
+	Pointer string `json:"pointer"`
+	Code    string `json:"code"`
+	Detail  string `json:"detail"`
+}
+
+// New returns a problem of the type named by code.
+func New(status int, code, title, detail string) Problem {
+	return Problem{Type: TypeBase + code, Title: title, Status: status, Detail: detail, Code: code}
+}
+
+// Write writes a problem response of the type named by code.
+func Write(w http.ResponseWriter, r *http.Request, status int, code, title, detail string) {
+	New(status, code, title, detail).Write(w, r)
 }
 
-func Write(w http.ResponseWriter, status int, title, detail string) {
+// Write writes p, with the request's ID as its instance.
+func (p Problem) Write(w http.ResponseWriter, r *http.Request) {
+	if p.Instance == "" && r != nil {
+		p.Instance = middleware.GetReqID(r.Context())
+	}
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(Problem{
-		Type:   "about:blank",
-		Title:  title,
-		Status: status,
-		Detail: detail,
-	})
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
 }